@@ -679,6 +679,9 @@ func buildGroupTransitions(d *DFA, P *partition, G group) *dfaTransitionTable {
 	Gtrans := newDFATransitionTable()
 
 	for s := range G.All() {
+		// States with no transitions (e.g., when the alphabet is empty) must still be partitioned.
+		Gtrans.Put(s, symboltable.NewRedBlack(cmpClassID, EqState))
+
 		if stab, ok := d.trans.Get(s); ok {
 			for cid, next := range stab.All() {
 				if rep := P.FindRep(next); rep != -1 {
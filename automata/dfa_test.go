@@ -676,6 +676,21 @@ func TestDFA_Minimize(t *testing.T) {
 					Add(3, 1, 0),
 			},
 		},
+		{
+			name: "NoTransitions",
+			d: &DFA{
+				start:  0,
+				final:  NewStates(0),
+				ranges: newRangeMapping(nil),
+				trans:  newDFATransitionTable(),
+			},
+			expectedDFA: &DFA{
+				start:  0,
+				final:  NewStates(0),
+				ranges: newRangeMapping(nil),
+				trans:  newDFATransitionTable(),
+			},
+		},
 	}
 
 	for _, tc := range tests {
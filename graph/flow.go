@@ -176,6 +176,13 @@ func (g *FlowNetwork) Edges() []FlowEdge {
 	return edges
 }
 
+// MaxFlow calculates the maximum flow from a source vertex (s) to a sink vertex (t)
+// and the corresponding minimum s-t cut of the network.
+// The flows on the edges of the network are not modified.
+func (g *FlowNetwork) MaxFlow(s, t int, strategy FlowStrategy) *MaxFlow {
+	return newMaxFlow(g, s, t, strategy)
+}
+
 // DOT generates a DOT representation of the graph.
 func (g *FlowNetwork) DOT() string {
 	graph := dot.NewGraph(true, true, false, "", "", "", dot.StyleSolid, dot.ShapeCircle)
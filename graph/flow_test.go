@@ -204,3 +204,147 @@ func TestFlowNetwork(t *testing.T) {
 		})
 	}
 }
+
+func TestFlowNetwork_MaxFlow(t *testing.T) {
+	tests := []struct {
+		name             string
+		V                int
+		edges            []FlowEdge
+		s, t             int
+		expectedValue    float64
+		expectedS        []int
+		expectedT        []int
+		expectedCutEdges []FlowEdge
+	}{
+		{
+			name:             "NoPath",
+			V:                3,
+			edges:            []FlowEdge{{0, 1, 1.00, 0.00}},
+			s:                0,
+			t:                2,
+			expectedValue:    0,
+			expectedS:        []int{0, 1},
+			expectedT:        []int{2},
+			expectedCutEdges: []FlowEdge{},
+		},
+		{
+			name:             "SameSourceAndSink",
+			V:                2,
+			edges:            []FlowEdge{{0, 1, 1.00, 0.00}},
+			s:                0,
+			t:                0,
+			expectedValue:    0,
+			expectedS:        []int{0, 1},
+			expectedT:        []int{},
+			expectedCutEdges: []FlowEdge{},
+		},
+		{
+			name: "Flow",
+			V:    7,
+			edges: []FlowEdge{
+				{0, 1, 2.00, 2.00},
+				{0, 2, 3.00, 1.00},
+				{1, 3, 3.00, 2.00},
+				{1, 4, 1.00, 0.00},
+				{2, 3, 1.00, 0.00},
+				{2, 4, 1.00, 1.00},
+				{3, 5, 2.00, 2.00},
+				{4, 5, 3.00, 1.00},
+			},
+			s:             0,
+			t:             5,
+			expectedValue: 4.00,
+			expectedS:     []int{0, 2},
+			expectedT:     []int{1, 3, 4, 5, 6},
+			expectedCutEdges: []FlowEdge{
+				{0, 1, 2.00, 2.00},
+				{2, 3, 1.00, 1.00},
+				{2, 4, 1.00, 1.00},
+			},
+		},
+		{
+			name: "Tiny",
+			V:    6,
+			edges: []FlowEdge{
+				{0, 1, 10.0, 0.00},
+				{0, 2, 10.0, 0.00},
+				{1, 2, 2.00, 0.00},
+				{1, 3, 4.00, 0.00},
+				{1, 4, 8.00, 0.00},
+				{2, 4, 9.00, 0.00},
+				{3, 5, 10.0, 0.00},
+				{4, 3, 6.00, 0.00},
+				{4, 5, 10.0, 0.00},
+			},
+			s:             0,
+			t:             5,
+			expectedValue: 19.0,
+			expectedS:     []int{0, 2},
+			expectedT:     []int{1, 3, 4, 5},
+			expectedCutEdges: []FlowEdge{
+				{0, 1, 10.0, 10.0},
+				{2, 4, 9.00, 9.00},
+			},
+		},
+	}
+
+	strategies := []struct {
+		name     string
+		strategy FlowStrategy
+	}{
+		{"EdmondsKarp", EdmondsKarp},
+		{"Dinic", Dinic},
+	}
+
+	for _, tc := range tests {
+		for _, s := range strategies {
+			t.Run(tc.name+"_"+s.name, func(t *testing.T) {
+				g := NewFlowNetwork(tc.V, tc.edges...)
+				edges := g.Edges()
+
+				mf := g.MaxFlow(tc.s, tc.t, s.strategy)
+
+				// The flow network must not be modified.
+				assert.Equal(t, edges, g.Edges())
+
+				assert.InDelta(t, tc.expectedValue, mf.Value(), float64Epsilon)
+
+				S, T := mf.Cut()
+				assert.Equal(t, tc.expectedS, S)
+				assert.Equal(t, tc.expectedT, T)
+
+				for _, v := range tc.expectedS {
+					assert.True(t, mf.InCut(v))
+				}
+
+				for _, v := range tc.expectedT {
+					assert.False(t, mf.InCut(v))
+				}
+
+				assert.Equal(t, tc.expectedCutEdges, mf.CutEdges())
+
+				// Verify the capacity constraints and the flow conservation.
+				excess := make([]float64, tc.V)
+				for _, e := range mf.Edges() {
+					assert.GreaterOrEqual(t, e.Flow(), 0.0)
+					assert.LessOrEqual(t, e.Flow(), e.Capacity())
+					excess[e.From()] -= e.Flow()
+					excess[e.To()] += e.Flow()
+				}
+
+				for v, x := range excess {
+					switch {
+					case tc.s == tc.t:
+						assert.InDelta(t, 0, x, float64Epsilon)
+					case v == tc.s:
+						assert.InDelta(t, -tc.expectedValue, x, float64Epsilon)
+					case v == tc.t:
+						assert.InDelta(t, tc.expectedValue, x, float64Epsilon)
+					default:
+						assert.InDelta(t, 0, x, float64Epsilon)
+					}
+				}
+			})
+		}
+	}
+}
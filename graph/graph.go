@@ -9,11 +9,14 @@
 package graph
 
 import (
+	"cmp"
 	"math"
+	"slices"
 
 	"github.com/moorara/algo/generic"
 	"github.com/moorara/algo/heap"
 	"github.com/moorara/algo/list"
+	"github.com/moorara/algo/unionfind"
 )
 
 const (
@@ -49,6 +52,53 @@ const (
 	Maximize
 )
 
+// FlowStrategy is the strategy for computing the maximum flow of a flow network.
+type FlowStrategy int
+
+const (
+	// EdmondsKarp is the Ford-Fulkerson method with shortest augmenting paths found by breadth-first search.
+	EdmondsKarp FlowStrategy = iota
+	// Dinic is Dinic's algorithm with level graphs and blocking flows.
+	Dinic
+)
+
+// SpanningTreeStrategy is the strategy for calculating the minimum spanning tree of a weighted undirected graph.
+type SpanningTreeStrategy int
+
+const (
+	// Prim is Prim's algorithm, which grows a tree from a vertex one edge at a time.
+	Prim SpanningTreeStrategy = iota
+	// Kruskal is Kruskal's algorithm, which adds edges in order of weight using a union-find.
+	Kruskal
+	// Boruvka is Borůvka's algorithm, which adds the cheapest edge leaving every component in each phase.
+	Boruvka
+)
+
+// ShortestPathStrategy is the strategy for calculating the shortest path tree of a weighted directed graph.
+type ShortestPathStrategy int
+
+const (
+	// Automatic picks Acyclic for a DAG, Dijkstra if no edge weight is negative, and BellmanFord otherwise.
+	Automatic ShortestPathStrategy = iota
+	// Dijkstra is Dijkstra's algorithm, which requires all edge weights to be non-negative.
+	Dijkstra
+	// BellmanFord is the queue-based Bellman-Ford algorithm, which allows negative edge weights and detects negative cycles.
+	BellmanFord
+	// Acyclic relaxes vertices in topological order, which allows negative edge weights but requires a DAG.
+	// If the graph has a directed cycle, BellmanFord is used instead.
+	Acyclic
+)
+
+// AllPairsStrategy is the strategy for calculating the shortest paths between all pairs of vertices.
+type AllPairsStrategy int
+
+const (
+	// FloydWarshall is the Floyd-Warshall algorithm, which is suitable for dense graphs.
+	FloydWarshall AllPairsStrategy = iota
+	// Johnson is Johnson's algorithm, which is suitable for sparse graphs.
+	Johnson
+)
+
 // Visitors provides a method for visiting vertices and edges when traversing a graph.
 // VertexPreOrder is called when visiting a vertex in a graph.
 // VertexPostOrder is called when visiting a vertex in a graph.
@@ -159,6 +209,236 @@ func (c *ConnectedComponents) Components() [][]int {
 	return comps
 }
 
+// Bipartite is used for determining if an undirected graph is bipartite (two-colorable).
+// A graph is bipartite if its vertices can be divided into two sets such that every edge connects a vertex in one set to a vertex in the other set.
+// A graph is bipartite if and only if it has no odd-length cycle.
+type Bipartite struct {
+	visited []bool
+	edgeTo  []int
+	color   []bool
+	cycle   list.Stack[int] // an odd-length cycle (if any)
+}
+
+func newBipartite(g *Undirected) *Bipartite {
+	b := &Bipartite{
+		visited: make([]bool, g.V()),
+		edgeTo:  make([]int, g.V()),
+		color:   make([]bool, g.V()),
+	}
+
+	for v := 0; v < g.V(); v++ {
+		if !b.visited[v] && b.cycle == nil {
+			b.dfs(g, v)
+		}
+	}
+
+	return b
+}
+
+func (b *Bipartite) dfs(g *Undirected, v int) {
+	b.visited[v] = true
+	for _, w := range g.adj[v] {
+		if b.cycle != nil { // short circuit if an odd cycle already found
+			return
+		} else if !b.visited[w] {
+			b.edgeTo[w] = v
+			b.color[w] = !b.color[v]
+			b.dfs(g, w)
+		} else if b.color[w] == b.color[v] { // odd cycle detected
+			b.cycle = list.NewStack[int](listNodeSize, nil)
+			b.cycle.Push(w)
+			for x := v; x != w; x = b.edgeTo[x] {
+				b.cycle.Push(x)
+			}
+			b.cycle.Push(w)
+		}
+	}
+}
+
+// IsBipartite returns true if the graph is bipartite.
+func (b *Bipartite) IsBipartite() bool {
+	return b.cycle == nil
+}
+
+// Color returns the side of a vertex in a two-coloring of the graph.
+// Two adjacent vertices always have different colors.
+// If the graph is not bipartite, the second return value will be false.
+func (b *Bipartite) Color(v int) (bool, bool) {
+	if b.cycle != nil {
+		return false, false
+	}
+
+	return b.color[v], true
+}
+
+// OddCycle returns an odd-length cycle as a witness that the graph is not bipartite.
+// The first and last vertices of the cycle are the same.
+// If the graph is bipartite, the second return value will be false.
+func (b *Bipartite) OddCycle() ([]int, bool) {
+	if b.cycle == nil {
+		return nil, false
+	}
+
+	cycle := make([]int, 0)
+	for !b.cycle.IsEmpty() {
+		v, _ := b.cycle.Pop()
+		cycle = append(cycle, v)
+	}
+
+	// Keep the cycle for subsequent calls
+	for i := len(cycle) - 1; i >= 0; i-- {
+		b.cycle.Push(cycle[i])
+	}
+
+	return cycle, true
+}
+
+// Biconnected is used for determining the biconnectivity of an undirected graph.
+// An articulation point (cut vertex) is a vertex whose removal increases the number of connected components.
+// A bridge (cut edge) is an edge whose removal increases the number of connected components.
+// A biconnected component (block) is a maximal set of edges such that any two edges in the set lie on a common simple cycle.
+type Biconnected struct {
+	counter     int
+	pre         []int // pre[v] = order in which dfs examines v
+	low         []int // low[v] = lowest preorder of any vertex connected to v by a back edge from the subtree rooted at v
+	articulated []bool
+	bridges     [][2]int
+	blocks      [][][2]int
+	stack       list.Stack[[2]int]
+}
+
+func newBiconnected(g *Undirected) *Biconnected {
+	b := &Biconnected{
+		counter:     0,
+		pre:         make([]int, g.V()),
+		low:         make([]int, g.V()),
+		articulated: make([]bool, g.V()),
+		bridges:     make([][2]int, 0),
+		blocks:      make([][][2]int, 0),
+		stack:       list.NewStack[[2]int](listNodeSize, nil),
+	}
+
+	for v := 0; v < g.V(); v++ {
+		b.pre[v] = -1
+	}
+
+	for v := 0; v < g.V(); v++ {
+		if b.pre[v] == -1 {
+			b.dfs(g, v, -1)
+		}
+	}
+
+	return b
+}
+
+// Tarjan's algorithm for finding articulation points, bridges and biconnected components in one depth-first search.
+func (b *Biconnected) dfs(g *Undirected, u, parent int) {
+	b.pre[u] = b.counter
+	b.low[u] = b.counter
+	b.counter++
+
+	children := 0
+	skipped := false // only one edge to parent is the tree edge, others are parallel edges
+
+	for _, w := range g.adj[u] {
+		if w == parent && !skipped {
+			skipped = true
+			continue
+		}
+
+		if b.pre[w] == -1 {
+			children++
+			b.stack.Push([2]int{u, w})
+			b.dfs(g, w, u)
+			b.low[u] = min(b.low[u], b.low[w])
+
+			if b.low[w] > b.pre[u] {
+				b.bridges = append(b.bridges, [2]int{u, w})
+			}
+
+			if b.low[w] >= b.pre[u] {
+				if parent != -1 {
+					b.articulated[u] = true
+				}
+
+				block := make([][2]int, 0)
+				for {
+					e, _ := b.stack.Pop()
+					block = append(block, e)
+					if e == [2]int{u, w} {
+						break
+					}
+				}
+				b.blocks = append(b.blocks, block)
+			}
+		} else if b.pre[w] < b.pre[u] { // back edge
+			b.stack.Push([2]int{u, w})
+			b.low[u] = min(b.low[u], b.pre[w])
+		}
+	}
+
+	// The root of a DFS tree is an articulation point if it has more than one child
+	if parent == -1 && children > 1 {
+		b.articulated[u] = true
+	}
+}
+
+// IsArticulation returns true if a vertex is an articulation point.
+func (b *Biconnected) IsArticulation(v int) bool {
+	return b.articulated[v]
+}
+
+// ArticulationPoints returns all articulation points in ascending order.
+func (b *Biconnected) ArticulationPoints() []int {
+	points := make([]int, 0)
+	for v, ok := range b.articulated {
+		if ok {
+			points = append(points, v)
+		}
+	}
+
+	return points
+}
+
+// Bridges returns all bridges.
+func (b *Biconnected) Bridges() [][2]int {
+	return b.bridges
+}
+
+// Components returns the edges of every biconnected component.
+// Every edge, except self-loops, belongs to exactly one biconnected component.
+func (b *Biconnected) Components() [][][2]int {
+	return b.blocks
+}
+
+// BlockCutTree returns the block-cut tree (forest) of the graph.
+// The block-cut tree has a vertex for each biconnected component and a vertex for each articulation point,
+// and an edge between a component and each articulation point that belongs to it.
+// Vertex i is the ith component returned by Components and
+// vertex len(Components()) + j is the jth articulation point returned by ArticulationPoints.
+func (b *Biconnected) BlockCutTree() *Undirected {
+	points := b.ArticulationPoints()
+	index := make(map[int]int, len(points)) // articulation point --> vertex in the block-cut tree
+	for j, v := range points {
+		index[v] = len(b.blocks) + j
+	}
+
+	t := NewUndirected(len(b.blocks) + len(points))
+	for i, block := range b.blocks {
+		added := make(map[int]bool)
+		for _, e := range block {
+			for _, v := range e {
+				if j, ok := index[v]; ok && !added[v] {
+					added[v] = true
+					t.AddEdge(i, j)
+				}
+			}
+		}
+	}
+
+	return t
+}
+
 // StronglyConnectedComponents is used for determining all the strongly connected components in a directed graph.
 // A strongly connected component is a maximal set of strongly connected vertices
 // (every two vertices are strongly connected with paths in both directions between them).
@@ -282,85 +562,197 @@ func (t *Topological) Rank(v int) (int, bool) {
 	return t.rank[v], true
 }
 
-// MinimumSpanningTree is used for calculating the minimum spanning trees (forest) of a weighted undirected graph.
+// SpanningTree is used for calculating the minimum or maximum spanning trees (forest) of a weighted undirected graph.
 // Given an edge-weighted undirected graph G with positive edge weights, an MST of G is a sub-graph T that is:
 //
 //	Tree: connected and acyclic
 //	Spanning: includes all of the vertices
 //	Minimum: sum of the edge wights are minimum
-type MinimumSpanningTree struct {
-	visited []bool                         // visited[v] = true if v on tree, false otherwise
-	edgeTo  []UndirectedEdge               // edgeTo[v] = shortest edge from tree vertex to non-tree vertex
-	distTo  []float64                      // distTo[v] = weight of shortest such edge (edgeTo[v].weight())
-	pq      heap.IndexedHeap[float64, any] // indexed priority queue of vertices connected by an edge to tree
+//
+// If the graph is not connected, a minimum spanning forest (a minimum spanning tree for each connected component) is calculated.
+// A maximum spanning tree (forest) is calculated in the same way by maximizing the sum of the edge weights instead.
+type SpanningTree struct {
+	v     int
+	edges []UndirectedEdge // edges in the spanning forest
 }
 
-func newMinimumSpanningTree(g *WeightedUndirected) *MinimumSpanningTree {
-	mst := &MinimumSpanningTree{
-		visited: make([]bool, g.V()),
-		edgeTo:  make([]UndirectedEdge, g.V()),
-		distTo:  make([]float64, g.V()),
-		pq:      heap.NewIndexedBinary[float64, any](g.V(), generic.NewCompareFunc[float64](), nil),
-	}
+// MinimumSpanningTree is the former name of SpanningTree, kept for backward compatibility.
+//
+// Deprecated: Use SpanningTree instead.
+type MinimumSpanningTree = SpanningTree
 
-	for v := 0; v < g.V(); v++ {
-		mst.distTo[v] = math.MaxFloat64
+func newSpanningTree(g *WeightedUndirected, strategy SpanningTreeStrategy, optimization OptimizationStrategy) *SpanningTree {
+	mst := &SpanningTree{
+		v:     g.V(),
+		edges: make([]UndirectedEdge, 0),
 	}
 
-	// run from each vertex to find minimum spanning forest
-	for v := 0; v < g.V(); v++ {
-		if !mst.visited[v] {
-			mst.prim(g, v)
+	// key is the value that is minimized for an edge.
+	key := func(e UndirectedEdge) float64 {
+		switch optimization {
+		case Minimize:
+			return e.Weight()
+		case Maximize:
+			return -e.Weight()
+		default:
+			return 0
 		}
 	}
 
+	switch strategy {
+	case Prim:
+		mst.prim(g, key)
+	case Kruskal:
+		mst.kruskal(g, key)
+	case Boruvka:
+		mst.boruvka(g, key)
+	}
+
 	return mst
 }
 
 // Prim's algorithm (eager version) for calculating minimum spanning tree.
-func (mst *MinimumSpanningTree) prim(g *WeightedUndirected, s int) {
-	mst.distTo[s] = 0.0
-	mst.pq.Insert(s, mst.distTo[s], nil)
+// It runs from each unvisited vertex to find a minimum spanning forest.
+func (mst *SpanningTree) prim(g *WeightedUndirected, key func(UndirectedEdge) float64) {
+	visited := make([]bool, g.V())          // visited[v] = true if v on tree, false otherwise
+	edgeTo := make([]UndirectedEdge, g.V()) // edgeTo[v] = shortest edge from tree vertex to non-tree vertex
+	distTo := make([]float64, g.V())        // distTo[v] = key of shortest such edge
+	hasEdge := make([]bool, g.V())          // hasEdge[v] = true if edgeTo[v] is set
+	pq := heap.NewIndexedBinary[float64, any](g.V(), generic.NewCompareFunc[float64](), nil)
 
-	for !mst.pq.IsEmpty() {
-		v, _, _, _ := mst.pq.Delete()
-		mst.visited[v] = true
+	for v := 0; v < g.V(); v++ {
+		distTo[v] = math.MaxFloat64
+	}
 
-		for _, e := range g.Adj(v) {
-			w := e.Other(v)
-			if mst.visited[w] {
-				continue
-			}
+	for s := 0; s < g.V(); s++ {
+		if visited[s] {
+			continue
+		}
 
-			if e.Weight() < mst.distTo[w] {
-				mst.edgeTo[w] = e
-				mst.distTo[w] = e.Weight()
+		distTo[s] = -math.MaxFloat64
+		pq.Insert(s, distTo[s], nil)
 
-				if mst.pq.ContainsIndex(w) {
-					mst.pq.ChangeKey(w, mst.distTo[w])
-				} else {
-					mst.pq.Insert(w, mst.distTo[w], nil)
+		for !pq.IsEmpty() {
+			v, _, _, _ := pq.Delete()
+			visited[v] = true
+
+			for _, e := range g.Adj(v) {
+				w := e.Other(v)
+				if visited[w] {
+					continue
+				}
+
+				if k := key(e); k < distTo[w] {
+					edgeTo[w] = e
+					distTo[w] = k
+					hasEdge[w] = true
+
+					if pq.ContainsIndex(w) {
+						pq.ChangeKey(w, distTo[w])
+					} else {
+						pq.Insert(w, distTo[w], nil)
+					}
 				}
 			}
 		}
 	}
+
+	for v, e := range edgeTo {
+		if hasEdge[v] {
+			mst.edges = append(mst.edges, e)
+		}
+	}
 }
 
-// Edges returns the edges in a minimum spanning tree (or forest).
-func (mst *MinimumSpanningTree) Edges() []UndirectedEdge {
-	zero := UndirectedEdge{}
-	edges := make([]UndirectedEdge, 0)
-	for _, e := range mst.edgeTo {
-		if e != zero {
-			edges = append(edges, e)
+// Kruskal's algorithm for calculating minimum spanning tree.
+// Edges are considered in ascending order of their keys, and an edge is added if it does not create a cycle.
+func (mst *SpanningTree) kruskal(g *WeightedUndirected, key func(UndirectedEdge) float64) {
+	edges := g.Edges()
+	slices.SortStableFunc(edges, func(a, b UndirectedEdge) int {
+		return cmp.Compare(key(a), key(b))
+	})
+
+	uf := unionfind.NewWeightedQuickUnion(g.V())
+	for _, e := range edges {
+		if len(mst.edges) == g.V()-1 {
+			break
+		}
+
+		v := e.Either()
+		w := e.Other(v)
+		if !uf.IsConnected(v, w) {
+			uf.Union(v, w)
+			mst.edges = append(mst.edges, e)
 		}
 	}
+}
 
-	return edges
+// Borůvka's algorithm for calculating minimum spanning tree.
+// In each phase, the cheapest edge leaving every component is added, at least halving the number of components.
+func (mst *SpanningTree) boruvka(g *WeightedUndirected, key func(UndirectedEdge) float64) {
+	edges := g.Edges()
+
+	// Ties are broken by edge index, so all components agree on a single total order of edges.
+	less := func(i, j int) bool {
+		if ki, kj := key(edges[i]), key(edges[j]); ki != kj {
+			return ki < kj
+		}
+		return i < j
+	}
+
+	uf := unionfind.NewWeightedQuickUnion(g.V())
+	cheapest := make([]int, g.V()) // cheapest[c] = index of the cheapest edge leaving component c
+
+	for added := true; added; {
+		added = false
+
+		for c := range cheapest {
+			cheapest[c] = -1
+		}
+
+		for i, e := range edges {
+			v := e.Either()
+			w := e.Other(v)
+
+			cv, _ := uf.Find(v)
+			cw, _ := uf.Find(w)
+			if cv == cw {
+				continue
+			}
+
+			if cheapest[cv] == -1 || less(i, cheapest[cv]) {
+				cheapest[cv] = i
+			}
+
+			if cheapest[cw] == -1 || less(i, cheapest[cw]) {
+				cheapest[cw] = i
+			}
+		}
+
+		for _, i := range cheapest {
+			if i == -1 {
+				continue
+			}
+
+			e := edges[i]
+			v := e.Either()
+			w := e.Other(v)
+			if !uf.IsConnected(v, w) {
+				uf.Union(v, w)
+				mst.edges = append(mst.edges, e)
+				added = true
+			}
+		}
+	}
 }
 
-// Weight returns the sum of the edge weights in a minimum spanning tree (or forest).
-func (mst *MinimumSpanningTree) Weight() float64 {
+// Edges returns the edges in a spanning tree (or forest).
+func (mst *SpanningTree) Edges() []UndirectedEdge {
+	return mst.edges
+}
+
+// Weight returns the sum of the edge weights in a spanning tree (or forest).
+func (mst *SpanningTree) Weight() float64 {
 	var weight float64
 	for _, e := range mst.Edges() {
 		weight += e.Weight()
@@ -369,66 +761,442 @@ func (mst *MinimumSpanningTree) Weight() float64 {
 	return weight
 }
 
+// Count returns the number of trees in a spanning forest.
+// This is the same as the number of connected components in the graph.
+func (mst *SpanningTree) Count() int {
+	return mst.v - len(mst.edges)
+}
+
+// Trees returns the edges of each tree in a spanning forest.
+// Trees are ordered by their smallest vertex, and an isolated vertex is a tree with no edges.
+func (mst *SpanningTree) Trees() [][]UndirectedEdge {
+	uf := unionfind.NewWeightedQuickUnion(mst.v)
+	for _, e := range mst.edges {
+		v := e.Either()
+		uf.Union(v, e.Other(v))
+	}
+
+	index := make(map[int]int) // component id --> tree index
+	trees := make([][]UndirectedEdge, 0)
+	for v := 0; v < mst.v; v++ {
+		c, _ := uf.Find(v)
+		if _, ok := index[c]; !ok {
+			index[c] = len(trees)
+			trees = append(trees, make([]UndirectedEdge, 0))
+		}
+	}
+
+	for _, e := range mst.edges {
+		c, _ := uf.Find(e.Either())
+		trees[index[c]] = append(trees[index[c]], e)
+	}
+
+	return trees
+}
+
 // ShortestPathTree is used for calculating the shortest path tree of a weighted directed graph.
 // A shortest path from vertex s to vertex t in a weighted directed graph is a directed path from s to t such that no other path has a lower weight.
+//
+// If a negative cycle is reachable from the source vertex, shortest paths are not well-defined.
+// Only the Bellman-Ford algorithm detects negative cycles.
 type ShortestPathTree struct {
-	edgeTo []DirectedEdge                 // edgeTo[v] = last edge on shortest path s->v
-	distTo []float64                      // distTo[v] = distance of shortest path s->v
-	pq     heap.IndexedHeap[float64, any] // indexed priority queue of vertices
+	edgeTo []DirectedEdge // edgeTo[v] = last edge on shortest path s->v
+	distTo []float64      // distTo[v] = distance of shortest path s->v
+	cycle  []DirectedEdge // a negative cycle reachable from s (if any)
 }
 
-func newShortestPathTree(g *WeightedDirected, s int) *ShortestPathTree {
+func newShortestPathTree(g *WeightedDirected, s int, strategy ShortestPathStrategy) *ShortestPathTree {
 	spt := &ShortestPathTree{
 		edgeTo: make([]DirectedEdge, g.V()),
 		distTo: make([]float64, g.V()),
-		pq:     heap.NewIndexedBinary[float64, any](g.V(), generic.NewCompareFunc[float64](), nil),
 	}
 
 	for v := 0; v < g.V(); v++ {
 		spt.distTo[v] = math.MaxFloat64
 	}
 
-	spt.dijkstra(g, s)
+	switch strategy {
+	case Automatic:
+		if order, ok := g.Topological().Order(); ok {
+			spt.acyclic(g, s, order)
+		} else if g.hasNegativeWeight() {
+			spt.bellmanFord(g, s)
+		} else {
+			spt.dijkstra(g, s, nil)
+		}
+	case Dijkstra:
+		spt.dijkstra(g, s, nil)
+	case BellmanFord:
+		spt.bellmanFord(g, s)
+	case Acyclic:
+		if order, ok := g.Topological().Order(); ok {
+			spt.acyclic(g, s, order)
+		} else {
+			spt.bellmanFord(g, s)
+		}
+	}
 
 	return spt
 }
 
 // Dijkstra's algorithm (eager version) for calculating shortest path tree.
-func (spt *ShortestPathTree) dijkstra(g *WeightedDirected, s int) {
+// If h is not nil, edge weights are reweighted by vertex potentials as w(v,w) + h[v] - h[w].
+func (spt *ShortestPathTree) dijkstra(g *WeightedDirected, s int, h []float64) {
+	pq := heap.NewIndexedBinary[float64, any](g.V(), generic.NewCompareFunc[float64](), nil)
+
 	spt.distTo[s] = 0.0
-	spt.pq.Insert(s, spt.distTo[s], nil)
+	pq.Insert(s, spt.distTo[s], nil)
 
-	for !spt.pq.IsEmpty() {
-		v, _, _, _ := spt.pq.Delete()
+	for !pq.IsEmpty() {
+		v, _, _, _ := pq.Delete()
 
 		// Relaxing edges
 		for _, e := range g.Adj(v) {
 			v, w := e.From(), e.To()
-			if dist := spt.distTo[v] + e.Weight(); dist < spt.distTo[w] {
+
+			weight := e.Weight()
+			if h != nil {
+				weight += h[v] - h[w]
+			}
+
+			if dist := spt.distTo[v] + weight; dist < spt.distTo[w] {
 				spt.edgeTo[w] = e
 				spt.distTo[w] = dist
 
-				if spt.pq.ContainsIndex(w) {
-					spt.pq.ChangeKey(w, spt.distTo[w])
+				if pq.ContainsIndex(w) {
+					pq.ChangeKey(w, spt.distTo[w])
 				} else {
-					spt.pq.Insert(w, spt.distTo[w], nil)
+					pq.Insert(w, spt.distTo[w], nil)
 				}
 			}
 		}
 	}
 }
 
+// Bellman-Ford algorithm (queue-based version) for calculating shortest path tree.
+// Only the vertices whose distance changed in the previous pass are relaxed in the next pass.
+func (spt *ShortestPathTree) bellmanFord(g *WeightedDirected, s int) {
+	queue := list.NewQueue[int](listNodeSize, nil)
+	onQueue := make([]bool, g.V())
+
+	spt.distTo[s] = 0.0
+	queue.Enqueue(s)
+	onQueue[s] = true
+
+	for cost := 0; !queue.IsEmpty(); {
+		v, _ := queue.Dequeue()
+		onQueue[v] = false
+
+		// Relaxing edges
+		for _, e := range g.Adj(v) {
+			v, w := e.From(), e.To()
+			if dist := spt.distTo[v] + e.Weight(); dist < spt.distTo[w] {
+				spt.edgeTo[w] = e
+				spt.distTo[w] = dist
+
+				if !onQueue[w] {
+					queue.Enqueue(w)
+					onQueue[w] = true
+				}
+			}
+
+			// Check periodically for a negative cycle in the shortest path tree
+			if cost++; cost%g.V() == 0 {
+				if spt.cycle = findNegativeCycle(spt.edgeTo); spt.cycle != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Relaxing vertices in topological order for calculating shortest path tree of a DAG.
+func (spt *ShortestPathTree) acyclic(g *WeightedDirected, s int, order []int) {
+	spt.distTo[s] = 0.0
+
+	for _, v := range order {
+		if spt.distTo[v] == math.MaxFloat64 {
+			continue
+		}
+
+		// Relaxing edges
+		for _, e := range g.Adj(v) {
+			w := e.To()
+			if dist := spt.distTo[v] + e.Weight(); dist < spt.distTo[w] {
+				spt.edgeTo[w] = e
+				spt.distTo[w] = dist
+			}
+		}
+	}
+}
+
 // PathTo returns shortest path from the source vertex (s) to vertex (v).
 // The second return value is distance from the source vertex (s) to vertex (v).
-// If no such path exists, the last return value will be false.
+// If no such path exists or a negative cycle is reachable from the source vertex, the last return value will be false.
 func (spt *ShortestPathTree) PathTo(v int) ([]DirectedEdge, float64, bool) {
-	if spt.distTo[v] == math.MaxFloat64 {
+	if spt.cycle != nil || spt.distTo[v] == math.MaxFloat64 {
+		return nil, -1, false
+	}
+
+	return pathTo(spt.edgeTo, v), spt.distTo[v], true
+}
+
+// NegativeCycle returns a negative cycle reachable from the source vertex (s).
+// The edges are in the order of the directed cycle.
+// If no negative cycle exists, the second return value will be false.
+func (spt *ShortestPathTree) NegativeCycle() ([]DirectedEdge, bool) {
+	if spt.cycle == nil {
+		return nil, false
+	}
+
+	return spt.cycle, true
+}
+
+// LongestPathTree is used for calculating the longest path tree of a weighted directed acyclic graph (DAG).
+// A longest path from vertex s to vertex t in a weighted DAG is a directed path from s to t such that no other path has a higher weight.
+//
+// Longest paths in a DAG are the basis of the critical path method for scheduling jobs with precedence constraints.
+// Finding longest paths in a graph with cycles is NP-hard, so the graph must be a DAG.
+type LongestPathTree struct {
+	edgeTo []DirectedEdge // edgeTo[v] = last edge on longest path s->v
+	distTo []float64      // distTo[v] = distance of longest path s->v
+}
+
+func newLongestPathTree(g *WeightedDirected, s int) *LongestPathTree {
+	lpt := &LongestPathTree{
+		edgeTo: make([]DirectedEdge, g.V()),
+		distTo: make([]float64, g.V()),
+	}
+
+	for v := 0; v < g.V(); v++ {
+		lpt.distTo[v] = -math.MaxFloat64
+	}
+
+	if order, ok := g.Topological().Order(); ok {
+		lpt.acyclic(g, s, order)
+	}
+
+	return lpt
+}
+
+// Relaxing vertices in topological order for calculating longest path tree of a DAG.
+func (lpt *LongestPathTree) acyclic(g *WeightedDirected, s int, order []int) {
+	lpt.distTo[s] = 0.0
+
+	for _, v := range order {
+		if lpt.distTo[v] == -math.MaxFloat64 {
+			continue
+		}
+
+		// Relaxing edges
+		for _, e := range g.Adj(v) {
+			w := e.To()
+			if dist := lpt.distTo[v] + e.Weight(); dist > lpt.distTo[w] {
+				lpt.edgeTo[w] = e
+				lpt.distTo[w] = dist
+			}
+		}
+	}
+}
+
+// PathTo returns longest path from the source vertex (s) to vertex (v).
+// The second return value is distance from the source vertex (s) to vertex (v).
+// If no such path exists or the graph is not a DAG, the last return value will be false.
+func (lpt *LongestPathTree) PathTo(v int) ([]DirectedEdge, float64, bool) {
+	if lpt.distTo[v] == -math.MaxFloat64 {
 		return nil, -1, false
 	}
 
+	return pathTo(lpt.edgeTo, v), lpt.distTo[v], true
+}
+
+// AllPairsShortestPaths is used for calculating the shortest paths between all pairs of vertices in a weighted directed graph.
+//
+// If the graph has a negative cycle, shortest paths are not well-defined.
+type AllPairsShortestPaths struct {
+	edgeTo [][]DirectedEdge // edgeTo[s][v] = last edge on shortest path s->v
+	distTo [][]float64      // distTo[s][v] = distance of shortest path s->v
+	cycle  []DirectedEdge   // a negative cycle (if any)
+}
+
+func newAllPairsShortestPaths(g *WeightedDirected, strategy AllPairsStrategy) *AllPairsShortestPaths {
+	apsp := &AllPairsShortestPaths{
+		edgeTo: make([][]DirectedEdge, g.V()),
+		distTo: make([][]float64, g.V()),
+	}
+
+	for v := 0; v < g.V(); v++ {
+		apsp.edgeTo[v] = make([]DirectedEdge, g.V())
+		apsp.distTo[v] = make([]float64, g.V())
+		for w := 0; w < g.V(); w++ {
+			apsp.distTo[v][w] = math.Inf(1)
+		}
+	}
+
+	switch strategy {
+	case FloydWarshall:
+		apsp.floydWarshall(g)
+	case Johnson:
+		apsp.johnson(g)
+	}
+
+	return apsp
+}
+
+// Floyd-Warshall algorithm for calculating all-pairs shortest paths in O(V³).
+func (apsp *AllPairsShortestPaths) floydWarshall(g *WeightedDirected) {
+	for v := 0; v < g.V(); v++ {
+		for _, e := range g.Adj(v) {
+			if w := e.To(); e.Weight() < apsp.distTo[v][w] {
+				apsp.edgeTo[v][w] = e
+				apsp.distTo[v][w] = e.Weight()
+			}
+		}
+
+		// A negative self-loop is not a shortest path
+		if apsp.distTo[v][v] >= 0 {
+			apsp.edgeTo[v][v] = DirectedEdge{}
+			apsp.distTo[v][v] = 0.0
+		}
+	}
+
+	for k := 0; k < g.V(); k++ {
+		for i := 0; i < g.V(); i++ {
+			if math.IsInf(apsp.distTo[i][k], 1) {
+				continue
+			}
+
+			for j := 0; j < g.V(); j++ {
+				if dist := apsp.distTo[i][k] + apsp.distTo[k][j]; dist < apsp.distTo[i][j] {
+					apsp.edgeTo[i][j] = apsp.edgeTo[k][j]
+					apsp.distTo[i][j] = dist
+				}
+			}
+
+			// Check for a negative cycle
+			if apsp.distTo[i][i] < 0 {
+				apsp.cycle = findNegativeCycle(apsp.edgeTo[i])
+				return
+			}
+		}
+	}
+}
+
+// Johnson's algorithm for calculating all-pairs shortest paths in O(VE log V).
+// Edges are reweighted to non-negative weights using vertex potentials computed by Bellman-Ford,
+// and then Dijkstra's algorithm runs from every vertex.
+func (apsp *AllPairsShortestPaths) johnson(g *WeightedDirected) {
+	// Add a new vertex q connected to every other vertex with a zero-weight edge
+	q := g.V()
+	h := NewWeightedDirected(g.V()+1, g.Edges()...)
+	for v := 0; v < g.V(); v++ {
+		h.AddEdge(DirectedEdge{q, v, 0})
+	}
+
+	// Bellman-Ford from q computes the vertex potentials
+	spt := newShortestPathTree(h, q, BellmanFord)
+	if cycle, ok := spt.NegativeCycle(); ok {
+		apsp.cycle = cycle
+		return
+	}
+
+	potential := spt.distTo[:g.V()]
+	for s := 0; s < g.V(); s++ {
+		spt := &ShortestPathTree{
+			edgeTo: apsp.edgeTo[s],
+			distTo: make([]float64, g.V()),
+		}
+
+		for v := 0; v < g.V(); v++ {
+			spt.distTo[v] = math.MaxFloat64
+		}
+
+		spt.dijkstra(g, s, potential)
+
+		for v := 0; v < g.V(); v++ {
+			if spt.distTo[v] != math.MaxFloat64 {
+				apsp.distTo[s][v] = spt.distTo[v] - potential[s] + potential[v]
+			}
+		}
+	}
+}
+
+// Distances returns the matrix of shortest path distances.
+// distances[s][v] is the distance of the shortest path from vertex s to vertex v,
+// and it is positive infinity if no such path exists.
+// If the graph has a negative cycle, the second return value will be false.
+func (apsp *AllPairsShortestPaths) Distances() ([][]float64, bool) {
+	if apsp.cycle != nil {
+		return nil, false
+	}
+
+	return apsp.distTo, true
+}
+
+// PathTo returns shortest path from vertex (s) to vertex (v).
+// The second return value is distance from vertex (s) to vertex (v).
+// If no such path exists or the graph has a negative cycle, the last return value will be false.
+func (apsp *AllPairsShortestPaths) PathTo(s, v int) ([]DirectedEdge, float64, bool) {
+	if apsp.cycle != nil || math.IsInf(apsp.distTo[s][v], 1) {
+		return nil, -1, false
+	}
+
+	return pathTo(apsp.edgeTo[s], v), apsp.distTo[s][v], true
+}
+
+// NegativeCycle returns a negative cycle in the graph.
+// The edges are in the order of the directed cycle.
+// If no negative cycle exists, the second return value will be false.
+func (apsp *AllPairsShortestPaths) NegativeCycle() ([]DirectedEdge, bool) {
+	if apsp.cycle == nil {
+		return nil, false
+	}
+
+	return apsp.cycle, true
+}
+
+// findNegativeCycle looks for a cycle in the subgraph formed by the edgeTo edges.
+// In a shortest path tree being relaxed, any such cycle is a negative cycle.
+func findNegativeCycle(edgeTo []DirectedEdge) []DirectedEdge {
+	zero := DirectedEdge{}
+	mark := make([]int, len(edgeTo)) // mark[v] = 1 + the vertex from which v is first reached walking edgeTo backwards
+
+	for s := range mark {
+		v := s
+		for mark[v] == 0 {
+			mark[v] = s + 1
+			if edgeTo[v] == zero {
+				break
+			}
+			v = edgeTo[v].From()
+		}
+
+		// The walk from s has come back to one of its own vertices
+		if mark[v] == s+1 && edgeTo[v] != zero {
+			stack := list.NewStack[DirectedEdge](listNodeSize, nil)
+			for e := edgeTo[v]; ; e = edgeTo[e.From()] {
+				stack.Push(e)
+				if e.From() == v {
+					break
+				}
+			}
+
+			cycle := make([]DirectedEdge, stack.Size())
+			for i := range cycle {
+				cycle[i], _ = stack.Pop()
+			}
+
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+func pathTo(edgeTo []DirectedEdge, v int) []DirectedEdge {
 	zero := DirectedEdge{}
 	stack := list.NewStack[DirectedEdge](listNodeSize, nil)
-	for e := spt.edgeTo[v]; e != zero; e = spt.edgeTo[e.From()] {
+	for e := edgeTo[v]; e != zero; e = edgeTo[e.From()] {
 		stack.Push(e)
 	}
 
@@ -437,5 +1205,480 @@ func (spt *ShortestPathTree) PathTo(v int) ([]DirectedEdge, float64, bool) {
 		path[i], _ = stack.Pop()
 	}
 
-	return path, spt.distTo[v], true
+	return path
+}
+
+// MaxFlow is used for calculating the maximum flow and the minimum cut of a flow network.
+// A maximum flow from vertex s to vertex t is a flow from s to t with the largest possible value.
+// A minimum s-t cut is a partition of vertices into two sets (one containing s and the other containing t)
+// such that the sum of the capacities of the edges from the first set to the second set is minimum.
+// The value of a maximum flow is equal to the capacity of a minimum cut (max-flow min-cut theorem).
+type MaxFlow struct {
+	value  float64
+	edges  []FlowEdge // edges in the same order as FlowNetwork.Edges() with their final flows
+	adj    [][]int    // adj[v] = indices of edges incident to v
+	marked []bool     // marked[v] = true if v is reachable from s in the residual network
+	edgeTo []int      // edgeTo[v] = index of last edge on shortest residual s->v path
+	level  []int      // level[v] = length of shortest residual s->v path
+}
+
+func newMaxFlow(g *FlowNetwork, s, t int, strategy FlowStrategy) *MaxFlow {
+	mf := &MaxFlow{
+		edges:  g.Edges(),
+		adj:    make([][]int, g.V()),
+		marked: make([]bool, g.V()),
+		edgeTo: make([]int, g.V()),
+		level:  make([]int, g.V()),
+	}
+
+	// The computation starts from zero flow.
+	for i := range mf.edges {
+		mf.edges[i].flow = 0
+		v, w := mf.edges[i].From(), mf.edges[i].To()
+		mf.adj[v] = append(mf.adj[v], i)
+		mf.adj[w] = append(mf.adj[w], i)
+	}
+
+	if s != t && g.isVertexValid(s) && g.isVertexValid(t) {
+		switch strategy {
+		case EdmondsKarp:
+			mf.edmondsKarp(s, t)
+		case Dinic:
+			mf.dinic(s, t)
+		}
+	}
+
+	// The source side of the minimum cut consists of the vertices reachable from s in the final residual network.
+	if g.isVertexValid(s) {
+		mf.bfs(s)
+	}
+
+	return mf
+}
+
+// bfs finds the shortest paths in the residual network from vertex s to every other vertex.
+func (mf *MaxFlow) bfs(s int) {
+	for v := range mf.marked {
+		mf.marked[v] = false
+		mf.edgeTo[v] = -1
+		mf.level[v] = -1
+	}
+
+	queue := list.NewQueue[int](listNodeSize, nil)
+	mf.marked[s] = true
+	mf.level[s] = 0
+	queue.Enqueue(s)
+
+	for !queue.IsEmpty() {
+		v, _ := queue.Dequeue()
+
+		for _, i := range mf.adj[v] {
+			e := &mf.edges[i]
+			w := e.Other(v)
+
+			if !mf.marked[w] && e.ResidualCapacityTo(w) > float64Epsilon {
+				mf.marked[w] = true
+				mf.edgeTo[w] = i
+				mf.level[w] = mf.level[v] + 1
+				queue.Enqueue(w)
+			}
+		}
+	}
+}
+
+// Edmonds-Karp algorithm for calculating maximum flow.
+func (mf *MaxFlow) edmondsKarp(s, t int) {
+	for mf.bfs(s); mf.marked[t]; mf.bfs(s) {
+		// Compute the bottleneck capacity of the augmenting path
+		bottleneck := math.MaxFloat64
+		for v := t; v != s; {
+			e := &mf.edges[mf.edgeTo[v]]
+			bottleneck = math.Min(bottleneck, e.ResidualCapacityTo(v))
+			v = e.Other(v)
+		}
+
+		// Augment the flow along the path
+		for v := t; v != s; {
+			e := &mf.edges[mf.edgeTo[v]]
+			e.AddResidualFlowTo(v, bottleneck)
+			v = e.Other(v)
+		}
+
+		mf.value += bottleneck
+	}
+}
+
+// Dinic's algorithm for calculating maximum flow.
+func (mf *MaxFlow) dinic(s, t int) {
+	next := make([]int, len(mf.adj)) // next[v] = position of the next edge to explore in adj[v]
+
+	for mf.bfs(s); mf.marked[t]; mf.bfs(s) {
+		for v := range next {
+			next[v] = 0
+		}
+
+		// Find a blocking flow in the level graph
+		for {
+			f := mf.augment(s, t, math.MaxFloat64, next)
+			if f <= float64Epsilon {
+				break
+			}
+			mf.value += f
+		}
+	}
+}
+
+// augment pushes flow from vertex v to vertex t along the edges of the level graph.
+// It returns the amount of flow pushed, which is at most limit.
+func (mf *MaxFlow) augment(v, t int, limit float64, next []int) float64 {
+	if v == t {
+		return limit
+	}
+
+	for ; next[v] < len(mf.adj[v]); next[v]++ {
+		e := &mf.edges[mf.adj[v][next[v]]]
+		w := e.Other(v)
+
+		if mf.level[w] != mf.level[v]+1 {
+			continue
+		}
+
+		if c := e.ResidualCapacityTo(w); c > float64Epsilon {
+			if f := mf.augment(w, t, math.Min(limit, c), next); f > float64Epsilon {
+				e.AddResidualFlowTo(w, f)
+				return f
+			}
+		}
+	}
+
+	return 0
+}
+
+// Value returns the value of the maximum flow.
+func (mf *MaxFlow) Value() float64 {
+	return mf.value
+}
+
+// Edges returns all edges of the flow network with their flows in the maximum flow.
+// The edges are in the same order as the edges returned by FlowNetwork.Edges.
+func (mf *MaxFlow) Edges() []FlowEdge {
+	return mf.edges
+}
+
+// InCut determines whether or not a vertex is on the source side of the minimum cut.
+func (mf *MaxFlow) InCut(v int) bool {
+	return mf.marked[v]
+}
+
+// Cut returns the partition of vertices by the minimum cut.
+// The first return value contains the vertices on the source side
+// and the second return value contains the vertices on the sink side.
+func (mf *MaxFlow) Cut() ([]int, []int) {
+	S, T := make([]int, 0), make([]int, 0)
+	for v, marked := range mf.marked {
+		if marked {
+			S = append(S, v)
+		} else {
+			T = append(T, v)
+		}
+	}
+
+	return S, T
+}
+
+// CutEdges returns the edges crossing the minimum cut from the source side to the sink side.
+// The sum of the capacities of these edges is equal to the value of the maximum flow.
+func (mf *MaxFlow) CutEdges() []FlowEdge {
+	edges := make([]FlowEdge, 0)
+	for _, e := range mf.edges {
+		if mf.marked[e.From()] && !mf.marked[e.To()] {
+			edges = append(edges, e)
+		}
+	}
+
+	return edges
+}
+
+// BipartiteMatching is used for calculating a maximum cardinality matching in a bipartite graph.
+// A matching is a set of edges without common vertices.
+// A maximum cardinality matching is a matching with the largest possible number of edges.
+type BipartiteMatching struct {
+	size int
+	mate []int // mate[v] = vertex matched to v, -1 if v is not matched
+	dist []int // dist[v] = layer of left vertex v in the current phase
+}
+
+func newBipartiteMatching(g *Undirected) *BipartiteMatching {
+	m := &BipartiteMatching{
+		size: 0,
+		mate: make([]int, g.V()),
+		dist: make([]int, g.V()),
+	}
+
+	for v := range m.mate {
+		m.mate[v] = -1
+	}
+
+	b := newBipartite(g)
+	if !b.IsBipartite() {
+		return m
+	}
+
+	left := make([]int, 0)
+	for v := 0; v < g.V(); v++ {
+		if !b.color[v] {
+			left = append(left, v)
+		}
+	}
+
+	m.hopcroftKarp(g, left)
+
+	return m
+}
+
+// Hopcroft-Karp algorithm for calculating maximum cardinality matching in O(E√V).
+// In each phase, a maximal set of vertex-disjoint shortest augmenting paths is found.
+func (m *BipartiteMatching) hopcroftKarp(g *Undirected, left []int) {
+	for m.bfs(g, left) {
+		for _, v := range left {
+			if m.mate[v] == -1 && m.dfs(g, v) {
+				m.size++
+			}
+		}
+	}
+}
+
+// bfs builds the layers of left vertices, starting from the free ones,
+// and returns true if an augmenting path exists.
+func (m *BipartiteMatching) bfs(g *Undirected, left []int) bool {
+	queue := list.NewQueue[int](listNodeSize, nil)
+	for _, v := range left {
+		if m.mate[v] == -1 {
+			m.dist[v] = 0
+			queue.Enqueue(v)
+		} else {
+			m.dist[v] = math.MaxInt
+		}
+	}
+
+	found := false
+	for !queue.IsEmpty() {
+		v, _ := queue.Dequeue()
+		for _, w := range g.adj[v] {
+			if u := m.mate[w]; u == -1 {
+				found = true
+			} else if m.dist[u] == math.MaxInt {
+				m.dist[u] = m.dist[v] + 1
+				queue.Enqueue(u)
+			}
+		}
+	}
+
+	return found
+}
+
+// dfs looks for an augmenting path from left vertex v along the layers.
+func (m *BipartiteMatching) dfs(g *Undirected, v int) bool {
+	for _, w := range g.adj[v] {
+		if u := m.mate[w]; u == -1 || (m.dist[u] == m.dist[v]+1 && m.dfs(g, u)) {
+			m.mate[v] = w
+			m.mate[w] = v
+			return true
+		}
+	}
+
+	// No augmenting path from v in this phase
+	m.dist[v] = math.MaxInt
+
+	return false
+}
+
+// Size returns the number of edges in the matching.
+func (m *BipartiteMatching) Size() int {
+	return m.size
+}
+
+// Mate returns the vertex matched to vertex (v).
+// If vertex (v) is not matched, the second return value will be false.
+func (m *BipartiteMatching) Mate(v int) (int, bool) {
+	if m.mate[v] == -1 {
+		return -1, false
+	}
+
+	return m.mate[v], true
+}
+
+// Edges returns the edges in the matching.
+// Each edge is ordered from its smaller vertex to its larger vertex, and edges are sorted by their first vertex.
+func (m *BipartiteMatching) Edges() [][2]int {
+	edges := make([][2]int, 0, m.size)
+	for v, w := range m.mate {
+		if w != -1 && v < w {
+			edges = append(edges, [2]int{v, w})
+		}
+	}
+
+	return edges
+}
+
+// Assignment is used for calculating a minimum-cost assignment in a weighted bipartite graph.
+// An assignment is a matching with the largest possible number of edges,
+// such that the sum of the edge weights is minimum among all such matchings.
+type Assignment struct {
+	edges []UndirectedEdge
+	mate  []int // mate[v] = vertex assigned to v, -1 if v is not assigned
+}
+
+func newAssignment(g *WeightedUndirected) *Assignment {
+	a := &Assignment{
+		edges: make([]UndirectedEdge, 0),
+		mate:  make([]int, g.V()),
+	}
+
+	for v := range a.mate {
+		a.mate[v] = -1
+	}
+
+	b := newBipartite(g.unweighted())
+	if !b.IsBipartite() {
+		return a
+	}
+
+	left, right := make([]int, 0), make([]int, 0)
+	for v := 0; v < g.V(); v++ {
+		if b.color[v] {
+			right = append(right, v)
+		} else {
+			left = append(left, v)
+		}
+	}
+
+	// The Hungarian algorithm requires rows to be no more than columns
+	if len(left) > len(right) {
+		left, right = right, left
+	}
+
+	a.hungarian(g, left, right)
+
+	return a
+}
+
+// The Hungarian algorithm (Kuhn-Munkres) with vertex potentials for calculating minimum-cost assignment in O(V³).
+func (a *Assignment) hungarian(g *WeightedUndirected, left, right []int) {
+	n, m := len(left), len(right)
+
+	col := make(map[int]int, m) // right vertex --> column index
+	for j, w := range right {
+		col[w] = j + 1
+	}
+
+	// A missing edge costs more than any set of existing edges,
+	// so the number of assigned pairs is maximized before the total weight is minimized.
+	missing := 1.0
+	for _, e := range g.Edges() {
+		missing += 2 * math.Abs(e.Weight())
+	}
+
+	// cost and edge matrices are 1-indexed
+	cost := make([][]float64, n+1)
+	edge := make([][]UndirectedEdge, n+1)
+	for i := 1; i <= n; i++ {
+		cost[i] = make([]float64, m+1)
+		edge[i] = make([]UndirectedEdge, m+1)
+		for j := 1; j <= m; j++ {
+			cost[i][j] = missing
+		}
+
+		for _, e := range g.Adj(left[i-1]) {
+			if j := col[e.Other(left[i-1])]; j > 0 && e.Weight() < cost[i][j] {
+				cost[i][j] = e.Weight()
+				edge[i][j] = e
+			}
+		}
+	}
+
+	u := make([]float64, n+1) // row potentials
+	v := make([]float64, m+1) // column potentials
+	p := make([]int, m+1)     // p[j] = row assigned to column j
+	way := make([]int, m+1)   // way[j] = previous column on the alternating path to column j
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, m+1)
+		used := make([]bool, m+1)
+		for j := range minv {
+			minv[j] = math.MaxFloat64
+		}
+
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, j1, delta := p[j0], 0, math.MaxFloat64
+
+			for j := 1; j <= m; j++ {
+				if !used[j] {
+					if cur := cost[i0][j] - u[i0] - v[j]; cur < minv[j] {
+						minv[j] = cur
+						way[j] = j0
+					}
+
+					if minv[j] < delta {
+						delta = minv[j]
+						j1 = j
+					}
+				}
+			}
+
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+
+			j0 = j1
+		}
+
+		// Augmenting along the alternating path
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	for j := 1; j <= m; j++ {
+		if i := p[j]; i != 0 && cost[i][j] != missing {
+			a.edges = append(a.edges, edge[i][j])
+			a.mate[left[i-1]] = right[j-1]
+			a.mate[right[j-1]] = left[i-1]
+		}
+	}
+}
+
+// Edges returns the edges in the assignment.
+func (a *Assignment) Edges() []UndirectedEdge {
+	return a.edges
+}
+
+// Cost returns the sum of the edge weights in the assignment.
+func (a *Assignment) Cost() float64 {
+	var cost float64
+	for _, e := range a.edges {
+		cost += e.Weight()
+	}
+
+	return cost
+}
+
+// Mate returns the vertex assigned to vertex (v).
+// If vertex (v) is not assigned, the second return value will be false.
+func (a *Assignment) Mate(v int) (int, bool) {
+	if a.mate[v] == -1 {
+		return -1, false
+	}
+
+	return a.mate[v], true
 }
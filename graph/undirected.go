@@ -303,6 +303,22 @@ func (g *Undirected) ConnectedComponents() *ConnectedComponents {
 	return cc
 }
 
+// Bipartite determines if the graph is bipartite.
+func (g *Undirected) Bipartite() *Bipartite {
+	return newBipartite(g)
+}
+
+// BipartiteMatching calculates a maximum cardinality matching of the graph using the Hopcroft-Karp algorithm.
+// If the graph is not bipartite, the matching will be empty.
+func (g *Undirected) BipartiteMatching() *BipartiteMatching {
+	return newBipartiteMatching(g)
+}
+
+// Biconnected determines the articulation points, bridges and biconnected components of the graph.
+func (g *Undirected) Biconnected() *Biconnected {
+	return newBiconnected(g)
+}
+
 // DOT generates a DOT representation of the graph.
 func (g *Undirected) DOT() string {
 	graph := dot.NewGraph(true, false, false, "", "", "", dot.StyleSolid, dot.ShapeCircle)
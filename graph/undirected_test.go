@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -359,3 +360,241 @@ func TestUndirected(t *testing.T) {
 		})
 	}
 }
+
+func TestUndirected_Bipartite(t *testing.T) {
+	tests := []struct {
+		name                string
+		V                   int
+		edges               [][2]int
+		expectedIsBipartite bool
+		expectedColors      []bool
+		expectedOddCycle    []int
+	}{
+		{
+			name:                "Bipartite",
+			V:                   5,
+			edges:               [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}},
+			expectedIsBipartite: true,
+			expectedColors:      []bool{false, true, false, true, false},
+			expectedOddCycle:    nil,
+		},
+		{
+			name:                "Triangle",
+			V:                   4,
+			edges:               [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}},
+			expectedIsBipartite: false,
+			expectedColors:      nil,
+			expectedOddCycle:    []int{0, 1, 2, 0},
+		},
+		{
+			name:                "OddCycle",
+			V:                   6,
+			edges:               [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 1}},
+			expectedIsBipartite: false,
+			expectedColors:      nil,
+			expectedOddCycle:    []int{1, 2, 3, 4, 5, 1},
+		},
+		{
+			name:                "SelfLoop",
+			V:                   2,
+			edges:               [][2]int{{0, 1}, {1, 1}},
+			expectedIsBipartite: false,
+			expectedColors:      nil,
+			expectedOddCycle:    []int{1, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewUndirected(tc.V, tc.edges...)
+			b := g.Bipartite()
+
+			assert.Equal(t, tc.expectedIsBipartite, b.IsBipartite())
+
+			for v := 0; v < tc.V; v++ {
+				color, ok := b.Color(v)
+				assert.Equal(t, tc.expectedIsBipartite, ok)
+				if tc.expectedColors != nil {
+					assert.Equal(t, tc.expectedColors[v], color)
+				}
+			}
+
+			for i := 0; i < 2; i++ {
+				cycle, ok := b.OddCycle()
+				assert.Equal(t, tc.expectedOddCycle, cycle)
+				assert.Equal(t, !tc.expectedIsBipartite, ok)
+			}
+		})
+	}
+}
+
+func TestUndirected_BipartiteMatching(t *testing.T) {
+	tests := []struct {
+		name          string
+		V             int
+		edges         [][2]int
+		expectedSize  int
+		expectedEdges [][2]int
+	}{
+		{
+			name:          "Empty",
+			V:             3,
+			edges:         [][2]int{},
+			expectedSize:  0,
+			expectedEdges: [][2]int{},
+		},
+		{
+			name:          "Path",
+			V:             4,
+			edges:         [][2]int{{0, 1}, {1, 2}, {2, 3}},
+			expectedSize:  2,
+			expectedEdges: [][2]int{{0, 1}, {2, 3}},
+		},
+		{
+			name:          "Augmenting",
+			V:             6,
+			edges:         [][2]int{{0, 3}, {0, 4}, {1, 3}, {2, 3}, {2, 5}},
+			expectedSize:  3,
+			expectedEdges: [][2]int{{0, 4}, {1, 3}, {2, 5}},
+		},
+		{
+			name:          "Deficient",
+			V:             6,
+			edges:         [][2]int{{0, 3}, {0, 4}, {1, 3}, {2, 3}},
+			expectedSize:  2,
+			expectedEdges: nil,
+		},
+		{
+			name:          "NotBipartite",
+			V:             3,
+			edges:         [][2]int{{0, 1}, {1, 2}, {2, 0}},
+			expectedSize:  0,
+			expectedEdges: [][2]int{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewUndirected(tc.V, tc.edges...)
+			m := g.BipartiteMatching()
+
+			assert.Equal(t, tc.expectedSize, m.Size())
+
+			edges := m.Edges()
+			assert.Len(t, edges, tc.expectedSize)
+			if tc.expectedEdges != nil {
+				assert.Equal(t, tc.expectedEdges, edges)
+			}
+
+			for _, e := range edges {
+				assert.Contains(t, g.Adj(e[0]), e[1])
+
+				w, ok := m.Mate(e[0])
+				assert.True(t, ok)
+				assert.Equal(t, e[1], w)
+
+				v, ok := m.Mate(e[1])
+				assert.True(t, ok)
+				assert.Equal(t, e[0], v)
+			}
+		})
+	}
+}
+
+func TestUndirected_Biconnected(t *testing.T) {
+	tests := []struct {
+		name                       string
+		V                          int
+		edges                      [][2]int
+		expectedArticulationPoints []int
+		expectedBridges            [][2]int
+		expectedComponents         [][][2]int
+		expectedBlockCutTree       [][]int
+	}{
+		{
+			name:                       "Empty",
+			V:                          2,
+			edges:                      [][2]int{},
+			expectedArticulationPoints: []int{},
+			expectedBridges:            [][2]int{},
+			expectedComponents:         [][][2]int{},
+			expectedBlockCutTree:       [][]int{},
+		},
+		{
+			name: "Cycle",
+			V:    4,
+			edges: [][2]int{
+				{0, 1}, {1, 2}, {2, 3}, {3, 0},
+			},
+			expectedArticulationPoints: []int{},
+			expectedBridges:            [][2]int{},
+			expectedComponents: [][][2]int{
+				{{3, 0}, {2, 3}, {1, 2}, {0, 1}},
+			},
+			expectedBlockCutTree: [][]int{
+				{},
+			},
+		},
+		{
+			name: "Star",
+			V:    4,
+			edges: [][2]int{
+				{0, 1}, {0, 2}, {0, 3},
+			},
+			expectedArticulationPoints: []int{0},
+			expectedBridges:            [][2]int{{0, 1}, {0, 2}, {0, 3}},
+			expectedComponents: [][][2]int{
+				{{0, 1}},
+				{{0, 2}},
+				{{0, 3}},
+			},
+			expectedBlockCutTree: [][]int{
+				{3}, {3}, {3}, {0, 1, 2},
+			},
+		},
+		{
+			name: "Blocks",
+			V:    10,
+			edges: [][2]int{
+				{0, 1}, {1, 2}, {2, 0},
+				{1, 3},
+				{3, 4}, {4, 5}, {5, 3},
+				{5, 6},
+				{8, 9}, {8, 9},
+			},
+			expectedArticulationPoints: []int{1, 3, 5},
+			expectedBridges:            [][2]int{{5, 6}, {1, 3}},
+			expectedComponents: [][][2]int{
+				{{5, 6}},
+				{{5, 3}, {4, 5}, {3, 4}},
+				{{1, 3}},
+				{{2, 0}, {1, 2}, {0, 1}},
+				{{9, 8}, {8, 9}},
+			},
+			expectedBlockCutTree: [][]int{
+				{7}, {7, 6}, {5, 6}, {5}, {}, {2, 3}, {1, 2}, {0, 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewUndirected(tc.V, tc.edges...)
+			b := g.Biconnected()
+
+			assert.Equal(t, tc.expectedArticulationPoints, b.ArticulationPoints())
+			for v := 0; v < tc.V; v++ {
+				assert.Equal(t, slices.Contains(tc.expectedArticulationPoints, v), b.IsArticulation(v))
+			}
+
+			assert.Equal(t, tc.expectedBridges, b.Bridges())
+			assert.Equal(t, tc.expectedComponents, b.Components())
+
+			tree := b.BlockCutTree()
+			assert.Equal(t, len(tc.expectedBlockCutTree), tree.V())
+			for v, expectedAdj := range tc.expectedBlockCutTree {
+				assert.Equal(t, expectedAdj, tree.Adj(v))
+			}
+		})
+	}
+}
@@ -363,9 +363,62 @@ func (g *WeightedDirected) StronglyConnectedComponents() *StronglyConnectedCompo
 	return scc
 }
 
-// ShortestPathTree calculates the shortest path tree of the graph.
+// DirectedCycle determines if the graph has a cyclic path.
+func (g *WeightedDirected) DirectedCycle() *DirectedCycle {
+	return newDirectedCycle(g.unweighted())
+}
+
+// Topological determines the topological sort of the graph.
+// If the graph has a directed cycle (not DAG), it does not have a topological order.
+func (g *WeightedDirected) Topological() *Topological {
+	return g.unweighted().Topological()
+}
+
+// unweighted returns the directed graph with the same edges and no weights.
+func (g *WeightedDirected) unweighted() *Directed {
+	d := NewDirected(g.V())
+	for v := range g.adj {
+		for _, e := range g.adj[v] {
+			d.AddEdge(e.From(), e.To())
+		}
+	}
+
+	return d
+}
+
+func (g *WeightedDirected) hasNegativeWeight() bool {
+	for v := range g.adj {
+		for _, e := range g.adj[v] {
+			if e.Weight() < 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ShortestPathTree calculates the shortest path tree of the graph using Dijkstra's algorithm.
+// All edge weights must be non-negative.
+// For graphs with negative edge weights, use ShortestPathTreeWith.
 func (g *WeightedDirected) ShortestPathTree(s int) *ShortestPathTree {
-	return newShortestPathTree(g, s)
+	return newShortestPathTree(g, s, Dijkstra)
+}
+
+// ShortestPathTreeWith calculates the shortest path tree of the graph using the given strategy.
+func (g *WeightedDirected) ShortestPathTreeWith(s int, strategy ShortestPathStrategy) *ShortestPathTree {
+	return newShortestPathTree(g, s, strategy)
+}
+
+// AllPairsShortestPaths calculates the shortest paths between all pairs of vertices using the given strategy.
+func (g *WeightedDirected) AllPairsShortestPaths(strategy AllPairsStrategy) *AllPairsShortestPaths {
+	return newAllPairsShortestPaths(g, strategy)
+}
+
+// LongestPathTree calculates the longest path tree of the graph.
+// The graph must be a DAG; otherwise, no longest path will be found.
+func (g *WeightedDirected) LongestPathTree(s int) *LongestPathTree {
+	return newLongestPathTree(g, s)
 }
 
 // DOT generates a DOT representation of the graph.
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -516,3 +517,469 @@ func TestWeightedDirected(t *testing.T) {
 		})
 	}
 }
+
+func TestWeightedDirected_Topological(t *testing.T) {
+	tests := []struct {
+		name          string
+		V             int
+		edges         []DirectedEdge
+		expectedCycle []int
+		expectedOrder []int
+	}{
+		{
+			name: "Cyclic",
+			V:    4,
+			edges: []DirectedEdge{
+				{0, 1, 0.5},
+				{1, 2, 0.5},
+				{2, 3, 0.5},
+				{3, 1, 0.5},
+			},
+			expectedCycle: []int{3, 1, 2, 3},
+			expectedOrder: nil,
+		},
+		{
+			name: "Acyclic",
+			V:    4,
+			edges: []DirectedEdge{
+				{0, 1, 0.5},
+				{0, 2, 0.5},
+				{1, 3, 0.5},
+				{2, 3, 0.5},
+			},
+			expectedCycle: nil,
+			expectedOrder: []int{0, 2, 1, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWeightedDirected(tc.V, tc.edges...)
+
+			cycle, ok := g.DirectedCycle().Cycle()
+			assert.Equal(t, tc.expectedCycle, cycle)
+			assert.Equal(t, tc.expectedCycle != nil, ok)
+
+			order, ok := g.Topological().Order()
+			assert.Equal(t, tc.expectedOrder, order)
+			assert.Equal(t, tc.expectedOrder != nil, ok)
+		})
+	}
+}
+
+func TestWeightedDirected_ShortestPathTreeWith(t *testing.T) {
+	type pathTest struct {
+		vertex           int
+		expectedPath     []DirectedEdge
+		expectedDistance float64
+		expectedOK       bool
+	}
+
+	// Negative edge weights, no negative cycle
+	negativeWeights := []DirectedEdge{
+		{4, 5, 0.35}, {5, 4, 0.35}, {4, 7, 0.37}, {5, 7, 0.28}, {7, 5, 0.28},
+		{5, 1, 0.32}, {0, 4, 0.38}, {0, 2, 0.26}, {7, 3, 0.39}, {1, 3, 0.29},
+		{2, 7, 0.34}, {6, 2, -1.20}, {3, 6, 0.52}, {6, 0, -1.40}, {6, 4, -1.25},
+	}
+
+	// Negative cycle 4 -> 5 -> 4
+	negativeCycle := []DirectedEdge{
+		{4, 5, 0.35}, {5, 4, -0.66}, {4, 7, 0.37}, {5, 7, 0.28}, {7, 5, 0.28},
+		{5, 1, 0.32}, {0, 4, 0.38}, {0, 2, 0.26}, {7, 3, 0.39}, {1, 3, 0.29},
+		{2, 7, 0.34}, {6, 2, 0.40}, {3, 6, 0.52}, {6, 0, 0.58}, {6, 4, 0.93},
+	}
+
+	// Directed acyclic graph
+	dag := []DirectedEdge{
+		{5, 4, 0.35}, {4, 7, 0.37}, {5, 7, 0.28}, {5, 1, 0.32}, {4, 0, 0.38},
+		{0, 2, 0.26}, {3, 7, 0.39}, {1, 3, 0.29}, {7, 2, 0.34}, {6, 2, 0.40},
+		{3, 6, 0.52}, {6, 0, 0.58}, {6, 4, 0.93},
+	}
+
+	tests := []struct {
+		name          string
+		V             int
+		edges         []DirectedEdge
+		source        int
+		strategies    []ShortestPathStrategy
+		expectedCycle []DirectedEdge
+		pathTests     []pathTest
+	}{
+		{
+			name:          "NegativeWeights",
+			V:             8,
+			edges:         negativeWeights,
+			source:        0,
+			strategies:    []ShortestPathStrategy{Automatic, BellmanFord, Acyclic},
+			expectedCycle: nil,
+			pathTests: []pathTest{
+				{
+					vertex:           0,
+					expectedPath:     []DirectedEdge{},
+					expectedDistance: 0,
+					expectedOK:       true,
+				},
+				{
+					vertex: 1,
+					expectedPath: []DirectedEdge{
+						{0, 2, 0.26}, {2, 7, 0.34}, {7, 3, 0.39}, {3, 6, 0.52}, {6, 4, -1.25}, {4, 5, 0.35}, {5, 1, 0.32},
+					},
+					expectedDistance: 0.93,
+					expectedOK:       true,
+				},
+				{
+					vertex: 4,
+					expectedPath: []DirectedEdge{
+						{0, 2, 0.26}, {2, 7, 0.34}, {7, 3, 0.39}, {3, 6, 0.52}, {6, 4, -1.25},
+					},
+					expectedDistance: 0.26,
+					expectedOK:       true,
+				},
+			},
+		},
+		{
+			name:          "NegativeCycle",
+			V:             8,
+			edges:         negativeCycle,
+			source:        0,
+			strategies:    []ShortestPathStrategy{Automatic, BellmanFord, Acyclic},
+			expectedCycle: []DirectedEdge{{5, 4, -0.66}, {4, 5, 0.35}},
+			pathTests: []pathTest{
+				{
+					vertex:           1,
+					expectedPath:     nil,
+					expectedDistance: -1,
+					expectedOK:       false,
+				},
+			},
+		},
+		{
+			name:          "Unreachable",
+			V:             3,
+			edges:         []DirectedEdge{{0, 1, -0.5}, {1, 0, 0.75}},
+			source:        0,
+			strategies:    []ShortestPathStrategy{Automatic, BellmanFord},
+			expectedCycle: nil,
+			pathTests: []pathTest{
+				{
+					vertex:           1,
+					expectedPath:     []DirectedEdge{{0, 1, -0.5}},
+					expectedDistance: -0.5,
+					expectedOK:       true,
+				},
+				{
+					vertex:           2,
+					expectedPath:     nil,
+					expectedDistance: -1,
+					expectedOK:       false,
+				},
+			},
+		},
+		{
+			name:          "DAG",
+			V:             8,
+			edges:         dag,
+			source:        5,
+			strategies:    []ShortestPathStrategy{Automatic, Dijkstra, BellmanFord, Acyclic},
+			expectedCycle: nil,
+			pathTests: []pathTest{
+				{
+					vertex:           0,
+					expectedPath:     []DirectedEdge{{5, 4, 0.35}, {4, 0, 0.38}},
+					expectedDistance: 0.73,
+					expectedOK:       true,
+				},
+				{
+					vertex:           2,
+					expectedPath:     []DirectedEdge{{5, 7, 0.28}, {7, 2, 0.34}},
+					expectedDistance: 0.62,
+					expectedOK:       true,
+				},
+				{
+					vertex:           6,
+					expectedPath:     []DirectedEdge{{5, 1, 0.32}, {1, 3, 0.29}, {3, 6, 0.52}},
+					expectedDistance: 1.13,
+					expectedOK:       true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWeightedDirected(tc.V, tc.edges...)
+
+			for _, strategy := range tc.strategies {
+				spt := g.ShortestPathTreeWith(tc.source, strategy)
+
+				cycle, ok := spt.NegativeCycle()
+				assert.Equal(t, tc.expectedCycle, cycle, "strategy %d", strategy)
+				assert.Equal(t, tc.expectedCycle != nil, ok, "strategy %d", strategy)
+
+				for _, pt := range tc.pathTests {
+					path, dist, ok := spt.PathTo(pt.vertex)
+					assert.Equal(t, pt.expectedPath, path, "strategy %d, vertex %d", strategy, pt.vertex)
+					assert.InDelta(t, pt.expectedDistance, dist, float64Epsilon, "strategy %d, vertex %d", strategy, pt.vertex)
+					assert.Equal(t, pt.expectedOK, ok, "strategy %d, vertex %d", strategy, pt.vertex)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedDirected_LongestPathTree(t *testing.T) {
+	type pathTest struct {
+		vertex           int
+		expectedPath     []DirectedEdge
+		expectedDistance float64
+		expectedOK       bool
+	}
+
+	tests := []struct {
+		name      string
+		V         int
+		edges     []DirectedEdge
+		source    int
+		pathTests []pathTest
+	}{
+		{
+			name: "Cyclic",
+			V:    3,
+			edges: []DirectedEdge{
+				{0, 1, 0.5}, {1, 2, 0.5}, {2, 0, 0.5},
+			},
+			source: 0,
+			pathTests: []pathTest{
+				{
+					vertex:           2,
+					expectedPath:     nil,
+					expectedDistance: -1,
+					expectedOK:       false,
+				},
+			},
+		},
+		{
+			name: "DAG",
+			V:    8,
+			edges: []DirectedEdge{
+				{5, 4, 0.35}, {4, 7, 0.37}, {5, 7, 0.28}, {5, 1, 0.32}, {4, 0, 0.38},
+				{0, 2, 0.26}, {3, 7, 0.39}, {1, 3, 0.29}, {7, 2, 0.34}, {6, 2, 0.40},
+				{3, 6, 0.52}, {6, 0, 0.58}, {6, 4, 0.93},
+			},
+			source: 5,
+			pathTests: []pathTest{
+				{
+					vertex:           0,
+					expectedPath:     []DirectedEdge{{5, 1, 0.32}, {1, 3, 0.29}, {3, 6, 0.52}, {6, 4, 0.93}, {4, 0, 0.38}},
+					expectedDistance: 2.44,
+					expectedOK:       true,
+				},
+				{
+					vertex:           2,
+					expectedPath:     []DirectedEdge{{5, 1, 0.32}, {1, 3, 0.29}, {3, 6, 0.52}, {6, 4, 0.93}, {4, 7, 0.37}, {7, 2, 0.34}},
+					expectedDistance: 2.77,
+					expectedOK:       true,
+				},
+				{
+					vertex:           7,
+					expectedPath:     []DirectedEdge{{5, 1, 0.32}, {1, 3, 0.29}, {3, 6, 0.52}, {6, 4, 0.93}, {4, 7, 0.37}},
+					expectedDistance: 2.43,
+					expectedOK:       true,
+				},
+			},
+		},
+		{
+			// Critical path method: job i has start vertex i and end vertex i+3,
+			// with source 6 and sink 7, and job 1 must start after job 0 ends.
+			name: "CriticalPath",
+			V:    8,
+			edges: []DirectedEdge{
+				{6, 0, 0}, {6, 1, 0}, {6, 2, 0},
+				{0, 3, 4}, {1, 4, 2}, {2, 5, 3},
+				{3, 7, 0}, {4, 7, 0}, {5, 7, 0},
+				{3, 1, 0},
+			},
+			source: 6,
+			pathTests: []pathTest{
+				{
+					vertex:           7,
+					expectedPath:     []DirectedEdge{{6, 0, 0}, {0, 3, 4}, {3, 1, 0}, {1, 4, 2}, {4, 7, 0}},
+					expectedDistance: 6,
+					expectedOK:       true,
+				},
+				{
+					vertex:           2,
+					expectedPath:     []DirectedEdge{{6, 2, 0}},
+					expectedDistance: 0,
+					expectedOK:       true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWeightedDirected(tc.V, tc.edges...)
+			lpt := g.LongestPathTree(tc.source)
+
+			for _, pt := range tc.pathTests {
+				path, dist, ok := lpt.PathTo(pt.vertex)
+				assert.Equal(t, pt.expectedPath, path, "vertex %d", pt.vertex)
+				assert.InDelta(t, pt.expectedDistance, dist, float64Epsilon, "vertex %d", pt.vertex)
+				assert.Equal(t, pt.expectedOK, ok, "vertex %d", pt.vertex)
+			}
+		})
+	}
+}
+
+func TestWeightedDirected_AllPairsShortestPaths(t *testing.T) {
+	type pathTest struct {
+		s, v             int
+		expectedPath     []DirectedEdge
+		expectedDistance float64
+		expectedOK       bool
+	}
+
+	inf := math.Inf(1)
+
+	tests := []struct {
+		name              string
+		V                 int
+		edges             []DirectedEdge
+		expectedDistances [][]float64
+		expectedCycle     bool
+		pathTests         []pathTest
+	}{
+		{
+			name: "NegativeWeights",
+			V:    4,
+			edges: []DirectedEdge{
+				{0, 2, -2}, {1, 0, 4}, {1, 2, 3}, {2, 3, 2}, {3, 1, -1},
+			},
+			expectedDistances: [][]float64{
+				{0, -1, -2, 0},
+				{4, 0, 2, 4},
+				{5, 1, 0, 2},
+				{3, -1, 1, 0},
+			},
+			expectedCycle: false,
+			pathTests: []pathTest{
+				{
+					s: 0, v: 1,
+					expectedPath:     []DirectedEdge{{0, 2, -2}, {2, 3, 2}, {3, 1, -1}},
+					expectedDistance: -1,
+					expectedOK:       true,
+				},
+				{
+					s: 3, v: 2,
+					expectedPath:     []DirectedEdge{{3, 1, -1}, {1, 0, 4}, {0, 2, -2}},
+					expectedDistance: 1,
+					expectedOK:       true,
+				},
+				{
+					s: 2, v: 2,
+					expectedPath:     []DirectedEdge{},
+					expectedDistance: 0,
+					expectedOK:       true,
+				},
+			},
+		},
+		{
+			name: "Unreachable",
+			V:    3,
+			edges: []DirectedEdge{
+				{0, 1, 0.5}, {0, 1, 0.25}, {1, 0, 0.75},
+			},
+			expectedDistances: [][]float64{
+				{0, 0.25, inf},
+				{0.75, 0, inf},
+				{inf, inf, 0},
+			},
+			expectedCycle: false,
+			pathTests: []pathTest{
+				{
+					s: 1, v: 1,
+					expectedPath:     []DirectedEdge{},
+					expectedDistance: 0,
+					expectedOK:       true,
+				},
+				{
+					s: 0, v: 1,
+					expectedPath:     []DirectedEdge{{0, 1, 0.25}},
+					expectedDistance: 0.25,
+					expectedOK:       true,
+				},
+				{
+					s: 0, v: 2,
+					expectedPath:     nil,
+					expectedDistance: -1,
+					expectedOK:       false,
+				},
+			},
+		},
+		{
+			name: "NegativeCycle",
+			V:    4,
+			edges: []DirectedEdge{
+				{0, 1, 1}, {1, 2, -1}, {2, 3, -1}, {3, 1, 1},
+			},
+			expectedDistances: nil,
+			expectedCycle:     true,
+			pathTests: []pathTest{
+				{
+					s: 0, v: 1,
+					expectedPath:     nil,
+					expectedDistance: -1,
+					expectedOK:       false,
+				},
+			},
+		},
+		{
+			name: "NegativeSelfLoop",
+			V:    2,
+			edges: []DirectedEdge{
+				{0, 1, 1}, {1, 1, -0.5},
+			},
+			expectedDistances: nil,
+			expectedCycle:     true,
+			pathTests:         []pathTest{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWeightedDirected(tc.V, tc.edges...)
+
+			for _, strategy := range []AllPairsStrategy{FloydWarshall, Johnson} {
+				apsp := g.AllPairsShortestPaths(strategy)
+
+				distances, ok := apsp.Distances()
+				assert.Equal(t, !tc.expectedCycle, ok, "strategy %d", strategy)
+				assert.Equal(t, len(tc.expectedDistances), len(distances), "strategy %d", strategy)
+				for s := range tc.expectedDistances {
+					for v := range tc.expectedDistances[s] {
+						assert.InDelta(t, tc.expectedDistances[s][v], distances[s][v], float64Epsilon, "strategy %d, %d->%d", strategy, s, v)
+					}
+				}
+
+				cycle, ok := apsp.NegativeCycle()
+				assert.Equal(t, tc.expectedCycle, ok, "strategy %d", strategy)
+				if ok {
+					var weight float64
+					for i, e := range cycle {
+						weight += e.Weight()
+						assert.Equal(t, cycle[(i+1)%len(cycle)].From(), e.To(), "strategy %d", strategy)
+					}
+					assert.Less(t, weight, 0.0, "strategy %d", strategy)
+				}
+
+				for _, pt := range tc.pathTests {
+					path, dist, ok := apsp.PathTo(pt.s, pt.v)
+					assert.Equal(t, pt.expectedPath, path, "strategy %d, %d->%d", strategy, pt.s, pt.v)
+					assert.InDelta(t, pt.expectedDistance, dist, float64Epsilon, "strategy %d, %d->%d", strategy, pt.s, pt.v)
+					assert.Equal(t, pt.expectedOK, ok, "strategy %d, %d->%d", strategy, pt.s, pt.v)
+				}
+			}
+		})
+	}
+}
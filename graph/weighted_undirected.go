@@ -351,9 +351,36 @@ func (g *WeightedUndirected) ConnectedComponents() *ConnectedComponents {
 	return cc
 }
 
-// MinimumSpanningTree calculates the minimum spanning tree (or forest) of the graph.
-func (g *WeightedUndirected) MinimumSpanningTree() *MinimumSpanningTree {
-	return newMinimumSpanningTree(g)
+// MinimumSpanningTree calculates the minimum spanning tree (or forest) of the graph using Prim's algorithm.
+func (g *WeightedUndirected) MinimumSpanningTree() *SpanningTree {
+	return newSpanningTree(g, Prim, Minimize)
+}
+
+// MinimumSpanningTreeWith calculates the minimum spanning tree (or forest) of the graph using the given strategy.
+func (g *WeightedUndirected) MinimumSpanningTreeWith(strategy SpanningTreeStrategy) *SpanningTree {
+	return newSpanningTree(g, strategy, Minimize)
+}
+
+// MaximumSpanningTree calculates the maximum spanning tree (or forest) of the graph using the given strategy.
+func (g *WeightedUndirected) MaximumSpanningTree(strategy SpanningTreeStrategy) *SpanningTree {
+	return newSpanningTree(g, strategy, Maximize)
+}
+
+// Assignment calculates a minimum-cost assignment of the graph using the Hungarian algorithm.
+// If the graph is not bipartite, the assignment will be empty.
+func (g *WeightedUndirected) Assignment() *Assignment {
+	return newAssignment(g)
+}
+
+// unweighted returns the undirected graph with the same edges and no weights.
+func (g *WeightedUndirected) unweighted() *Undirected {
+	u := NewUndirected(g.V())
+	for _, e := range g.Edges() {
+		v := e.Either()
+		u.AddEdge(v, e.Other(v))
+	}
+
+	return u
 }
 
 // DOT generates a DOT representation of the graph.
@@ -445,3 +445,173 @@ func TestWeightedGraph(t *testing.T) {
 		})
 	}
 }
+
+func TestWeightedUndirected_SpanningTree(t *testing.T) {
+	tests := []struct {
+		name              string
+		V                 int
+		edges             []UndirectedEdge
+		expectedMinCount  int
+		expectedMinTrees  [][]UndirectedEdge
+		expectedMinWeight float64
+		expectedMaxTrees  [][]UndirectedEdge
+		expectedMaxWeight float64
+	}{
+		{
+			name:              "Empty",
+			V:                 0,
+			edges:             []UndirectedEdge{},
+			expectedMinCount:  0,
+			expectedMinTrees:  [][]UndirectedEdge{},
+			expectedMinWeight: 0,
+			expectedMaxTrees:  [][]UndirectedEdge{},
+			expectedMaxWeight: 0,
+		},
+		{
+			name: "Ties",
+			V:    4,
+			edges: []UndirectedEdge{
+				{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 0, 1}, {0, 2, 1},
+			},
+			expectedMinCount:  1,
+			expectedMinTrees:  nil,
+			expectedMinWeight: 3,
+			expectedMaxTrees:  nil,
+			expectedMaxWeight: 3,
+		},
+		{
+			name: "Disconnected",
+			V:    6,
+			edges: []UndirectedEdge{
+				{0, 1, 1}, {1, 2, 2}, {0, 2, 3}, {3, 4, 5}, {3, 4, 1},
+			},
+			expectedMinCount: 3,
+			expectedMinTrees: [][]UndirectedEdge{
+				{{0, 1, 1}, {1, 2, 2}},
+				{{3, 4, 1}},
+				{},
+			},
+			expectedMinWeight: 4,
+			expectedMaxTrees: [][]UndirectedEdge{
+				{{1, 2, 2}, {0, 2, 3}},
+				{{3, 4, 5}},
+				{},
+			},
+			expectedMaxWeight: 10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWeightedUndirected(tc.V, tc.edges...)
+
+			for _, strategy := range []SpanningTreeStrategy{Prim, Kruskal, Boruvka} {
+				minST := g.MinimumSpanningTreeWith(strategy)
+				assert.Equal(t, tc.expectedMinCount, minST.Count(), "strategy %d", strategy)
+				assert.Len(t, minST.Edges(), tc.V-tc.expectedMinCount, "strategy %d", strategy)
+				assert.InDelta(t, tc.expectedMinWeight, minST.Weight(), float64Epsilon, "strategy %d", strategy)
+
+				maxST := g.MaximumSpanningTree(strategy)
+				assert.Equal(t, tc.expectedMinCount, maxST.Count(), "strategy %d", strategy)
+				assert.Len(t, maxST.Edges(), tc.V-tc.expectedMinCount, "strategy %d", strategy)
+				assert.InDelta(t, tc.expectedMaxWeight, maxST.Weight(), float64Epsilon, "strategy %d", strategy)
+
+				if tc.expectedMinTrees != nil {
+					trees := minST.Trees()
+					assert.Len(t, trees, len(tc.expectedMinTrees), "strategy %d", strategy)
+					for i := range trees {
+						assert.ElementsMatch(t, tc.expectedMinTrees[i], trees[i], "strategy %d", strategy)
+					}
+				}
+
+				if tc.expectedMaxTrees != nil {
+					trees := maxST.Trees()
+					assert.Len(t, trees, len(tc.expectedMaxTrees), "strategy %d", strategy)
+					for i := range trees {
+						assert.ElementsMatch(t, tc.expectedMaxTrees[i], trees[i], "strategy %d", strategy)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedUndirected_Assignment(t *testing.T) {
+	tests := []struct {
+		name          string
+		V             int
+		edges         []UndirectedEdge
+		expectedEdges []UndirectedEdge
+		expectedCost  float64
+		expectedMates []int
+	}{
+		{
+			name: "Square",
+			V:    6,
+			edges: []UndirectedEdge{
+				{0, 3, 4}, {0, 4, 1}, {0, 5, 3},
+				{1, 3, 2}, {1, 4, 0}, {1, 5, 5},
+				{2, 3, 3}, {2, 4, 2}, {2, 5, 2},
+			},
+			expectedEdges: []UndirectedEdge{{1, 3, 2}, {0, 4, 1}, {2, 5, 2}},
+			expectedCost:  5,
+			expectedMates: []int{4, 3, 5, 1, 0, 2},
+		},
+		{
+			name: "Unbalanced",
+			V:    5,
+			edges: []UndirectedEdge{
+				{0, 3, 5}, {1, 3, 1}, {1, 4, 4}, {2, 4, 3},
+			},
+			expectedEdges: []UndirectedEdge{{1, 3, 1}, {2, 4, 3}},
+			expectedCost:  4,
+			expectedMates: []int{-1, 3, 4, 1, 2},
+		},
+		{
+			name: "MissingEdge",
+			V:    4,
+			edges: []UndirectedEdge{
+				{0, 2, 1}, {0, 3, 10}, {1, 2, 1},
+			},
+			expectedEdges: []UndirectedEdge{{1, 2, 1}, {0, 3, 10}},
+			expectedCost:  11,
+			expectedMates: []int{3, 2, 1, 0},
+		},
+		{
+			name: "NegativeWeights",
+			V:    4,
+			edges: []UndirectedEdge{
+				{0, 2, -1}, {0, 3, -5}, {1, 2, -2}, {1, 3, -3},
+			},
+			expectedEdges: []UndirectedEdge{{1, 2, -2}, {0, 3, -5}},
+			expectedCost:  -7,
+			expectedMates: []int{3, 2, 1, 0},
+		},
+		{
+			name: "NotBipartite",
+			V:    3,
+			edges: []UndirectedEdge{
+				{0, 1, 1}, {1, 2, 1}, {2, 0, 1},
+			},
+			expectedEdges: []UndirectedEdge{},
+			expectedCost:  0,
+			expectedMates: []int{-1, -1, -1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWeightedUndirected(tc.V, tc.edges...)
+			a := g.Assignment()
+
+			assert.Equal(t, tc.expectedEdges, a.Edges())
+			assert.InDelta(t, tc.expectedCost, a.Cost(), float64Epsilon)
+
+			for v, expectedMate := range tc.expectedMates {
+				mate, ok := a.Mate(v)
+				assert.Equal(t, expectedMate, mate)
+				assert.Equal(t, expectedMate != -1, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Package dfa provides a table-driven lexer generated from token definitions.
+//
+// Each token (terminal symbol) is defined by a regular expression.
+// The regular expressions are compiled into NFAs, converted into minimized DFAs,
+// and combined into a single DFA that recognizes all tokens at once.
+// The combined DFA keeps track of which token each final state belongs to.
+//
+// The lexer simulates the combined DFA on the input and follows two rules to resolve ambiguities:
+//
+//   - Longest match: the lexer always prefers the longest prefix of the remaining input that matches a token.
+//   - Rule priority: if the longest prefix matches more than one token, the token defined first is chosen.
+//
+// For more information and details, see "Compilers: Principles, Techniques, and Tools (2nd Edition)".
+package dfa
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/moorara/algo/automata"
+	errs "github.com/moorara/algo/errors"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/lexer/input"
+	"github.com/moorara/algo/regex"
+)
+
+// Rule defines a token (terminal symbol) by a regular expression.
+type Rule struct {
+	Terminal grammar.Terminal
+	Regex    string
+}
+
+// String implements the fmt.Stringer interface.
+func (r Rule) String() string {
+	return fmt.Sprintf("%s → /%s/", r.Terminal, r.Regex)
+}
+
+// Table represents the transition table of a lexer built from a list of rules.
+// It is immutable and can be shared by multiple lexers.
+type Table struct {
+	rules  []Rule
+	skip   int // The index of the first skip rule.
+	dfa    *automata.DFA
+	runner *automata.DFARunner
+	final  map[automata.State]int // Maps each final state to the index of the rule it recognizes.
+}
+
+// BuildTable constructs a lexer transition table from a list of token rules and a list of skip patterns.
+//
+// Rules are prioritized by their order; a rule defined earlier takes precedence over the rules after it.
+// Skip patterns (e.g., whitespaces and comments) have the lowest priority,
+// and the lexemes matching them are discarded by the lexer.
+//
+// An error is returned if any of the regular expressions is invalid or matches the empty string.
+func BuildTable(rules []Rule, skip []string) (*Table, error) {
+	var err error
+
+	all := make([]Rule, 0, len(rules)+len(skip))
+	all = append(all, rules...)
+	for _, s := range skip {
+		all = append(all, Rule{Regex: s})
+	}
+
+	ds := make([]*automata.DFA, len(all))
+	for i, r := range all {
+		nfa, e := regex.Compile(r.Regex)
+		if e != nil {
+			err = errs.Append(err, fmt.Errorf("invalid regular expression /%s/: %s", r.Regex, e))
+			continue
+		}
+
+		ds[i] = nfa.ToDFA().Minimize()
+
+		// A token matching the empty string would never advance the input.
+		if d := ds[i]; d.Runner().Accept(automata.String{}) {
+			err = errs.Append(err, fmt.Errorf("regular expression /%s/ matches the empty string", r.Regex))
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	dfa, finalMap := automata.UnionDFA(ds...)
+
+	final := make(map[automata.State]int)
+
+	// Iterate over the rules in reverse order, so rules defined earlier take precedence.
+	for i := len(finalMap) - 1; i >= 0; i-- {
+		for _, f := range finalMap[i] {
+			final[f] = i
+		}
+	}
+
+	return &Table{
+		rules:  all,
+		skip:   len(rules),
+		dfa:    dfa,
+		runner: dfa.Runner(),
+		final:  final,
+	}, nil
+}
+
+// DFA returns the combined DFA recognizing all tokens.
+func (t *Table) DFA() *automata.DFA {
+	return t.dfa
+}
+
+// String implements the fmt.Stringer interface.
+func (t *Table) String() string {
+	var b bytes.Buffer
+
+	b.WriteString("Rules:\n")
+	for i, r := range t.rules {
+		if i < t.skip {
+			fmt.Fprintf(&b, "  %d. %s\n", i+1, r)
+		} else {
+			fmt.Fprintf(&b, "  %d. skip → /%s/\n", i+1, r.Regex)
+		}
+	}
+
+	b.WriteString("\nFinal states:\n")
+	for _, f := range t.dfa.Final() {
+		fmt.Fprintf(&b, "  %d: rule %d\n", f, t.final[f]+1)
+	}
+
+	return b.String()
+}
+
+// Lexer is a table-driven lexer.
+// It implements the lexer.Lexer interface.
+type Lexer struct {
+	In *input.Input
+	T  *Table
+}
+
+// New creates a new table-driven lexer for a list of token rules and a list of skip patterns.
+// It requires an input reader for reading the input characters.
+func New(in *input.Input, rules []Rule, skip []string) (*Lexer, error) {
+	T, err := BuildTable(rules, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Lexer{
+		In: in,
+		T:  T,
+	}, nil
+}
+
+// NextToken reads characters from the input source and returns the next token.
+//
+// When the end of input is reached, it returns an io.EOF error alongside a token holding the position of the end of input.
+// If no token matches the next characters, the offending character is skipped and a *LexError is returned.
+func (l *Lexer) NextToken() (lexer.Token, error) {
+	for {
+		// The index of the rule and the number of runes for the longest match seen so far.
+		rule, length := -1, 0
+
+		var count int
+		var err error
+
+		// Simulate the DFA until there is no transition or the end of input is reached.
+		for s := l.T.dfa.Start(); ; {
+			var r rune
+			if r, err = l.In.Next(); err != nil {
+				break
+			}
+
+			count++
+
+			if s = l.T.runner.Next(s, automata.Symbol(r)); s == -1 {
+				break
+			}
+
+			if i, ok := l.T.final[s]; ok {
+				rule, length = i, count
+			}
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return lexer.Token{}, err
+		}
+
+		// The end of input is reached.
+		if count == 0 {
+			return lexer.Token{Pos: l.In.Skip()}, io.EOF
+		}
+
+		// No token matches the next characters.
+		if rule == -1 {
+			for ; count > 1; count-- {
+				l.In.Retract()
+			}
+
+			lexeme, pos := l.In.Lexeme()
+
+			return lexer.Token{Pos: pos}, &LexError{
+				Description: fmt.Sprintf("unexpected character %q", lexeme),
+				Pos:         pos,
+			}
+		}
+
+		// Retract the characters read after the longest match.
+		for ; count > length; count-- {
+			l.In.Retract()
+		}
+
+		if rule >= l.T.skip {
+			l.In.Skip()
+			continue
+		}
+
+		lexeme, pos := l.In.Lexeme()
+
+		return lexer.Token{
+			Terminal: l.T.rules[rule].Terminal,
+			Lexeme:   lexeme,
+			Pos:      pos,
+		}, nil
+	}
+}
+
+// LexError represents an error encountered when tokenizing an input source.
+type LexError struct {
+	Description string
+	Pos         lexer.Position
+}
+
+// Error implements the error interface.
+// It returns a formatted string describing the error in detail.
+func (e *LexError) Error() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s: %s", e.Pos, e.Description)
+	return b.String()
+}
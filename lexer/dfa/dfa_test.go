@@ -0,0 +1,296 @@
+package dfa
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/lexer/input"
+)
+
+var rules = []Rule{
+	{Terminal: "if", Regex: `if`},
+	{Terminal: "ID", Regex: `[A-Za-z_][0-9A-Za-z_]*`},
+	{Terminal: "NUM", Regex: `[0-9]+(\.[0-9]+)?`},
+	{Terminal: "==", Regex: `==`},
+	{Terminal: "=", Regex: `=`},
+}
+
+var skip = []string{
+	`[ \t\n]+`,
+	`#[^\n]*`,
+}
+
+func TestRule_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		r              Rule
+		expectedString string
+	}{
+		{
+			name:           "OK",
+			r:              Rule{Terminal: "NUM", Regex: `[0-9]+`},
+			expectedString: `"NUM" → /[0-9]+/`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.r.String())
+		})
+	}
+}
+
+func TestBuildTable(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         []Rule
+		skip          []string
+		expectedError string
+	}{
+		{
+			name:  "OK",
+			rules: rules,
+			skip:  skip,
+		},
+		{
+			name: "InvalidRegex",
+			rules: []Rule{
+				{Terminal: "ID", Regex: `[a-z`},
+			},
+			skip:          []string{`(a`},
+			expectedError: "invalid regular expression /[a-z/: 0: missing closing ]\ninvalid regular expression /(a/: 2: missing closing )\n",
+		},
+		{
+			name: "EmptyMatch",
+			rules: []Rule{
+				{Terminal: "NUM", Regex: `[0-9]*`},
+			},
+			expectedError: "regular expression /[0-9]*/ matches the empty string\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			T, err := BuildTable(tc.rules, tc.skip)
+
+			if tc.expectedError == "" {
+				assert.NotNil(t, T)
+				assert.NotNil(t, T.DFA())
+				assert.NoError(t, err)
+			} else {
+				assert.Nil(t, T)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestTable_String(t *testing.T) {
+	tests := []struct {
+		name               string
+		rules              []Rule
+		skip               []string
+		expectedSubstrings []string
+	}{
+		{
+			name:  "OK",
+			rules: rules,
+			skip:  skip,
+			expectedSubstrings: []string{
+				"Rules:\n",
+				"  1. \"if\" → /if/\n",
+				"  2. \"ID\" → /[A-Za-z_][0-9A-Za-z_]*/\n",
+				"  5. \"=\" → /=/\n",
+				"  6. skip → /[ \\t\\n]+/\n",
+				"  7. skip → /#[^\\n]*/\n",
+				"Final states:\n",
+				": rule 1\n",
+				": rule 7\n",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			T, err := BuildTable(tc.rules, tc.skip)
+			assert.NoError(t, err)
+
+			s := T.String()
+			for _, expectedSubstring := range tc.expectedSubstrings {
+				assert.Contains(t, s, expectedSubstring)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         []Rule
+		skip          []string
+		expectedError string
+	}{
+		{
+			name:  "OK",
+			rules: rules,
+			skip:  skip,
+		},
+		{
+			name: "Error",
+			rules: []Rule{
+				{Terminal: "ID", Regex: `[a-z`},
+			},
+			expectedError: "invalid regular expression /[a-z/: 0: missing closing ]\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in, err := input.New("test", strings.NewReader("x"), 64)
+			assert.NoError(t, err)
+
+			l, err := New(in, tc.rules, tc.skip)
+
+			if tc.expectedError == "" {
+				assert.NotNil(t, l)
+				assert.NoError(t, err)
+			} else {
+				assert.Nil(t, l)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestLexer_NextToken(t *testing.T) {
+	type result struct {
+		token         lexer.Token
+		expectedError string
+	}
+
+	pos := func(offset, line, column int) lexer.Position {
+		return lexer.Position{Filename: "test", Offset: offset, Line: line, Column: column}
+	}
+
+	tests := []struct {
+		name            string
+		src             string
+		n               int
+		expectedResults []result
+	}{
+		{
+			name: "Tokens",
+			src:  "if iffy == 3.14 # comment\nx=10",
+			n:    64,
+			expectedResults: []result{
+				{token: lexer.Token{Terminal: "if", Lexeme: "if", Pos: pos(0, 1, 1)}},
+				{token: lexer.Token{Terminal: "ID", Lexeme: "iffy", Pos: pos(3, 1, 4)}},
+				{token: lexer.Token{Terminal: "==", Lexeme: "==", Pos: pos(8, 1, 9)}},
+				{token: lexer.Token{Terminal: "NUM", Lexeme: "3.14", Pos: pos(11, 1, 12)}},
+				{token: lexer.Token{Terminal: "ID", Lexeme: "x", Pos: pos(26, 2, 1)}},
+				{token: lexer.Token{Terminal: "=", Lexeme: "=", Pos: pos(27, 2, 2)}},
+				{token: lexer.Token{Terminal: "NUM", Lexeme: "10", Pos: pos(28, 2, 3)}},
+				{token: lexer.Token{Pos: pos(30, 2, 5)}, expectedError: "EOF"},
+			},
+		},
+		{
+			name: "LongestMatch",
+			src:  "1.x",
+			n:    64,
+			expectedResults: []result{
+				{token: lexer.Token{Terminal: "NUM", Lexeme: "1", Pos: pos(0, 1, 1)}},
+				{token: lexer.Token{Pos: pos(1, 1, 2)}, expectedError: `test:1:2: unexpected character "."`},
+				{token: lexer.Token{Terminal: "ID", Lexeme: "x", Pos: pos(2, 1, 3)}},
+				{token: lexer.Token{Pos: pos(3, 1, 4)}, expectedError: "EOF"},
+			},
+		},
+		{
+			name: "UnexpectedCharacter",
+			src:  "a @ b",
+			n:    8,
+			expectedResults: []result{
+				{token: lexer.Token{Terminal: "ID", Lexeme: "a", Pos: pos(0, 1, 1)}},
+				{token: lexer.Token{Pos: pos(2, 1, 3)}, expectedError: `test:1:3: unexpected character "@"`},
+				{token: lexer.Token{Terminal: "ID", Lexeme: "b", Pos: pos(4, 1, 5)}},
+				{token: lexer.Token{Pos: pos(5, 1, 6)}, expectedError: "EOF"},
+			},
+		},
+		{
+			name: "SmallBuffer",
+			src:  "abc 12 de",
+			n:    8,
+			expectedResults: []result{
+				{token: lexer.Token{Terminal: "ID", Lexeme: "abc", Pos: pos(0, 1, 1)}},
+				{token: lexer.Token{Terminal: "NUM", Lexeme: "12", Pos: pos(4, 1, 5)}},
+				{token: lexer.Token{Terminal: "ID", Lexeme: "de", Pos: pos(7, 1, 8)}},
+				{token: lexer.Token{Pos: pos(9, 1, 10)}, expectedError: "EOF"},
+			},
+		},
+		{
+			name: "InvalidUTF8",
+			src:  "a\xffb",
+			n:    64,
+			expectedResults: []result{
+				{token: lexer.Token{}, expectedError: "test:1:2: invalid utf-8 character"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in, err := input.New("test", strings.NewReader(tc.src), tc.n)
+			assert.NoError(t, err)
+
+			l, err := New(in, rules, skip)
+			assert.NoError(t, err)
+
+			for _, expected := range tc.expectedResults {
+				token, err := l.NextToken()
+
+				assert.Equal(t, expected.token, token)
+				if expected.expectedError == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, expected.expectedError)
+				}
+			}
+
+			if last := tc.expectedResults[len(tc.expectedResults)-1]; last.expectedError == "EOF" {
+				_, err := l.NextToken()
+				assert.Equal(t, io.EOF, err)
+			}
+		})
+	}
+}
+
+func TestLexError(t *testing.T) {
+	tests := []struct {
+		name          string
+		e             *LexError
+		expectedError string
+	}{
+		{
+			name: "OK",
+			e: &LexError{
+				Description: `unexpected character "@"`,
+				Pos: lexer.Position{
+					Filename: "test",
+					Offset:   2,
+					Line:     1,
+					Column:   3,
+				},
+			},
+			expectedError: `test:1:3: unexpected character "@"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, tc.e, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package dfa_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/moorara/algo/lexer/dfa"
+	"github.com/moorara/algo/lexer/input"
+)
+
+func ExampleLexer() {
+	src := strings.NewReader("x = 42 + y1")
+
+	in, err := input.New("example", src, 4096)
+	if err != nil {
+		panic(err)
+	}
+
+	rules := []dfa.Rule{
+		{Terminal: "ID", Regex: `[A-Za-z_][0-9A-Za-z_]*`},
+		{Terminal: "NUM", Regex: `[0-9]+`},
+		{Terminal: "=", Regex: `=`},
+		{Terminal: "+", Regex: `\+`},
+	}
+
+	skip := []string{`\s+`}
+
+	l, err := dfa.New(in, rules, skip)
+	if err != nil {
+		panic(err)
+	}
+
+	for {
+		token, err := l.NextToken()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			panic(err)
+		}
+
+		fmt.Printf("%s %q %s\n", token.Terminal, token.Lexeme, token.Pos)
+	}
+
+	// Output:
+	// "ID" "x" example:1:1
+	// "=" "=" example:1:3
+	// "NUM" "42" example:1:5
+	// "+" "+" example:1:8
+	// "ID" "y1" example:1:10
+}
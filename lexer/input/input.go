@@ -42,6 +42,10 @@ type Input struct {
 	runeSizes   list.Stack[int] // Tracks the size of runes read between lexemeBegin and forward.
 	lastColumns list.Stack[int] // Tracks the last column numbers for each line between lexemeBegin and forward.
 
+	// Tracks whether the half ahead of the forward pointer is already loaded.
+	// This happens when the forward pointer is retracted from one half back to the other half.
+	loaded bool
+
 	err error // Last error encountered.
 }
 
@@ -77,15 +81,12 @@ func (i *Input) loadFirst() error {
 	high := len(i.buff) / 2
 
 	n, err := i.src.Read(i.buff[:high])
-	if err != nil {
-		return err
-	}
 
 	if n < high {
 		i.buff[n] = eof
 	}
 
-	return nil
+	return err
 }
 
 // loadSecond reads the input and loads the second sub-buffer.
@@ -93,15 +94,12 @@ func (i *Input) loadSecond() error {
 	low, high := len(i.buff)/2, len(i.buff)
 
 	n, err := i.src.Read(i.buff[low:high])
-	if err != nil {
-		return err
-	}
 
 	if n < high-low {
 		i.buff[low+n] = eof
 	}
 
-	return nil
+	return err
 }
 
 // next returns the current byte at the forward pointer and advances the forward pointer to the next byte.
@@ -114,16 +112,26 @@ func (i *Input) next() (byte, error) {
 	i.forward++
 
 	// Determine whether or not the forward pointer has reached the end of any halves.
-	// If so, it loads the other half and set the forward pointer to the beginning of it.
+	// If so, it loads the other half (unless already loaded) and set the forward pointer to the beginning of it.
 	// If the forward pointer has reached to the end of input, an io.EOF error will be returned.
-	if i.forward == len(i.buff)/2 { // Is forward at the end of first half?
+	if i.forward == len(i.buff)/2 && !i.loaded { // Is forward at the end of first half?
 		i.err = i.loadSecond()
-	} else if i.forward == len(i.buff) { // Is forward at the end of second half?
+	} else if i.forward == len(i.buff) && !i.loaded { // Is forward at the end of second half?
 		if i.err = i.loadFirst(); i.err == nil {
 			i.forward = 0 // beginning of the first half
 		}
-	} else if i.buff[i.forward] == eof {
-		i.err = io.EOF
+	} else {
+		if i.forward == len(i.buff) {
+			i.forward = 0 // beginning of the first half
+		}
+
+		if i.forward == 0 || i.forward == len(i.buff)/2 {
+			i.loaded = false
+		}
+
+		if i.buff[i.forward] == eof {
+			i.err = io.EOF
+		}
 	}
 
 	// The current read is fine, but the next one may return an error
@@ -243,11 +251,25 @@ func (i *Input) Next() (rune, error) {
 // Retract recedes to the last rune in the input.
 func (i *Input) Retract() {
 	if size, ok := i.runeSizes.Pop(); ok {
+		half := len(i.buff) / 2
+		prev := i.forward
+
 		i.forward -= size
 		if i.forward < 0 { // adjust the forward pointer if needed
 			i.forward += len(i.buff)
 		}
 
+		// If the forward pointer moved back across the two halves,
+		// the half ahead of it is already loaded and must not be reloaded.
+		if (prev < half) != (i.forward < half) {
+			i.loaded = true
+		}
+
+		// The end of input is ahead of the forward pointer again.
+		if i.err == io.EOF {
+			i.err = nil
+		}
+
 		// Check for new line
 		if i.buff[i.forward] == '\n' {
 			if lastColumn, ok := i.lastColumns.Pop(); ok {
@@ -573,6 +573,72 @@ func TestInput_Retract(t *testing.T) {
 	}
 }
 
+func TestInput_RetractAndNext(t *testing.T) {
+	tests := []struct {
+		name          string
+		n             int
+		src           string
+		retractCount  int
+		expectedRunes string
+	}{
+		{
+			name:          "RetractFromEOF",
+			n:             64,
+			src:           "Lorem ipsum",
+			retractCount:  5,
+			expectedRunes: "ipsum",
+		},
+		{
+			name:          "RetractAcrossHalves",
+			n:             4,
+			src:           "Lorem",
+			retractCount:  3,
+			expectedRunes: "rem",
+		},
+		{
+			name:          "RetractAcrossWrap",
+			n:             4,
+			src:           "Lorem ipsum dolor",
+			retractCount:  4,
+			expectedRunes: "olor",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in, err := New("test", strings.NewReader(tc.src), tc.n)
+			assert.NoError(t, err)
+
+			// Read all runes and skip them except the last few (to keep the lexeme shorter than the buffer).
+			for i := 0; i < len(tc.src); i++ {
+				_, err := in.Next()
+				assert.NoError(t, err)
+
+				if i < len(tc.src)-tc.retractCount {
+					in.Skip()
+				}
+			}
+
+			_, err = in.Next()
+			assert.Equal(t, io.EOF, err)
+
+			for range tc.retractCount {
+				in.Retract()
+			}
+
+			var runes []rune
+			for r, err := in.Next(); err == nil; r, err = in.Next() {
+				runes = append(runes, r)
+			}
+
+			assert.Equal(t, tc.expectedRunes, string(runes))
+
+			lexeme, _ := in.Lexeme()
+			assert.Equal(t, tc.expectedRunes, lexeme)
+		})
+	}
+}
+
 func TestInput_Lexeme(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,97 @@
+// Package ast provides a toolkit for querying, matching, and rewriting abstract syntax trees (ASTs)
+// built by the parsers in this module (see parser.Node).
+//
+// The toolkit consists of the following pieces:
+//
+//   - Span computes the range of input covered by a node, including its end position.
+//   - Cursor is a zipper over an AST, providing parent pointers and navigation between nodes.
+//   - Selector queries nodes by paths of grammar symbols (e.g., all E nodes under a T node).
+//   - Pattern matches the structure of subtrees and captures parts of them by name.
+//   - Rewrite replaces the subtrees matched by patterns with new subtrees.
+//
+// Selectors and patterns can be written in a compact textual syntax.
+// Non-terminals are written by name (e.g., expr), and terminals are written in double quotes (e.g., "+"),
+// following the string representation of grammar symbols.
+package ast
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+)
+
+// Span represents the range of input covered by a node.
+// Start is the position of the first character, and End is the position right after the last character.
+type Span struct {
+	Start lexer.Position
+	End   lexer.Position
+}
+
+// String returns a string representation of a span.
+func (s Span) String() string {
+	return fmt.Sprintf("%s-%s", s.Start, s.End)
+}
+
+// IsZero checks if a span is a zero (empty) value.
+// The span of a node that does not cover any input (e.g., a node for an ε-production) is zero.
+func (s Span) IsZero() bool {
+	return s.Start.IsZero() && s.End.IsZero()
+}
+
+// Contains determines whether or not a span contains a byte offset in the input.
+func (s Span) Contains(offset int) bool {
+	return s.Start.Offset <= offset && offset < s.End.Offset
+}
+
+// SpanOf returns the range of input covered by a node.
+//
+// The span of a leaf node starts at its position and ends after its lexeme.
+// The span of an internal node starts at its first leaf and ends after its last leaf.
+func SpanOf(n parser.Node) Span {
+	var first, last *parser.LeafNode
+
+	parser.Traverse(n, generic.VLR, func(n parser.Node) bool {
+		if leaf, ok := n.(*parser.LeafNode); ok {
+			if first == nil {
+				first = leaf
+			}
+			last = leaf
+		}
+		return true
+	})
+
+	if first == nil {
+		return Span{}
+	}
+
+	return Span{
+		Start: first.Position,
+		End:   End(last),
+	}
+}
+
+// End returns the position right after the lexeme of a leaf node.
+//
+// The offset is advanced by the number of bytes in the lexeme, since offsets are byte offsets.
+// If the position of the leaf has line and column numbers, they are advanced by runes accordingly.
+func End(n *parser.LeafNode) lexer.Position {
+	pos := n.Position
+
+	for _, r := range n.Lexeme {
+		pos.Offset += utf8.RuneLen(r)
+
+		if pos.Line > 0 {
+			if r == '\n' {
+				pos.Line++
+				pos.Column = 1
+			} else {
+				pos.Column++
+			}
+		}
+	}
+
+	return pos
+}
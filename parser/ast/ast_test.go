@@ -0,0 +1,127 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+)
+
+func TestSpan_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		s              Span
+		expectedString string
+	}{
+		{
+			name:           "Zero",
+			s:              Span{},
+			expectedString: `0-0`,
+		},
+		{
+			name: "WithPositions",
+			s: Span{
+				Start: lexer.Position{Filename: "test", Offset: 2, Line: 1, Column: 3},
+				End:   lexer.Position{Filename: "test", Offset: 5, Line: 1, Column: 6},
+			},
+			expectedString: `test:1:3-test:1:6`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.s.String())
+		})
+	}
+}
+
+func TestSpan_IsZero(t *testing.T) {
+	assert.True(t, Span{}.IsZero())
+	assert.False(t, Span{End: lexer.Position{Offset: 1}}.IsZero())
+}
+
+func TestSpan_Contains(t *testing.T) {
+	s := Span{
+		Start: lexer.Position{Offset: 2},
+		End:   lexer.Position{Offset: 5},
+	}
+
+	assert.False(t, s.Contains(1))
+	assert.True(t, s.Contains(2))
+	assert.True(t, s.Contains(4))
+	assert.False(t, s.Contains(5))
+}
+
+func TestSpanOf(t *testing.T) {
+	root := getTestAST()
+
+	tests := []struct {
+		name         string
+		n            parser.Node
+		expectedSpan Span
+	}{
+		{
+			name:         "Root",
+			n:            root,
+			expectedSpan: Span{Start: lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1}, End: lexer.Position{Filename: "test", Offset: 15, Line: 1, Column: 16}},
+		},
+		{
+			name:         "Internal",
+			n:            root.Children[2],
+			expectedSpan: Span{Start: lexer.Position{Filename: "test", Offset: 4, Line: 1, Column: 5}, End: lexer.Position{Filename: "test", Offset: 15, Line: 1, Column: 16}},
+		},
+		{
+			name:         "Leaf",
+			n:            root.Children[1],
+			expectedSpan: Span{Start: lexer.Position{Filename: "test", Offset: 2, Line: 1, Column: 3}, End: lexer.Position{Filename: "test", Offset: 3, Line: 1, Column: 4}},
+		},
+		{
+			name:         "Empty",
+			n:            &parser.InternalNode{NonTerminal: "E"},
+			expectedSpan: Span{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSpan, SpanOf(tc.n))
+		})
+	}
+}
+
+func TestEnd(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           *parser.LeafNode
+		expectedEnd lexer.Position
+	}{
+		{
+			name:        "SingleLine",
+			n:           &parser.LeafNode{Terminal: "id", Lexeme: "count", Position: lexer.Position{Offset: 4, Line: 2, Column: 3}},
+			expectedEnd: lexer.Position{Offset: 9, Line: 2, Column: 8},
+		},
+		{
+			name:        "MultipleLines",
+			n:           &parser.LeafNode{Terminal: "str", Lexeme: "\"ab\ncd\"", Position: lexer.Position{Offset: 4, Line: 2, Column: 3}},
+			expectedEnd: lexer.Position{Offset: 11, Line: 3, Column: 4},
+		},
+		{
+			name:        "MultiByte",
+			n:           &parser.LeafNode{Terminal: "id", Lexeme: "αβγ", Position: lexer.Position{Offset: 0, Line: 1, Column: 1}},
+			expectedEnd: lexer.Position{Offset: 6, Line: 1, Column: 4},
+		},
+		{
+			name:        "OffsetOnly",
+			n:           &parser.LeafNode{Terminal: "id", Lexeme: "a\nb", Position: lexer.Position{Offset: 7}},
+			expectedEnd: lexer.Position{Offset: 10},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedEnd, End(tc.n))
+		})
+	}
+}
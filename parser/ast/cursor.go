@@ -0,0 +1,220 @@
+package ast
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/moorara/algo/parser"
+)
+
+// Cursor is a zipper over an abstract syntax tree (AST).
+//
+// A cursor points to a node in an AST and remembers the path from the root to the node.
+// This provides parent pointers for the nodes of an AST without modifying the nodes themselves,
+// and allows navigating to the parent, children, and siblings of a node.
+//
+// Cursors are immutable. Navigation methods return new cursors, and Replace returns
+// a cursor into a new AST that shares all unchanged subtrees with the original AST.
+type Cursor struct {
+	node   parser.Node
+	parent *Cursor
+	index  int // The index of the node among the children of its parent.
+}
+
+// NewCursor creates a new cursor pointing to the root of an AST.
+func NewCursor(root parser.Node) *Cursor {
+	return &Cursor{
+		node:  root,
+		index: -1,
+	}
+}
+
+// String returns a string representation of a cursor.
+// It consists of the symbols of the nodes on the path from the root to the current node.
+func (c *Cursor) String() string {
+	var b bytes.Buffer
+
+	for i, n := range c.Path() {
+		if i > 0 {
+			b.WriteString(" > ")
+		}
+		b.WriteString(n.Symbol().String())
+	}
+
+	return b.String()
+}
+
+// Node returns the node the cursor points to.
+func (c *Cursor) Node() parser.Node {
+	return c.node
+}
+
+// Parent returns a cursor pointing to the parent of the current node.
+// It returns nil if the current node is the root.
+func (c *Cursor) Parent() *Cursor {
+	return c.parent
+}
+
+// Index returns the index of the current node among the children of its parent.
+// It returns -1 if the current node is the root.
+func (c *Cursor) Index() int {
+	return c.index
+}
+
+// IsRoot returns true if the current node is the root of the AST.
+func (c *Cursor) IsRoot() bool {
+	return c.parent == nil
+}
+
+// Root returns a cursor pointing to the root of the AST.
+func (c *Cursor) Root() *Cursor {
+	for c.parent != nil {
+		c = c.parent
+	}
+
+	return c
+}
+
+// Depth returns the number of edges from the root to the current node.
+func (c *Cursor) Depth() int {
+	d := 0
+	for p := c.parent; p != nil; p = p.parent {
+		d++
+	}
+
+	return d
+}
+
+// Path returns the nodes on the path from the root to the current node.
+func (c *Cursor) Path() []parser.Node {
+	path := []parser.Node{}
+	for p := c; p != nil; p = p.parent {
+		path = append(path, p.node)
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
+
+// Span returns the range of input covered by the current node.
+func (c *Cursor) Span() Span {
+	return SpanOf(c.node)
+}
+
+// NumChildren returns the number of children of the current node.
+func (c *Cursor) NumChildren() int {
+	if in, ok := c.node.(*parser.InternalNode); ok {
+		return len(in.Children)
+	}
+
+	return 0
+}
+
+// Child returns a cursor pointing to the i-th child of the current node.
+// It returns nil if the current node does not have an i-th child.
+func (c *Cursor) Child(i int) *Cursor {
+	in, ok := c.node.(*parser.InternalNode)
+	if !ok || i < 0 || i >= len(in.Children) {
+		return nil
+	}
+
+	return &Cursor{
+		node:   in.Children[i],
+		parent: c,
+		index:  i,
+	}
+}
+
+// FirstChild returns a cursor pointing to the first child of the current node.
+// It returns nil if the current node does not have any children.
+func (c *Cursor) FirstChild() *Cursor {
+	return c.Child(0)
+}
+
+// LastChild returns a cursor pointing to the last child of the current node.
+// It returns nil if the current node does not have any children.
+func (c *Cursor) LastChild() *Cursor {
+	return c.Child(c.NumChildren() - 1)
+}
+
+// NextSibling returns a cursor pointing to the next sibling of the current node.
+// It returns nil if the current node is the root or the last child of its parent.
+func (c *Cursor) NextSibling() *Cursor {
+	if c.parent == nil {
+		return nil
+	}
+
+	return c.parent.Child(c.index + 1)
+}
+
+// PrevSibling returns a cursor pointing to the previous sibling of the current node.
+// It returns nil if the current node is the root or the first child of its parent.
+func (c *Cursor) PrevSibling() *Cursor {
+	if c.parent == nil {
+		return nil
+	}
+
+	return c.parent.Child(c.index - 1)
+}
+
+// Walk performs a depth-first traversal of the subtree rooted at the current node in pre-order.
+// It passes a cursor for each node to the provided visit function.
+// If the visit function returns false, the traversal is stopped early.
+func (c *Cursor) Walk(visit func(*Cursor) bool) bool {
+	if !visit(c) {
+		return false
+	}
+
+	for i := range c.NumChildren() {
+		if !c.Child(i).Walk(visit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Replace replaces the current node with a new node and returns a cursor pointing to the new node.
+//
+// The original AST is not modified. Instead, the ancestors of the current node are copied
+// with their annotations, and the new AST shares all other subtrees with the original AST.
+// The root of the new AST is available through the Root method of the returned cursor.
+func (c *Cursor) Replace(n parser.Node) *Cursor {
+	if c.parent == nil {
+		return NewCursor(n)
+	}
+
+	in := c.parent.node.(*parser.InternalNode)
+
+	dup := &parser.InternalNode{
+		NonTerminal: in.NonTerminal,
+		Production:  in.Production,
+		Children:    slices.Clone(in.Children),
+	}
+
+	dup.Children[c.index] = n
+	dup.Annotate(in.Annotation())
+
+	return &Cursor{
+		node:   n,
+		parent: c.parent.Replace(dup),
+		index:  c.index,
+	}
+}
+
+// Find returns a cursor pointing to a node in an AST.
+// The node is looked up by identity, and nil is returned if the AST does not contain the node.
+func Find(root, n parser.Node) *Cursor {
+	var found *Cursor
+
+	NewCursor(root).Walk(func(c *Cursor) bool {
+		if c.node == n {
+			found = c
+			return false
+		}
+		return true
+	})
+
+	return found
+}
@@ -0,0 +1,129 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/parser"
+)
+
+func TestNewCursor(t *testing.T) {
+	root := getTestAST()
+	c := NewCursor(root)
+
+	assert.NotNil(t, c)
+	assert.Same(t, root, c.Node())
+	assert.Nil(t, c.Parent())
+	assert.Equal(t, -1, c.Index())
+	assert.True(t, c.IsRoot())
+	assert.Equal(t, 0, c.Depth())
+	assert.Equal(t, "E", c.String())
+}
+
+func TestCursor_Navigation(t *testing.T) {
+	root := getTestAST()
+	c := NewCursor(root)
+
+	assert.Equal(t, 3, c.NumChildren())
+	assert.Nil(t, c.Child(-1))
+	assert.Nil(t, c.Child(3))
+	assert.Nil(t, c.NextSibling())
+	assert.Nil(t, c.PrevSibling())
+
+	first := c.FirstChild()
+	assert.Same(t, root.Children[0], first.Node())
+	assert.Equal(t, 0, first.Index())
+	assert.Same(t, c, first.Parent())
+	assert.Nil(t, first.PrevSibling())
+
+	plus := first.NextSibling()
+	assert.Same(t, root.Children[1], plus.Node())
+	assert.Equal(t, 0, plus.NumChildren())
+	assert.Nil(t, plus.FirstChild())
+	assert.Equal(t, `E > "+"`, plus.String())
+
+	last := c.LastChild()
+	assert.Same(t, root.Children[2], last.Node())
+	assert.Same(t, plus.Node(), last.PrevSibling().Node())
+	assert.Nil(t, last.NextSibling())
+
+	// a + b * (c + 0)
+	zero := last.LastChild().Child(1).LastChild().FirstChild()
+	assert.Equal(t, `E > E > E > E > E > "id"`, zero.String())
+	assert.Equal(t, 5, zero.Depth())
+	assert.Same(t, root, zero.Root().Node())
+	assert.Len(t, zero.Path(), 6)
+	assert.Equal(t, "test:1:14-test:1:15", zero.Span().String())
+}
+
+func TestCursor_Walk(t *testing.T) {
+	root := getTestAST()
+
+	var symbols []string
+	completed := NewCursor(root).Walk(func(c *Cursor) bool {
+		symbols = append(symbols, c.Node().Symbol().String())
+		return true
+	})
+
+	assert.True(t, completed)
+	assert.Equal(t, []string{
+		`E`, `E`, `"id"`, `"+"`, `E`, `E`, `"id"`, `"*"`, `E`, `"("`, `E`, `E`, `"id"`, `"+"`, `E`, `"id"`, `")"`,
+	}, symbols)
+
+	count := 0
+	completed = NewCursor(root).Walk(func(c *Cursor) bool {
+		count++
+		return count < 4
+	})
+
+	assert.False(t, completed)
+	assert.Equal(t, 4, count)
+}
+
+func TestCursor_Replace(t *testing.T) {
+	root := getTestAST()
+	root.Annotate("root")
+
+	t.Run("Root", func(t *testing.T) {
+		n := &parser.LeafNode{Terminal: "id", Lexeme: "x"}
+		c := NewCursor(root).Replace(n)
+
+		assert.True(t, c.IsRoot())
+		assert.Same(t, n, c.Node())
+	})
+
+	t.Run("Descendant", func(t *testing.T) {
+		n := leaf("id", "x", 4)
+
+		// b
+		c := NewCursor(root).LastChild().FirstChild().FirstChild()
+		r := c.Replace(n)
+
+		assert.Same(t, n, r.Node())
+		assert.Equal(t, c.Depth(), r.Depth())
+		assert.Equal(t, c.Index(), r.Index())
+
+		newRoot := r.Root().Node().(*parser.InternalNode)
+		assert.NotSame(t, root, newRoot)
+		assert.Equal(t, "root", newRoot.Annotation())
+
+		// Unchanged subtrees are shared.
+		assert.Same(t, root.Children[0], newRoot.Children[0])
+		assert.Same(t, root.Children[1], newRoot.Children[1])
+
+		// The original AST is not modified.
+		assert.Equal(t, "b", root.Children[2].(*parser.InternalNode).Children[0].(*parser.InternalNode).Children[0].(*parser.LeafNode).Lexeme)
+		assert.Equal(t, "x", newRoot.Children[2].(*parser.InternalNode).Children[0].(*parser.InternalNode).Children[0].(*parser.LeafNode).Lexeme)
+	})
+}
+
+func TestFind(t *testing.T) {
+	root := getTestAST()
+
+	c := Find(root, root.Children[1])
+	assert.NotNil(t, c)
+	assert.Equal(t, 1, c.Index())
+
+	assert.Nil(t, Find(root, leaf("id", "a", 0)))
+}
@@ -0,0 +1,67 @@
+package ast_test
+
+import (
+	"fmt"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+	"github.com/moorara/algo/parser/ast"
+)
+
+func Example() {
+	add := &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}}
+	num := &grammar.Production{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("num")}}
+
+	// 7 + 0
+	root := &parser.InternalNode{
+		NonTerminal: "expr",
+		Production:  add,
+		Children: []parser.Node{
+			&parser.InternalNode{
+				NonTerminal: "expr",
+				Production:  num,
+				Children: []parser.Node{
+					&parser.LeafNode{Terminal: "num", Lexeme: "7", Position: lexer.Position{Offset: 0, Line: 1, Column: 1}},
+				},
+			},
+			&parser.LeafNode{Terminal: "+", Lexeme: "+", Position: lexer.Position{Offset: 2, Line: 1, Column: 3}},
+			&parser.InternalNode{
+				NonTerminal: "expr",
+				Production:  num,
+				Children: []parser.Node{
+					&parser.LeafNode{Terminal: "num", Lexeme: "0", Position: lexer.Position{Offset: 4, Line: 1, Column: 5}},
+				},
+			},
+		},
+	}
+
+	// Query all numbers under expressions.
+	matches, err := ast.Select(root, `expr > expr > "num"`)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, c := range matches {
+		fmt.Printf("%s at %s\n", c.Node(), c.Span())
+	}
+
+	// Simplify the additions of zero.
+	simplified, err := ast.Rewrite(root, &ast.Rule{
+		Pattern: ast.MustCompilePattern(`(expr $x "+" (expr "num"='0'))`),
+		Rewrite: func(_ parser.Node, caps ast.Captures) (parser.Node, error) {
+			return caps["x"], nil
+		},
+	})
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(simplified)
+
+	// Output:
+	// "num" <7, 1:1> at 1:1-1:2
+	// "num" <0, 1:5> at 1:5-1:6
+	// expr → "num" <7, 1:1>
+}
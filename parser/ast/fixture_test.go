@@ -0,0 +1,97 @@
+package ast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/lexer/dfa"
+	"github.com/moorara/algo/lexer/input"
+	"github.com/moorara/algo/parser"
+	"github.com/moorara/algo/parser/lr"
+	"github.com/moorara/algo/parser/lr/simple"
+)
+
+func leaf(a grammar.Terminal, lexeme string, offset int) *parser.LeafNode {
+	return &parser.LeafNode{
+		Terminal: a,
+		Lexeme:   lexeme,
+		Position: lexer.Position{
+			Filename: "test",
+			Offset:   offset,
+			Line:     1,
+			Column:   offset + 1,
+		},
+	}
+}
+
+func node(prod *grammar.Production, children ...parser.Node) *parser.InternalNode {
+	return &parser.InternalNode{
+		NonTerminal: prod.Head,
+		Production:  prod,
+		Children:    children,
+	}
+}
+
+// getTestAST returns the AST for the input string "a + b * (c + 0)" using the following grammar:
+//
+//	E → E + E | E * E | ( E ) | id
+func getTestAST() *parser.InternalNode {
+	return node(parsertest.Prods[4][1], // E → E + E
+		node(parsertest.Prods[4][4], leaf("id", "a", 0)), // E → id
+		leaf("+", "+", 2),
+		node(parsertest.Prods[4][2], // E → E * E
+			node(parsertest.Prods[4][4], leaf("id", "b", 4)), // E → id
+			leaf("*", "*", 6),
+			node(parsertest.Prods[4][3], // E → ( E )
+				leaf("(", "(", 8),
+				node(parsertest.Prods[4][1], // E → E + E
+					node(parsertest.Prods[4][4], leaf("id", "c", 9)), // E → id
+					leaf("+", "+", 11),
+					node(parsertest.Prods[4][4], leaf("id", "0", 13)), // E → id
+				),
+				leaf(")", ")", 14),
+			),
+		),
+	)
+}
+
+// parse parses an input string into an AST using the following grammar:
+//
+//	E → E + E | E * E | ( E ) | id
+func parse(t *testing.T, src string) *parser.InternalNode {
+	rules := []dfa.Rule{
+		{Terminal: "id", Regex: `[0-9a-z]+`},
+		{Terminal: "+", Regex: `\+`},
+		{Terminal: "*", Regex: `\*`},
+		{Terminal: "(", Regex: `\(`},
+		{Terminal: ")", Regex: `\)`},
+	}
+
+	T, err := dfa.BuildTable(rules, []string{`\s+`})
+	assert.NoError(t, err)
+
+	in, err := input.New("test", strings.NewReader(src), 4096)
+	assert.NoError(t, err)
+
+	p, err := simple.New(&dfa.Lexer{In: in, T: T}, parsertest.Grammars[4], lr.PrecedenceLevels{
+		{
+			Associativity: lr.LEFT,
+			Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("*")),
+		},
+		{
+			Associativity: lr.LEFT,
+			Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("+")),
+		},
+	})
+	assert.NoError(t, err)
+
+	root, err := p.ParseAndBuildAST()
+	assert.NoError(t, err)
+
+	return root.(*parser.InternalNode)
+}
@@ -0,0 +1,364 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/parser"
+)
+
+// Captures maps the names of captures in a pattern to the nodes they matched.
+type Captures map[string]parser.Node
+
+// Pattern matches the structure of a subtree in an AST.
+//
+// Patterns can be built using the constructor functions in this package or compiled from a string.
+// A pattern string has the following syntax:
+//
+//   - _ matches any node.
+//   - A non-terminal name (e.g., expr) matches an internal node for that non-terminal with any children.
+//   - (A p₁ p₂ … pₙ) matches an internal node for non-terminal A with exactly n children matching p₁ … pₙ.
+//     The non-terminal can be _ to match an internal node for any non-terminal.
+//   - A double-quoted terminal (e.g., "id") matches a leaf node for that terminal.
+//     It can be followed by a single-quoted lexeme (e.g., "num"='0') to match the lexeme as well.
+//   - $name:p matches a node matching p and captures it by name. $name alone is equivalent to $name:_.
+//     If the same name is captured more than once, all captured subtrees must have the same symbols and lexemes,
+//     regardless of their positions in the source.
+//
+// For example, (expr $x "+" (expr "num"='0')) matches the additions of zero and captures the other operand as x.
+type Pattern interface {
+	fmt.Stringer
+
+	// match determines whether or not a node matches the pattern and records the captured nodes.
+	match(parser.Node, Captures) bool
+}
+
+// Any returns a pattern that matches any node.
+func Any() Pattern {
+	return &anyPattern{}
+}
+
+// Symbol returns a pattern that matches any internal node for a non-terminal, regardless of its children.
+func Symbol(A grammar.NonTerminal) Pattern {
+	return &symbolPattern{A: A}
+}
+
+// Node returns a pattern that matches an internal node for a non-terminal
+// with children matching the given patterns one to one.
+// If A is empty, the pattern matches an internal node for any non-terminal.
+func Node(A grammar.NonTerminal, children ...Pattern) Pattern {
+	return &nodePattern{
+		A:        A,
+		children: children,
+	}
+}
+
+// Leaf returns a pattern that matches a leaf node for a terminal.
+func Leaf(a grammar.Terminal) Pattern {
+	return &leafPattern{a: a}
+}
+
+// Lexeme returns a pattern that matches a leaf node for a terminal with a specific lexeme.
+func Lexeme(a grammar.Terminal, lexeme string) Pattern {
+	return &leafPattern{a: a, lexeme: &lexeme}
+}
+
+// Capture returns a pattern that matches a node matching p and captures it by name.
+func Capture(name string, p Pattern) Pattern {
+	return &capturePattern{
+		name: name,
+		p:    p,
+	}
+}
+
+// anyPattern matches any node.
+type anyPattern struct{}
+
+func (p *anyPattern) String() string {
+	return "_"
+}
+
+func (p *anyPattern) match(parser.Node, Captures) bool {
+	return true
+}
+
+// symbolPattern matches any internal node for a non-terminal.
+type symbolPattern struct {
+	A grammar.NonTerminal
+}
+
+func (p *symbolPattern) String() string {
+	return p.A.String()
+}
+
+func (p *symbolPattern) match(n parser.Node, _ Captures) bool {
+	return p.A.Equal(n.Symbol())
+}
+
+// nodePattern matches an internal node and its children.
+type nodePattern struct {
+	A        grammar.NonTerminal
+	children []Pattern
+}
+
+func (p *nodePattern) String() string {
+	var b bytes.Buffer
+
+	b.WriteRune('(')
+
+	if p.A == "" {
+		b.WriteRune('_')
+	} else {
+		b.WriteString(p.A.String())
+	}
+
+	for _, c := range p.children {
+		b.WriteRune(' ')
+		b.WriteString(c.String())
+	}
+
+	b.WriteRune(')')
+
+	return b.String()
+}
+
+func (p *nodePattern) match(n parser.Node, caps Captures) bool {
+	in, ok := n.(*parser.InternalNode)
+	if !ok || len(in.Children) != len(p.children) {
+		return false
+	}
+
+	if p.A != "" && !p.A.Equal(in.NonTerminal) {
+		return false
+	}
+
+	for i, c := range p.children {
+		if !c.match(in.Children[i], caps) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// leafPattern matches a leaf node.
+type leafPattern struct {
+	a      grammar.Terminal
+	lexeme *string
+}
+
+func (p *leafPattern) String() string {
+	if p.lexeme != nil {
+		return fmt.Sprintf("%s='%s'", p.a, *p.lexeme)
+	}
+
+	return p.a.String()
+}
+
+func (p *leafPattern) match(n parser.Node, _ Captures) bool {
+	leaf, ok := n.(*parser.LeafNode)
+	return ok &&
+		p.a.Equal(leaf.Terminal) &&
+		(p.lexeme == nil || *p.lexeme == leaf.Lexeme)
+}
+
+// capturePattern captures the node matched by another pattern.
+type capturePattern struct {
+	name string
+	p    Pattern
+}
+
+func (p *capturePattern) String() string {
+	if _, ok := p.p.(*anyPattern); ok {
+		return "$" + p.name
+	}
+
+	return fmt.Sprintf("$%s:%s", p.name, p.p)
+}
+
+func (p *capturePattern) match(n parser.Node, caps Captures) bool {
+	if !p.p.match(n, caps) {
+		return false
+	}
+
+	// A repeated capture matches a node with the same structure as the first one,
+	// but at a different position in the source.
+	if m, ok := caps[p.name]; ok {
+		return sameStructure(m, n)
+	}
+
+	caps[p.name] = n
+
+	return true
+}
+
+// sameStructure determines whether or not two nodes represent the same syntax,
+// comparing symbols, production rules, lexemes, and children, but not positions.
+func sameStructure(a, b parser.Node) bool {
+	switch a := a.(type) {
+	case *parser.LeafNode:
+		b, ok := b.(*parser.LeafNode)
+		return ok &&
+			a.Terminal.Equal(b.Terminal) &&
+			a.Lexeme == b.Lexeme
+
+	case *parser.InternalNode:
+		b, ok := b.(*parser.InternalNode)
+		if !ok ||
+			!a.NonTerminal.Equal(b.NonTerminal) ||
+			!a.Production.Equal(b.Production) ||
+			len(a.Children) != len(b.Children) {
+			return false
+		}
+
+		for i := range len(a.Children) {
+			if !sameStructure(a.Children[i], b.Children[i]) {
+				return false
+			}
+		}
+
+		return true
+
+	default:
+		return false
+	}
+}
+
+// CompilePattern parses a pattern string into a pattern.
+func CompilePattern(s string) (Pattern, error) {
+	tokens, err := scan(s)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := &tokenStream{tokens: tokens}
+
+	p, err := parsePattern(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	if t := ts.next(); t.kind != tokenEOF {
+		return nil, ts.unexpected(t)
+	}
+
+	return p, nil
+}
+
+// MustCompilePattern is like CompilePattern but panics if the pattern cannot be parsed.
+func MustCompilePattern(s string) Pattern {
+	p, err := CompilePattern(s)
+	if err != nil {
+		panic(fmt.Sprintf("ast: CompilePattern(%q): %s", s, err))
+	}
+
+	return p
+}
+
+// parsePattern parses a single pattern from a token stream.
+func parsePattern(ts *tokenStream) (Pattern, error) {
+	t := ts.next()
+
+	switch t.kind {
+	case tokenIdent:
+		if t.value == "_" {
+			return Any(), nil
+		}
+		return Symbol(grammar.NonTerminal(t.value)), nil
+
+	case tokenTerminal:
+		if ts.peek().kind != tokenEqual {
+			return Leaf(grammar.Terminal(t.value)), nil
+		}
+
+		ts.next()
+
+		l := ts.next()
+		if l.kind != tokenLexeme {
+			return nil, ts.unexpected(l)
+		}
+
+		return Lexeme(grammar.Terminal(t.value), l.value), nil
+
+	case tokenCapture:
+		if ts.peek().kind != tokenColon {
+			return Capture(t.value, Any()), nil
+		}
+
+		ts.next()
+
+		p, err := parsePattern(ts)
+		if err != nil {
+			return nil, err
+		}
+
+		return Capture(t.value, p), nil
+
+	case tokenLParen:
+		h := ts.next()
+		if h.kind != tokenIdent {
+			return nil, ts.unexpected(h)
+		}
+
+		var A grammar.NonTerminal
+		if h.value != "_" {
+			A = grammar.NonTerminal(h.value)
+		}
+
+		var children []Pattern
+		for ts.peek().kind != tokenRParen {
+			if ts.peek().kind == tokenEOF {
+				return nil, ts.unexpected(ts.next())
+			}
+
+			c, err := parsePattern(ts)
+			if err != nil {
+				return nil, err
+			}
+
+			children = append(children, c)
+		}
+
+		ts.next()
+
+		return Node(A, children...), nil
+
+	default:
+		return nil, ts.unexpected(t)
+	}
+}
+
+// Match determines whether or not a node matches a pattern.
+// If it does, the nodes captured by the pattern are returned.
+func Match(p Pattern, n parser.Node) (Captures, bool) {
+	caps := Captures{}
+	if !p.match(n, caps) {
+		return nil, false
+	}
+
+	return caps, true
+}
+
+// MatchResult is a node in an AST that matches a pattern, along with the nodes captured by the pattern.
+type MatchResult struct {
+	Cursor   *Cursor
+	Captures Captures
+}
+
+// FindAll returns all nodes in an AST that match a pattern in pre-order.
+func FindAll(p Pattern, root parser.Node) []*MatchResult {
+	results := []*MatchResult{}
+
+	NewCursor(root).Walk(func(c *Cursor) bool {
+		if caps, ok := Match(p, c.node); ok {
+			results = append(results, &MatchResult{
+				Cursor:   c,
+				Captures: caps,
+			})
+		}
+		return true
+	})
+
+	return results
+}
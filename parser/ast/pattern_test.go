@@ -0,0 +1,273 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/parser"
+)
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		name            string
+		s               string
+		expectedPattern Pattern
+		expectedError   string
+	}{
+		{
+			name:            "Any",
+			s:               `_`,
+			expectedPattern: Any(),
+		},
+		{
+			name:            "Symbol",
+			s:               `E`,
+			expectedPattern: Symbol("E"),
+		},
+		{
+			name:            "Leaf",
+			s:               `"id"`,
+			expectedPattern: Leaf("id"),
+		},
+		{
+			name:            "Lexeme",
+			s:               `"id"='0'`,
+			expectedPattern: Lexeme("id", "0"),
+		},
+		{
+			name:            "Capture",
+			s:               `$x`,
+			expectedPattern: Capture("x", Any()),
+		},
+		{
+			name:            "CaptureWithPattern",
+			s:               `$x:(E "id")`,
+			expectedPattern: Capture("x", Node("E", Leaf("id"))),
+		},
+		{
+			name:            "Node",
+			s:               `(E $lhs "+" (_ "id"='0'))`,
+			expectedPattern: Node("E", Capture("lhs", Any()), Leaf("+"), Node("", Lexeme("id", "0"))),
+		},
+		{
+			name:            "EmptyNode",
+			s:               `(E)`,
+			expectedPattern: Node("E"),
+		},
+		{
+			name:          "ScanError",
+			s:             `(E "id`,
+			expectedError: `3: unterminated terminal`,
+		},
+		{
+			name:          "MissingLexeme",
+			s:             `"id"=E`,
+			expectedError: `5: unexpected identifier E`,
+		},
+		{
+			name:          "InvalidCapturedPattern",
+			s:             `$x:)`,
+			expectedError: `3: unexpected ")"`,
+		},
+		{
+			name:          "MissingHead",
+			s:             `("id")`,
+			expectedError: `1: unexpected terminal "id"`,
+		},
+		{
+			name:          "UnclosedNode",
+			s:             `(E "id"`,
+			expectedError: `7: unexpected end of input`,
+		},
+		{
+			name:          "InvalidChild",
+			s:             `(E >)`,
+			expectedError: `3: unexpected ">"`,
+		},
+		{
+			name:          "ExtraTokens",
+			s:             `E E`,
+			expectedError: `2: unexpected identifier E`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := CompilePattern(tc.s)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPattern, p)
+			} else {
+				assert.Nil(t, p)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestMustCompilePattern(t *testing.T) {
+	assert.NotPanics(t, func() {
+		MustCompilePattern(`(E $x "+" $y)`)
+	})
+
+	assert.PanicsWithValue(t, `ast: CompilePattern("(E"): 2: unexpected end of input`, func() {
+		MustCompilePattern(`(E`)
+	})
+}
+
+func TestPattern_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		p              Pattern
+		expectedString string
+	}{
+		{
+			name:           "Any",
+			p:              Any(),
+			expectedString: `_`,
+		},
+		{
+			name:           "Symbol",
+			p:              Symbol("E"),
+			expectedString: `E`,
+		},
+		{
+			name:           "Node",
+			p:              Node("", Capture("x", Symbol("E")), Lexeme("+", "+"), Capture("y", Any())),
+			expectedString: `(_ $x:E "+"='+' $y)`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.p.String())
+
+			// A pattern string can be compiled back into the same pattern.
+			p, err := CompilePattern(tc.expectedString)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.p, p)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	root := getTestAST()
+
+	// c + 0
+	sum := root.Children[2].(*parser.InternalNode).Children[2].(*parser.InternalNode).Children[1].(*parser.InternalNode)
+
+	// Repeated operands at different positions in the source.
+	twice := parse(t, "c + c")
+	twiceSum := parse(t, "(a + b) * (a + b)")
+
+	tests := []struct {
+		name             string
+		p                string
+		n                parser.Node
+		expectedOK       bool
+		expectedCaptures Captures
+	}{
+		{
+			name:             "Root",
+			p:                `(E $lhs "+" $rhs)`,
+			n:                root,
+			expectedOK:       true,
+			expectedCaptures: Captures{"lhs": root.Children[0], "rhs": root.Children[2]},
+		},
+		{
+			name:             "NestedWithLexeme",
+			p:                `(E $x "+" (E "id"='0'))`,
+			n:                sum,
+			expectedOK:       true,
+			expectedCaptures: Captures{"x": sum.Children[0]},
+		},
+		{
+			name:       "LexemeMismatch",
+			p:          `(E $x "+" (E "id"='1'))`,
+			n:          sum,
+			expectedOK: false,
+		},
+		{
+			name:       "ChildrenCountMismatch",
+			p:          `(E $x "+")`,
+			n:          sum,
+			expectedOK: false,
+		},
+		{
+			name:       "NonTerminalMismatch",
+			p:          `(T $x "+" $y)`,
+			n:          sum,
+			expectedOK: false,
+		},
+		{
+			name:       "LeafMismatch",
+			p:          `(_ _ "*" _)`,
+			n:          sum,
+			expectedOK: false,
+		},
+		{
+			name:       "InternalNodeForLeaf",
+			p:          `(_ "id")`,
+			n:          sum.Children[1],
+			expectedOK: false,
+		},
+		{
+			name:       "RepeatedCaptureMismatch",
+			p:          `(E $x "+" $x)`,
+			n:          sum,
+			expectedOK: false,
+		},
+		{
+			name:             "RepeatedCaptureMatch",
+			p:                `(E $x "+" $x)`,
+			n:                twice,
+			expectedOK:       true,
+			expectedCaptures: Captures{"x": twice.Children[0]},
+		},
+		{
+			name:             "RepeatedCaptureMatch_Subtree",
+			p:                `(E $x "*" $x)`,
+			n:                twiceSum,
+			expectedOK:       true,
+			expectedCaptures: Captures{"x": twiceSum.Children[0]},
+		},
+		{
+			name:       "RepeatedCaptureMismatch_Subtree",
+			p:          `(E $x "*" $x)`,
+			n:          parse(t, "(a + b) * (b + a)"),
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			caps, ok := Match(MustCompilePattern(tc.p), tc.n)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			if tc.expectedOK {
+				assert.Len(t, caps, len(tc.expectedCaptures))
+				for name, n := range tc.expectedCaptures {
+					assert.True(t, n.Equal(caps[name]), "capture %s: expected %s, got %s", name, n, caps[name])
+				}
+			} else {
+				assert.Nil(t, caps)
+			}
+		})
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	root := getTestAST()
+
+	results := FindAll(MustCompilePattern(`(E $lhs $op $rhs)`), root)
+
+	ops := []string{}
+	for _, r := range results {
+		assert.Same(t, r.Cursor.Node().(*parser.InternalNode).Children[1], r.Captures["op"])
+		ops = append(ops, r.Captures["op"].Symbol().String())
+	}
+
+	assert.Equal(t, []string{`"+"`, `"*"`, `E`, `"+"`}, ops)
+}
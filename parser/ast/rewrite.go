@@ -0,0 +1,77 @@
+package ast
+
+import (
+	"github.com/moorara/algo/parser"
+)
+
+// RewriteFunc is a function that creates a replacement for a node matched by a pattern.
+// It receives the matched node and the nodes captured by the pattern.
+// It may return an error if the replacement cannot be created.
+type RewriteFunc func(parser.Node, Captures) (parser.Node, error)
+
+// Rule is a rewrite rule, which replaces the subtrees matching a pattern.
+type Rule struct {
+	Pattern Pattern
+	Rewrite RewriteFunc
+}
+
+// String returns a string representation of a rewrite rule.
+func (r *Rule) String() string {
+	return r.Pattern.String()
+}
+
+// Rewrite applies a list of rewrite rules to an AST and returns the root of the rewritten AST.
+//
+// The AST is rewritten bottom-up in a single pass: the children of a node are rewritten before the node itself.
+// For each node, the rules are tried in order and the first rule whose pattern matches the node replaces it.
+// The replacement is not rewritten again; call Rewrite repeatedly to rewrite an AST until it no longer changes.
+//
+// The original AST is not modified. Instead, the ancestors of the rewritten nodes are copied with their annotations,
+// and the new AST shares all unchanged subtrees with the original AST.
+// If no rule matches any node, the original root is returned.
+//
+// An error is returned if any of the rewrite functions returns an error.
+func Rewrite(root parser.Node, rules ...*Rule) (parser.Node, error) {
+	return rewrite(root, rules)
+}
+
+func rewrite(n parser.Node, rules []*Rule) (parser.Node, error) {
+	if in, ok := n.(*parser.InternalNode); ok {
+		var children []parser.Node
+
+		for i, child := range in.Children {
+			m, err := rewrite(child, rules)
+			if err != nil {
+				return nil, err
+			}
+
+			if m != child && children == nil {
+				children = make([]parser.Node, len(in.Children))
+				copy(children, in.Children)
+			}
+
+			if children != nil {
+				children[i] = m
+			}
+		}
+
+		if children != nil {
+			dup := &parser.InternalNode{
+				NonTerminal: in.NonTerminal,
+				Production:  in.Production,
+				Children:    children,
+			}
+
+			dup.Annotate(in.Annotation())
+			n = dup
+		}
+	}
+
+	for _, r := range rules {
+		if caps, ok := Match(r.Pattern, n); ok {
+			return r.Rewrite(n, caps)
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,78 @@
+package ast
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/parser"
+)
+
+func TestRule_String(t *testing.T) {
+	r := &Rule{Pattern: MustCompilePattern(`(E $x "+" (E "id"='0'))`)}
+	assert.Equal(t, `(E $x "+" (E "id"='0'))`, r.String())
+}
+
+func TestRewrite(t *testing.T) {
+	// x + 0 → x
+	addZero := &Rule{
+		Pattern: MustCompilePattern(`(E $x "+" (E "id"='0'))`),
+		Rewrite: func(_ parser.Node, caps Captures) (parser.Node, error) {
+			return caps["x"], nil
+		},
+	}
+
+	// ( x ) → x, if x is an identifier.
+	parens := &Rule{
+		Pattern: MustCompilePattern(`(E "(" $x:(E "id") ")")`),
+		Rewrite: func(_ parser.Node, caps Captures) (parser.Node, error) {
+			return caps["x"], nil
+		},
+	}
+
+	failing := &Rule{
+		Pattern: MustCompilePattern(`(E "id"='b')`),
+		Rewrite: func(parser.Node, Captures) (parser.Node, error) {
+			return nil, errors.New("cannot rewrite b")
+		},
+	}
+
+	t.Run("NoMatch", func(t *testing.T) {
+		root := getTestAST()
+
+		n, err := Rewrite(root, parens)
+		assert.NoError(t, err)
+		assert.Same(t, root, n)
+	})
+
+	t.Run("BottomUp", func(t *testing.T) {
+		root := getTestAST()
+		root.Annotate("root")
+
+		// a + b * (c + 0) → a + b * (c) → a + b * c
+		n, err := Rewrite(root, addZero, parens)
+		assert.NoError(t, err)
+
+		in := n.(*parser.InternalNode)
+		assert.NotSame(t, root, in)
+		assert.Equal(t, "root", in.Annotation())
+
+		// Unchanged subtrees are shared.
+		assert.Same(t, root.Children[0], in.Children[0])
+
+		// b * c
+		mul := in.Children[2].(*parser.InternalNode)
+		assert.Len(t, mul.Children, 3)
+		assert.Equal(t, "c", mul.Children[2].(*parser.InternalNode).Children[0].(*parser.LeafNode).Lexeme)
+
+		// The original AST is not modified.
+		assert.True(t, getTestAST().Equal(root))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		n, err := Rewrite(getTestAST(), addZero, failing)
+		assert.Nil(t, n)
+		assert.EqualError(t, err, "cannot rewrite b")
+	})
+}
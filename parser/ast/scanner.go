@@ -0,0 +1,191 @@
+package ast
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind is the type of a token in a selector or a pattern.
+type tokenKind int
+
+const (
+	tokenEOF      tokenKind = iota // end of input
+	tokenIdent                     // non-terminal name, _, or *
+	tokenTerminal                  // double-quoted terminal
+	tokenLexeme                    // single-quoted lexeme
+	tokenCapture                   // $name
+	tokenLParen                    // (
+	tokenRParen                    // )
+	tokenGreater                   // >
+	tokenColon                     // :
+	tokenEqual                     // =
+)
+
+// punctuations maps the single-rune tokens to their kinds.
+var punctuations = map[rune]tokenKind{
+	'(': tokenLParen,
+	')': tokenRParen,
+	'>': tokenGreater,
+	':': tokenColon,
+	'=': tokenEqual,
+}
+
+// token is a lexical unit of a selector or a pattern.
+type token struct {
+	kind   tokenKind
+	value  string
+	offset int
+}
+
+// String returns a string representation of the token for error messages.
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenIdent:
+		return fmt.Sprintf("identifier %s", t.value)
+	case tokenTerminal:
+		return fmt.Sprintf("terminal %q", t.value)
+	case tokenLexeme:
+		return fmt.Sprintf("lexeme '%s'", t.value)
+	case tokenCapture:
+		return fmt.Sprintf("capture $%s", t.value)
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+// SyntaxError represents an error encountered when compiling a selector or a pattern.
+type SyntaxError struct {
+	Description string
+	Offset      int
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Offset, e.Description)
+}
+
+// isDelimiter determines whether or not a rune ends an identifier.
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`()>:="'$`, r)
+}
+
+// scan breaks a selector or a pattern into tokens.
+func scan(src string) ([]token, error) {
+	tokens := []token{}
+
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+		case punctuations[r] != tokenEOF:
+			tokens = append(tokens, token{kind: punctuations[r], value: string(r), offset: i})
+			i += size
+
+		case r == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+
+			if j >= len(src) {
+				return nil, &SyntaxError{Description: "unterminated terminal", Offset: i}
+			}
+
+			value, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, &SyntaxError{Description: fmt.Sprintf("invalid terminal %s", src[i:j+1]), Offset: i}
+			}
+
+			tokens = append(tokens, token{kind: tokenTerminal, value: value, offset: i})
+			i = j + 1
+
+		case r == '\'':
+			var b strings.Builder
+			j := i + 1
+			for j < len(src) && src[j] != '\'' {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				b.WriteByte(src[j])
+				j++
+			}
+
+			if j >= len(src) {
+				return nil, &SyntaxError{Description: "unterminated lexeme", Offset: i}
+			}
+
+			tokens = append(tokens, token{kind: tokenLexeme, value: b.String(), offset: i})
+			i = j + 1
+
+		default:
+			start := i
+			if r == '$' {
+				i += size
+			}
+
+			j := i
+			for j < len(src) {
+				r, size := utf8.DecodeRuneInString(src[j:])
+				if isDelimiter(r) {
+					break
+				}
+				j += size
+			}
+
+			if r == '$' {
+				if j == i {
+					return nil, &SyntaxError{Description: "missing capture name after $", Offset: start}
+				}
+				tokens = append(tokens, token{kind: tokenCapture, value: src[i:j], offset: start})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, value: src[i:j], offset: start})
+			}
+
+			i = j
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, offset: len(src)})
+
+	return tokens, nil
+}
+
+// tokenStream provides sequential access to the tokens of a selector or a pattern.
+type tokenStream struct {
+	tokens []token
+	i      int
+}
+
+// peek returns the next token without consuming it.
+func (s *tokenStream) peek() token {
+	return s.tokens[s.i]
+}
+
+// next consumes the next token and returns it.
+func (s *tokenStream) next() token {
+	t := s.tokens[s.i]
+	if t.kind != tokenEOF {
+		s.i++
+	}
+
+	return t
+}
+
+// unexpected returns an error for an unexpected token.
+func (s *tokenStream) unexpected(t token) error {
+	return &SyntaxError{
+		Description: fmt.Sprintf("unexpected %s", t),
+		Offset:      t.offset,
+	}
+}
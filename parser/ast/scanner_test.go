@@ -0,0 +1,101 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntaxError(t *testing.T) {
+	e := &SyntaxError{Description: "unexpected end of input", Offset: 4}
+	assert.EqualError(t, e, "4: unexpected end of input")
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		expectedTokens []token
+		expectedError  string
+	}{
+		{
+			name: "Selector",
+			src:  `stmt > expr′ "id"='x\'y'`,
+			expectedTokens: []token{
+				{kind: tokenIdent, value: "stmt", offset: 0},
+				{kind: tokenGreater, value: ">", offset: 5},
+				{kind: tokenIdent, value: "expr′", offset: 7},
+				{kind: tokenTerminal, value: "id", offset: 15},
+				{kind: tokenEqual, value: "=", offset: 19},
+				{kind: tokenLexeme, value: "x'y", offset: 20},
+				{kind: tokenEOF, offset: 26},
+			},
+		},
+		{
+			name: "Pattern",
+			src:  `(_ $x:E "\"")`,
+			expectedTokens: []token{
+				{kind: tokenLParen, value: "(", offset: 0},
+				{kind: tokenIdent, value: "_", offset: 1},
+				{kind: tokenCapture, value: "x", offset: 3},
+				{kind: tokenColon, value: ":", offset: 5},
+				{kind: tokenIdent, value: "E", offset: 6},
+				{kind: tokenTerminal, value: `"`, offset: 8},
+				{kind: tokenRParen, value: ")", offset: 12},
+				{kind: tokenEOF, offset: 13},
+			},
+		},
+		{
+			name:          "UnterminatedTerminal",
+			src:           `E "id`,
+			expectedError: `2: unterminated terminal`,
+		},
+		{
+			name:          "InvalidTerminal",
+			src:           `"\q"`,
+			expectedError: `0: invalid terminal "\q"`,
+		},
+		{
+			name:          "UnterminatedLexeme",
+			src:           `"id"='x`,
+			expectedError: `5: unterminated lexeme`,
+		},
+		{
+			name:          "MissingCaptureName",
+			src:           `(E $ "+")`,
+			expectedError: `3: missing capture name after $`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens, err := scan(tc.src)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedTokens, tokens)
+			} else {
+				assert.Nil(t, tokens)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestToken_String(t *testing.T) {
+	tests := []struct {
+		t              token
+		expectedString string
+	}{
+		{token{kind: tokenEOF}, `end of input`},
+		{token{kind: tokenIdent, value: "E"}, `identifier E`},
+		{token{kind: tokenTerminal, value: "id"}, `terminal "id"`},
+		{token{kind: tokenLexeme, value: "x"}, `lexeme 'x'`},
+		{token{kind: tokenCapture, value: "x"}, `capture $x`},
+		{token{kind: tokenGreater, value: ">"}, `">"`},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expectedString, tc.t.String())
+	}
+}
@@ -0,0 +1,201 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/parser"
+)
+
+// step is a single step of a selector.
+// It matches a node by its grammar symbol and, for leaf nodes, optionally by its lexeme.
+type step struct {
+	symbol grammar.Symbol // nil matches any symbol.
+	lexeme *string        // nil matches any lexeme.
+	child  bool           // The node must be a child (rather than a descendant) of the node matched by the previous step.
+}
+
+// match determines whether or not a node matches the step.
+func (s *step) match(n parser.Node) bool {
+	if s.symbol != nil && !s.symbol.Equal(n.Symbol()) {
+		return false
+	}
+
+	if s.lexeme != nil {
+		leaf, ok := n.(*parser.LeafNode)
+		return ok && leaf.Lexeme == *s.lexeme
+	}
+
+	return true
+}
+
+// String returns a string representation of a step.
+func (s *step) String() string {
+	var b bytes.Buffer
+
+	if s.symbol == nil {
+		b.WriteRune('*')
+	} else {
+		b.WriteString(s.symbol.String())
+	}
+
+	if s.lexeme != nil {
+		fmt.Fprintf(&b, "='%s'", *s.lexeme)
+	}
+
+	return b.String()
+}
+
+// Selector is a query for finding nodes in an AST by the grammar symbols on their paths from the root.
+//
+// A selector is a sequence of steps, similar to CSS selectors, in the following syntax:
+//
+//   - A non-terminal name (e.g., expr) matches internal nodes for that non-terminal.
+//   - A double-quoted terminal (e.g., "id") matches leaf nodes for that terminal.
+//     It can be followed by a single-quoted lexeme (e.g., "id"='x') to match the lexeme as well.
+//   - An asterisk (*) matches any node.
+//   - Two steps separated by whitespace match a node that is a descendant of a node matching the first step.
+//   - Two steps separated by > match a node that is a child of a node matching the first step.
+//
+// For example, `stmt expr` selects all expr nodes under stmt nodes,
+// and `call > "id"` selects all identifiers that are direct children of call nodes.
+type Selector struct {
+	steps []*step
+}
+
+// CompileSelector parses a selector string into a selector.
+func CompileSelector(s string) (*Selector, error) {
+	tokens, err := scan(s)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := &tokenStream{tokens: tokens}
+	sel := &Selector{}
+
+	for child := false; ; {
+		t := ts.next()
+
+		st := &step{child: child}
+
+		switch t.kind {
+		case tokenIdent:
+			if t.value != "*" {
+				st.symbol = grammar.NonTerminal(t.value)
+			}
+
+		case tokenTerminal:
+			st.symbol = grammar.Terminal(t.value)
+
+			if ts.peek().kind == tokenEqual {
+				ts.next()
+
+				t = ts.next()
+				if t.kind != tokenLexeme {
+					return nil, ts.unexpected(t)
+				}
+
+				st.lexeme = &t.value
+			}
+
+		default:
+			return nil, ts.unexpected(t)
+		}
+
+		sel.steps = append(sel.steps, st)
+
+		switch ts.peek().kind {
+		case tokenEOF:
+			return sel, nil
+		case tokenGreater:
+			ts.next()
+			child = true
+		default:
+			child = false
+		}
+	}
+}
+
+// MustCompileSelector is like CompileSelector but panics if the selector cannot be parsed.
+func MustCompileSelector(s string) *Selector {
+	sel, err := CompileSelector(s)
+	if err != nil {
+		panic(fmt.Sprintf("ast: CompileSelector(%q): %s", s, err))
+	}
+
+	return sel
+}
+
+// String returns a string representation of a selector.
+func (s *Selector) String() string {
+	var b bytes.Buffer
+
+	for i, st := range s.steps {
+		if i > 0 {
+			if st.child {
+				b.WriteString(" > ")
+			} else {
+				b.WriteRune(' ')
+			}
+		}
+
+		b.WriteString(st.String())
+	}
+
+	return b.String()
+}
+
+// Matches determines whether or not the node a cursor points to matches the selector.
+func (s *Selector) Matches(c *Cursor) bool {
+	return s.matches(len(s.steps)-1, c)
+}
+
+// matches determines whether or not the node a cursor points to matches the first i+1 steps of the selector.
+func (s *Selector) matches(i int, c *Cursor) bool {
+	st := s.steps[i]
+	if !st.match(c.node) {
+		return false
+	}
+
+	if i == 0 {
+		return true
+	}
+
+	if st.child {
+		return c.parent != nil && s.matches(i-1, c.parent)
+	}
+
+	for p := c.parent; p != nil; p = p.parent {
+		if s.matches(i-1, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Select returns cursors for all nodes in an AST that match the selector in pre-order.
+func (s *Selector) Select(root parser.Node) []*Cursor {
+	matches := []*Cursor{}
+
+	NewCursor(root).Walk(func(c *Cursor) bool {
+		if s.Matches(c) {
+			matches = append(matches, c)
+		}
+		return true
+	})
+
+	return matches
+}
+
+// Select returns cursors for all nodes in an AST that match a selector string in pre-order.
+// An error is returned if the selector cannot be parsed.
+func Select(root parser.Node, selector string) ([]*Cursor, error) {
+	sel, err := CompileSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return sel.Select(root), nil
+}
@@ -0,0 +1,160 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/parser"
+)
+
+func TestCompileSelector(t *testing.T) {
+	tests := []struct {
+		name           string
+		s              string
+		expectedString string
+		expectedError  string
+	}{
+		{
+			name:           "Descendant",
+			s:              `E   "id"`,
+			expectedString: `E "id"`,
+		},
+		{
+			name:           "Child",
+			s:              `E>*>"id"='0'`,
+			expectedString: `E > * > "id"='0'`,
+		},
+		{
+			name:          "Empty",
+			s:             ``,
+			expectedError: `0: unexpected end of input`,
+		},
+		{
+			name:          "DanglingCombinator",
+			s:             `E >`,
+			expectedError: `3: unexpected end of input`,
+		},
+		{
+			name:          "MissingLexeme",
+			s:             `"id"= E`,
+			expectedError: `6: unexpected identifier E`,
+		},
+		{
+			name:          "InvalidToken",
+			s:             `E (`,
+			expectedError: `2: unexpected "("`,
+		},
+		{
+			name:          "ScanError",
+			s:             `E "id`,
+			expectedError: `2: unterminated terminal`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sel, err := CompileSelector(tc.s)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedString, sel.String())
+			} else {
+				assert.Nil(t, sel)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestMustCompileSelector(t *testing.T) {
+	assert.NotPanics(t, func() {
+		MustCompileSelector(`E > "id"`)
+	})
+
+	assert.PanicsWithValue(t, `ast: CompileSelector("E >"): 3: unexpected end of input`, func() {
+		MustCompileSelector(`E >`)
+	})
+}
+
+func TestSelector_Select(t *testing.T) {
+	root := getTestAST()
+
+	tests := []struct {
+		name            string
+		s               string
+		expectedLexemes []string
+		expectedPaths   []string
+	}{
+		{
+			name:            "AllIdentifiers",
+			s:               `"id"`,
+			expectedLexemes: []string{"a", "b", "c", "0"},
+		},
+		{
+			name:            "Child",
+			s:               `E > "(" `,
+			expectedLexemes: []string{"("},
+		},
+		{
+			name:            "Descendant",
+			s:               `E E E "id"`,
+			expectedLexemes: []string{"b", "c", "0"},
+		},
+		{
+			name:            "DescendantOfChild",
+			s:               `"*" "id"`,
+			expectedLexemes: []string{},
+		},
+		{
+			name:            "Lexeme",
+			s:               `E "id"='0'`,
+			expectedLexemes: []string{"0"},
+		},
+		{
+			name:          "Wildcard",
+			s:             `E > E > * > * > E > "id"`,
+			expectedPaths: []string{`E > E > E > E > E > "id"`, `E > E > E > E > E > "id"`},
+		},
+		{
+			name:          "Nested",
+			s:             `E > * E > "id"`,
+			expectedPaths: []string{`E > E > E > "id"`, `E > E > E > E > E > "id"`, `E > E > E > E > E > "id"`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matches := MustCompileSelector(tc.s).Select(root)
+
+			if tc.expectedLexemes != nil {
+				lexemes := []string{}
+				for _, c := range matches {
+					lexemes = append(lexemes, c.Node().(*parser.LeafNode).Lexeme)
+				}
+				assert.Equal(t, tc.expectedLexemes, lexemes)
+			}
+
+			if tc.expectedPaths != nil {
+				paths := []string{}
+				for _, c := range matches {
+					paths = append(paths, c.String())
+				}
+				assert.Equal(t, tc.expectedPaths, paths)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	root := getTestAST()
+
+	matches, err := Select(root, `E > E > "+"`)
+	assert.NoError(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, 11, matches[0].Node().Pos().Offset)
+
+	matches, err = Select(root, `>`)
+	assert.Nil(t, matches)
+	assert.EqualError(t, err, `0: unexpected ">"`)
+}
@@ -0,0 +1,473 @@
+// Package attribute provides an evaluator for attribute grammars over abstract syntax trees (ASTs).
+//
+// An attribute grammar associates attributes with the grammar symbols
+// and semantic rules with the production rules of a context-free grammar.
+// Each semantic rule defines the value of an attribute in terms of other attributes
+// of the symbols in the same production rule.
+//
+// Attributes are of two kinds:
+//
+//   - A synthesized attribute of a node is defined by the production rule at the node itself,
+//     in terms of the attributes of its children and its own attributes.
+//   - An inherited attribute of a node is defined by the production rule at its parent,
+//     in terms of the attributes of its parent and its siblings.
+//
+// Unlike the bottom-up evaluation of LR parsers, which is limited to synthesized attributes,
+// the evaluator computes the dependencies between all attribute instances of an AST,
+// checks them for cycles, and evaluates the attributes in a topological order.
+// It works with the ASTs built by any parser in this module (e.g., LR or predictive parsers).
+//
+// For more details on attribute grammars and syntax-directed definitions,
+// refer to "Compilers: Principles, Techniques, and Tools (2nd Edition)".
+package attribute
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	errs "github.com/moorara/algo/errors"
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/graph"
+	"github.com/moorara/algo/parser"
+	"github.com/moorara/algo/sort"
+	"golang.org/x/exp/constraints"
+)
+
+// Lexeme is the name of the intrinsic attribute of terminal symbols.
+// The value of this attribute for a leaf node is its lexeme as a string.
+const Lexeme = "lexeme"
+
+// Kind determines whether an attribute is synthesized or inherited.
+type Kind int
+
+const (
+	// Synthesized attributes are defined by the production rule at the node itself.
+	Synthesized Kind = iota
+	// Inherited attributes are defined by the production rule at the parent of the node.
+	Inherited
+)
+
+// String returns a string representation of an attribute kind.
+func (k Kind) String() string {
+	switch k {
+	case Synthesized:
+		return "synthesized"
+	case Inherited:
+		return "inherited"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Ref refers to an attribute of a symbol in a production rule.
+//
+// Index 0 refers to the head of the production rule,
+// and index i (1 ≤ i ≤ n) refers to the i-th symbol in the body of the production rule.
+// For example, in the production rule E → E + T, Ref{0, "val"} refers to the val attribute of the head E,
+// and Ref{3, "val"} refers to the val attribute of T.
+type Ref struct {
+	Index int
+	Name  string
+}
+
+// String returns a string representation of an attribute reference.
+func (r Ref) String() string {
+	return fmt.Sprintf("$%d.%s", r.Index, r.Name)
+}
+
+// ComputeFunc computes the value of an attribute from the values of its arguments.
+// The arguments are passed in the same order as they are listed in the semantic rule.
+// The function may return an error if the values are invalid, indicating a semantic issue.
+type ComputeFunc func(args []any) (any, error)
+
+// Rule is a semantic rule that defines an attribute of a symbol in a production rule.
+type Rule struct {
+	Production *grammar.Production
+	Target     Ref
+	Args       []Ref
+	Compute    ComputeFunc
+}
+
+// String returns a string representation of a semantic rule.
+func (r *Rule) String() string {
+	args := make([]string, len(r.Args))
+	for i, a := range r.Args {
+		args[i] = a.String()
+	}
+
+	return fmt.Sprintf("%s: %s = f(%s)", r.Production, r.Target, strings.Join(args, ", "))
+}
+
+// Attributes holds the values of the attributes of a node by their names.
+// The evaluator stores the attributes of each node as its annotation.
+type Attributes map[string]any
+
+// Get returns the value of an attribute of a node evaluated by Grammar.Evaluate.
+func Get(n parser.Node, name string) (any, bool) {
+	attrs, ok := n.Annotation().(Attributes)
+	if !ok {
+		return nil, false
+	}
+
+	val, ok := attrs[name]
+	return val, ok
+}
+
+// Grammar is an attribute grammar.
+// It consists of the declarations of attributes for non-terminal symbols
+// and the semantic rules for production rules.
+type Grammar struct {
+	attributes map[grammar.NonTerminal]map[string]Kind
+	rules      map[string][]*Rule // Semantic rules by the string representation of their production rules.
+}
+
+// New creates a new attribute grammar without any attributes or semantic rules.
+func New() *Grammar {
+	return &Grammar{
+		attributes: map[grammar.NonTerminal]map[string]Kind{},
+		rules:      map[string][]*Rule{},
+	}
+}
+
+// String returns a string representation of an attribute grammar.
+func (g *Grammar) String() string {
+	var b bytes.Buffer
+
+	b.WriteString("Attributes:\n")
+	for _, A := range sortedKeys(g.attributes) {
+		for _, name := range sortedKeys(g.attributes[A]) {
+			fmt.Fprintf(&b, "  %s.%s: %s\n", A, name, g.attributes[A][name])
+		}
+	}
+
+	b.WriteString("Rules:\n")
+	for _, key := range sortedKeys(g.rules) {
+		for _, r := range g.rules[key] {
+			fmt.Fprintf(&b, "  %s\n", r)
+		}
+	}
+
+	return b.String()
+}
+
+// Declare declares an attribute for a non-terminal symbol.
+// An error is returned if the attribute is reserved or already declared with a different kind.
+func (g *Grammar) Declare(A grammar.NonTerminal, name string, kind Kind) error {
+	if name == Lexeme {
+		return fmt.Errorf("attribute %s.%s is reserved for terminals", A, name)
+	}
+
+	if g.attributes[A] == nil {
+		g.attributes[A] = map[string]Kind{}
+	}
+
+	if k, ok := g.attributes[A][name]; ok && k != kind {
+		return fmt.Errorf("attribute %s.%s is already declared as %s", A, name, k)
+	}
+
+	g.attributes[A][name] = kind
+
+	return nil
+}
+
+// attribute returns the kind of an attribute of a symbol.
+// The Lexeme attribute is implicitly declared as synthesized for all terminals.
+func (g *Grammar) attribute(X grammar.Symbol, name string) (Kind, bool) {
+	switch X := X.(type) {
+	case grammar.Terminal:
+		return Synthesized, name == Lexeme
+	case grammar.NonTerminal:
+		k, ok := g.attributes[X][name]
+		return k, ok
+	default:
+		return 0, false
+	}
+}
+
+// symbol returns the symbol a reference refers to in a production rule.
+func symbol(p *grammar.Production, r Ref) (grammar.Symbol, bool) {
+	switch {
+	case r.Index == 0:
+		return p.Head, true
+	case r.Index > 0 && r.Index <= len(p.Body):
+		return p.Body[r.Index-1], true
+	default:
+		return nil, false
+	}
+}
+
+// AddRule adds a semantic rule to the attribute grammar.
+//
+// The target of a semantic rule must be either a synthesized attribute of the head of the production rule,
+// or an inherited attribute of a non-terminal in the body of the production rule.
+// The arguments can be any attributes declared for the symbols of the production rule.
+//
+// An error is returned if the rule is invalid or if another rule already defines the same target.
+func (g *Grammar) AddRule(r *Rule) error {
+	if r.Production == nil || r.Compute == nil {
+		return fmt.Errorf("rule %s: production rule and compute function are required", r.Target)
+	}
+
+	var err error
+
+	if X, ok := symbol(r.Production, r.Target); !ok {
+		err = errs.Append(err, fmt.Errorf("rule %s: target %s is out of range", r.Production, r.Target))
+	} else if k, ok := g.attribute(X, r.Target.Name); !ok {
+		err = errs.Append(err, fmt.Errorf("rule %s: target %s: attribute %s.%s is not declared", r.Production, r.Target, X, r.Target.Name))
+	} else if _, ok := X.(grammar.Terminal); ok {
+		err = errs.Append(err, fmt.Errorf("rule %s: target %s: attributes of terminals cannot be defined", r.Production, r.Target))
+	} else if r.Target.Index == 0 && k != Synthesized {
+		err = errs.Append(err, fmt.Errorf("rule %s: target %s: attribute of the head must be synthesized", r.Production, r.Target))
+	} else if r.Target.Index > 0 && k != Inherited {
+		err = errs.Append(err, fmt.Errorf("rule %s: target %s: attribute of a body symbol must be inherited", r.Production, r.Target))
+	}
+
+	for _, a := range r.Args {
+		if X, ok := symbol(r.Production, a); !ok {
+			err = errs.Append(err, fmt.Errorf("rule %s: argument %s is out of range", r.Production, a))
+		} else if _, ok := g.attribute(X, a.Name); !ok {
+			err = errs.Append(err, fmt.Errorf("rule %s: argument %s: attribute %s.%s is not declared", r.Production, a, X, a.Name))
+		}
+	}
+
+	key := r.Production.String()
+	for _, s := range g.rules[key] {
+		if s.Target == r.Target {
+			err = errs.Append(err, fmt.Errorf("rule %s: target %s is already defined", r.Production, r.Target))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	g.rules[key] = append(g.rules[key], r)
+
+	return nil
+}
+
+// instance is an attribute instance of a node in an AST.
+type instance struct {
+	node parser.Node
+	name string
+}
+
+// String returns a string representation of an attribute instance.
+func (i instance) String() string {
+	if pos := i.node.Pos(); !pos.IsZero() {
+		return fmt.Sprintf("%s.%s (%s)", i.node.Symbol(), i.name, pos)
+	}
+
+	return fmt.Sprintf("%s.%s", i.node.Symbol(), i.name)
+}
+
+// definition is a semantic rule applied to a node in an AST to define an attribute instance.
+type definition struct {
+	rule *Rule
+	args []int // The vertices of the argument instances.
+}
+
+/*
+ * The evaluation of an attribute grammar over an AST is based on its dependency graph.
+ * The dependency graph has a vertex for each attribute instance of each node in the AST,
+ * and an edge from the vertex of an argument to the vertex of the attribute it is used to compute.
+ *
+ *   for each node n in the AST:
+ *     for each semantic rule b = f(c₁, c₂, ..., cₖ) of the production rule at n:
+ *       for each i = 1, 2, ..., k:
+ *         add an edge from the vertex of cᵢ to the vertex of b;
+ *
+ * If the dependency graph has a cycle, the attributes cannot be evaluated.
+ * Otherwise, any topological order of the dependency graph is a valid evaluation order.
+ */
+
+// Evaluate computes the values of all attribute instances in an AST.
+//
+// The values of the inherited attributes of the root are provided by the caller.
+// The values of the Lexeme attribute of leaf nodes are their lexemes.
+// Every other attribute instance must be defined by a semantic rule.
+//
+// The attributes of each node are stored as its annotation of type Attributes, replacing any previous annotation.
+// They can be retrieved using the Get function.
+//
+// An error is returned if an attribute instance is not defined by any semantic rule, if the dependencies between
+// the attribute instances are circular, or if any compute function returns an error.
+func (g *Grammar) Evaluate(root parser.Node, inherited Attributes) error {
+	vertices := map[instance]int{}
+	instances := []instance{}
+	values := []any{}
+	defined := []bool{}
+
+	addInstance := func(n parser.Node, name string) {
+		vertices[instance{n, name}] = len(instances)
+		instances = append(instances, instance{n, name})
+		values = append(values, nil)
+		defined = append(defined, false)
+	}
+
+	// Create a vertex for every attribute instance.
+	parser.Traverse(root, generic.VLR, func(n parser.Node) bool {
+		switch n := n.(type) {
+		case *parser.LeafNode:
+			addInstance(n, Lexeme)
+			values[len(values)-1], defined[len(defined)-1] = n.Lexeme, true
+		case *parser.InternalNode:
+			for _, name := range sortedKeys(g.attributes[n.NonTerminal]) {
+				addInstance(n, name)
+			}
+		}
+		return true
+	})
+
+	// The inherited attributes of the root are provided by the caller.
+	if A, ok := root.Symbol().(grammar.NonTerminal); ok {
+		for name, kind := range g.attributes[A] {
+			if val, ok := inherited[name]; ok && kind == Inherited {
+				v := vertices[instance{root, name}]
+				values[v], defined[v] = val, true
+			}
+		}
+	}
+
+	var err error
+	defs := make([]*definition, len(instances))
+	edges := [][2]int{}
+
+	// Apply the semantic rules to every internal node and add the dependencies.
+	parser.Traverse(root, generic.VLR, func(n parser.Node) bool {
+		in, ok := n.(*parser.InternalNode)
+		if !ok || in.Production == nil {
+			return true
+		}
+
+		resolve := func(r Ref) (int, bool) {
+			m := n
+			if r.Index > 0 {
+				if r.Index > len(in.Children) {
+					return -1, false
+				}
+				m = in.Children[r.Index-1]
+			}
+
+			v, ok := vertices[instance{m, r.Name}]
+			return v, ok
+		}
+
+		for _, r := range g.rules[in.Production.String()] {
+			t, ok := resolve(r.Target)
+			if !ok {
+				err = errs.Append(err, fmt.Errorf("%s: cannot apply rule for %s", n.Pos(), r.Target))
+				continue
+			}
+
+			d := &definition{rule: r}
+			for _, a := range r.Args {
+				v, ok := resolve(a)
+				if !ok {
+					err = errs.Append(err, fmt.Errorf("%s: cannot apply rule for %s: invalid argument %s", n.Pos(), r.Target, a))
+					continue
+				}
+
+				d.args = append(d.args, v)
+				edges = append(edges, [2]int{v, t})
+			}
+
+			defs[t], defined[t] = d, true
+		}
+
+		return true
+	})
+
+	for v, ok := range defined {
+		if !ok {
+			err = errs.Append(err, fmt.Errorf("no rule defines attribute %s", instances[v]))
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	G := graph.NewDirected(len(instances), edges...)
+
+	if cycle, ok := G.DirectedCycle().Cycle(); ok {
+		names := make([]string, len(cycle))
+		for i, v := range cycle {
+			names[i] = instances[v].String()
+		}
+
+		return fmt.Errorf("circular dependency between attributes: %s", strings.Join(names, " → "))
+	}
+
+	order, _ := G.Topological().Order()
+
+	for _, v := range order {
+		d := defs[v]
+		if d == nil {
+			continue
+		}
+
+		args := make([]any, len(d.args))
+		for i, a := range d.args {
+			args[i] = values[a]
+		}
+
+		val, err := d.rule.Compute(args)
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", instances[v].node.Pos(), d.rule.Target, err)
+		}
+
+		values[v] = val
+	}
+
+	// Store the attributes of every node as its annotation.
+	attrs := map[parser.Node]Attributes{}
+	for v, i := range instances {
+		if attrs[i.node] == nil {
+			attrs[i.node] = Attributes{}
+		}
+		attrs[i.node][i.name] = values[v]
+	}
+
+	parser.Traverse(root, generic.VLR, func(n parser.Node) bool {
+		if a, ok := attrs[n]; ok {
+			n.Annotate(a)
+		} else {
+			n.Annotate(Attributes{})
+		}
+		return true
+	})
+
+	return nil
+}
+
+// Parse parses the input of a parser into an AST and evaluates the attributes of the AST.
+// The values of the inherited attributes of the root are provided by the caller.
+//
+// An error is returned if the parser fails to build an AST, or if the evaluation fails.
+func (g *Grammar) Parse(p parser.Parser, inherited Attributes) (parser.Node, error) {
+	root, err := p.ParseAndBuildAST()
+	if err != nil {
+		return root, err
+	}
+
+	if err := g.Evaluate(root, inherited); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// sortedKeys returns the keys of a map in ascending order for deterministic iteration.
+func sortedKeys[K constraints.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Quick(keys, generic.NewCompareFunc[K]())
+
+	return keys
+}
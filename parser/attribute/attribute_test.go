@@ -0,0 +1,601 @@
+package attribute
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+	"github.com/moorara/algo/parser/predictive"
+)
+
+func leaf(a grammar.Terminal, lexeme string, offset int) *parser.LeafNode {
+	return &parser.LeafNode{
+		Terminal: a,
+		Lexeme:   lexeme,
+		Position: lexer.Position{
+			Filename: "test",
+			Offset:   offset,
+			Line:     1,
+			Column:   offset + 1,
+		},
+	}
+}
+
+func node(prod *grammar.Production, children ...parser.Node) *parser.InternalNode {
+	return &parser.InternalNode{
+		NonTerminal: prod.Head,
+		Production:  prod,
+		Children:    children,
+	}
+}
+
+func pass(args []any) (any, error) {
+	return args[0], nil
+}
+
+func add(args []any) (any, error) {
+	return args[0].(int) + args[1].(int), nil
+}
+
+func mul(args []any) (any, error) {
+	return args[0].(int) * args[1].(int), nil
+}
+
+func atoi(args []any) (any, error) {
+	return strconv.Atoi(args[0].(string))
+}
+
+// getTestAST returns the AST for the input string "2 + 3 * 4" using the following grammar:
+//
+//	E  → T E′
+//	E′ → + T E′ | ε
+//	T  → F T′
+//	T′ → * F T′ | ε
+//	F  → ( E ) | id
+func getTestAST() *parser.InternalNode {
+	return node(parsertest.Prods[0][0], // E → T E′
+		node(parsertest.Prods[0][3], // T → F T′
+			node(parsertest.Prods[0][7], leaf("id", "2", 0)), // F → id
+			node(parsertest.Prods[0][5]),                     // T′ → ε
+		),
+		node(parsertest.Prods[0][1], // E′ → + T E′
+			leaf("+", "+", 2),
+			node(parsertest.Prods[0][3], // T → F T′
+				node(parsertest.Prods[0][7], leaf("id", "3", 4)), // F → id
+				node(parsertest.Prods[0][4], // T′ → * F T′
+					leaf("*", "*", 6),
+					node(parsertest.Prods[0][7], leaf("id", "4", 8)), // F → id
+					node(parsertest.Prods[0][5]),                     // T′ → ε
+				),
+			),
+			node(parsertest.Prods[0][2]), // E′ → ε
+		),
+	)
+}
+
+// getTestGrammar returns the classic attribute grammar for evaluating expressions
+// with the inherited attributes for the non-terminals E′ and T′.
+func getTestGrammar() *Grammar {
+	g := New()
+
+	_ = g.Declare("E", "val", Synthesized)
+	_ = g.Declare("E′", "inh", Inherited)
+	_ = g.Declare("E′", "syn", Synthesized)
+	_ = g.Declare("T", "val", Synthesized)
+	_ = g.Declare("T′", "inh", Inherited)
+	_ = g.Declare("T′", "syn", Synthesized)
+	_ = g.Declare("F", "val", Synthesized)
+
+	P := parsertest.Prods[0]
+	rules := []*Rule{
+		{Production: P[0], Target: Ref{2, "inh"}, Args: []Ref{{1, "val"}}, Compute: pass},            // E → T E′
+		{Production: P[0], Target: Ref{0, "val"}, Args: []Ref{{2, "syn"}}, Compute: pass},            // E → T E′
+		{Production: P[1], Target: Ref{3, "inh"}, Args: []Ref{{0, "inh"}, {2, "val"}}, Compute: add}, // E′ → + T E′
+		{Production: P[1], Target: Ref{0, "syn"}, Args: []Ref{{3, "syn"}}, Compute: pass},            // E′ → + T E′
+		{Production: P[2], Target: Ref{0, "syn"}, Args: []Ref{{0, "inh"}}, Compute: pass},            // E′ → ε
+		{Production: P[3], Target: Ref{2, "inh"}, Args: []Ref{{1, "val"}}, Compute: pass},            // T → F T′
+		{Production: P[3], Target: Ref{0, "val"}, Args: []Ref{{2, "syn"}}, Compute: pass},            // T → F T′
+		{Production: P[4], Target: Ref{3, "inh"}, Args: []Ref{{0, "inh"}, {2, "val"}}, Compute: mul}, // T′ → * F T′
+		{Production: P[4], Target: Ref{0, "syn"}, Args: []Ref{{3, "syn"}}, Compute: pass},            // T′ → * F T′
+		{Production: P[5], Target: Ref{0, "syn"}, Args: []Ref{{0, "inh"}}, Compute: pass},            // T′ → ε
+		{Production: P[6], Target: Ref{0, "val"}, Args: []Ref{{2, "val"}}, Compute: pass},            // F → ( E )
+		{Production: P[7], Target: Ref{0, "val"}, Args: []Ref{{1, Lexeme}}, Compute: atoi},           // F → id
+	}
+
+	for _, r := range rules {
+		_ = g.AddRule(r)
+	}
+
+	return g
+}
+
+func TestKind_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		k              Kind
+		expectedString string
+	}{
+		{
+			name:           "Synthesized",
+			k:              Synthesized,
+			expectedString: "synthesized",
+		},
+		{
+			name:           "Inherited",
+			k:              Inherited,
+			expectedString: "inherited",
+		},
+		{
+			name:           "Invalid",
+			k:              Kind(9),
+			expectedString: "Kind(9)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.k.String())
+		})
+	}
+}
+
+func TestRef_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		r              Ref
+		expectedString string
+	}{
+		{
+			name:           "Head",
+			r:              Ref{0, "val"},
+			expectedString: "$0.val",
+		},
+		{
+			name:           "Body",
+			r:              Ref{2, "inh"},
+			expectedString: "$2.inh",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.r.String())
+		})
+	}
+}
+
+func TestRule_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		r              *Rule
+		expectedString string
+	}{
+		{
+			name: "NoArgs",
+			r: &Rule{
+				Production: parsertest.Prods[0][2],
+				Target:     Ref{0, "syn"},
+				Compute:    pass,
+			},
+			expectedString: `E′ → ε: $0.syn = f()`,
+		},
+		{
+			name: "WithArgs",
+			r: &Rule{
+				Production: parsertest.Prods[0][1],
+				Target:     Ref{3, "inh"},
+				Args:       []Ref{{0, "inh"}, {2, "val"}},
+				Compute:    add,
+			},
+			expectedString: `E′ → "+" T E′: $3.inh = f($0.inh, $2.val)`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.r.String())
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	annotated := leaf("id", "x", 0)
+	annotated.Annotate(Attributes{"val": 69})
+
+	tests := []struct {
+		name          string
+		n             parser.Node
+		attr          string
+		expectedOK    bool
+		expectedValue any
+	}{
+		{
+			name:       "NotAnnotated",
+			n:          leaf("id", "x", 0),
+			attr:       "val",
+			expectedOK: false,
+		},
+		{
+			name:       "NotFound",
+			n:          annotated,
+			attr:       "inh",
+			expectedOK: false,
+		},
+		{
+			name:          "OK",
+			n:             annotated,
+			attr:          "val",
+			expectedOK:    true,
+			expectedValue: 69,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			val, ok := Get(tc.n, tc.attr)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedValue, val)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	g := New()
+
+	assert.NotNil(t, g)
+	assert.NotNil(t, g.attributes)
+	assert.NotNil(t, g.rules)
+}
+
+func TestGrammar_String(t *testing.T) {
+	g := New()
+	_ = g.Declare("T′", "syn", Synthesized)
+	_ = g.Declare("T′", "inh", Inherited)
+	_ = g.Declare("F", "val", Synthesized)
+	_ = g.AddRule(&Rule{Production: parsertest.Prods[0][7], Target: Ref{0, "val"}, Args: []Ref{{1, Lexeme}}, Compute: atoi})
+	_ = g.AddRule(&Rule{Production: parsertest.Prods[0][5], Target: Ref{0, "syn"}, Args: []Ref{{0, "inh"}}, Compute: pass})
+
+	expectedString := `Attributes:
+  F.val: synthesized
+  T′.inh: inherited
+  T′.syn: synthesized
+Rules:
+  F → "id": $0.val = f($1.lexeme)
+  T′ → ε: $0.syn = f($0.inh)
+`
+
+	assert.Equal(t, expectedString, g.String())
+}
+
+func TestGrammar_Declare(t *testing.T) {
+	tests := []struct {
+		name          string
+		A             grammar.NonTerminal
+		attr          string
+		kind          Kind
+		expectedError string
+	}{
+		{
+			name:          "Reserved",
+			A:             "E",
+			attr:          Lexeme,
+			kind:          Synthesized,
+			expectedError: `attribute E.lexeme is reserved for terminals`,
+		},
+		{
+			name:          "Redeclared",
+			A:             "E′",
+			attr:          "inh",
+			kind:          Synthesized,
+			expectedError: `attribute E′.inh is already declared as inherited`,
+		},
+		{
+			name: "SameKind",
+			A:    "E′",
+			attr: "inh",
+			kind: Inherited,
+		},
+		{
+			name: "OK",
+			A:    "E",
+			attr: "type",
+			kind: Inherited,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := getTestGrammar()
+			err := g.Declare(tc.A, tc.attr, tc.kind)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				kind, ok := g.attribute(tc.A, tc.attr)
+				assert.True(t, ok)
+				assert.Equal(t, tc.kind, kind)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestGrammar_AddRule(t *testing.T) {
+	P := parsertest.Prods[0]
+
+	tests := []struct {
+		name          string
+		r             *Rule
+		expectedError string
+	}{
+		{
+			name:          "NoProduction",
+			r:             &Rule{Target: Ref{0, "val"}, Compute: pass},
+			expectedError: `rule $0.val: production rule and compute function are required`,
+		},
+		{
+			name:          "NoCompute",
+			r:             &Rule{Production: P[0], Target: Ref{0, "val"}},
+			expectedError: `rule $0.val: production rule and compute function are required`,
+		},
+		{
+			name:          "TargetOutOfRange",
+			r:             &Rule{Production: P[0], Target: Ref{3, "val"}, Compute: pass},
+			expectedError: "rule E → T E′: target $3.val is out of range\n",
+		},
+		{
+			name:          "TargetNotDeclared",
+			r:             &Rule{Production: P[0], Target: Ref{0, "type"}, Compute: pass},
+			expectedError: "rule E → T E′: target $0.type: attribute E.type is not declared\n",
+		},
+		{
+			name:          "TargetTerminal",
+			r:             &Rule{Production: P[7], Target: Ref{1, Lexeme}, Compute: pass},
+			expectedError: "rule F → \"id\": target $1.lexeme: attributes of terminals cannot be defined\n",
+		},
+		{
+			name:          "TargetHeadInherited",
+			r:             &Rule{Production: P[1], Target: Ref{0, "inh"}, Compute: pass},
+			expectedError: "rule E′ → \"+\" T E′: target $0.inh: attribute of the head must be synthesized\n",
+		},
+		{
+			name:          "TargetBodySynthesized",
+			r:             &Rule{Production: P[1], Target: Ref{2, "val"}, Compute: pass},
+			expectedError: "rule E′ → \"+\" T E′: target $2.val: attribute of a body symbol must be inherited\n",
+		},
+		{
+			name:          "InvalidArgs",
+			r:             &Rule{Production: P[6], Target: Ref{2, "type"}, Args: []Ref{{4, "val"}, {1, "val"}}, Compute: pass},
+			expectedError: "rule F → \"(\" E \")\": target $2.type: attribute E.type is not declared\nrule F → \"(\" E \")\": argument $4.val is out of range\nrule F → \"(\" E \")\": argument $1.val: attribute \"(\".val is not declared\n",
+		},
+		{
+			name:          "AlreadyDefined",
+			r:             &Rule{Production: P[7], Target: Ref{0, "val"}, Args: []Ref{{1, Lexeme}}, Compute: pass},
+			expectedError: "rule F → \"id\": target $0.val is already defined\n",
+		},
+		{
+			name: "OK",
+			r:    &Rule{Production: P[6], Target: Ref{2, "inh"}, Compute: pass},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := getTestGrammar()
+			_ = g.Declare("E", "inh", Inherited)
+			err := g.AddRule(tc.r)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestGrammar_Evaluate(t *testing.T) {
+	P := parsertest.Prods[0]
+
+	tests := []struct {
+		name          string
+		g             func() *Grammar
+		root          func() parser.Node
+		inherited     Attributes
+		expectedError string
+		expectedVal   any
+	}{
+		{
+			name: "Success",
+			g:    getTestGrammar,
+			root: func() parser.Node {
+				return getTestAST()
+			},
+			expectedVal: 14,
+		},
+		{
+			name: "RootInherited",
+			g: func() *Grammar {
+				g := getTestGrammar()
+				_ = g.Declare("T′", "val", Synthesized)
+				_ = g.AddRule(&Rule{Production: P[5], Target: Ref{0, "val"}, Args: []Ref{{0, "inh"}}, Compute: pass})
+				return g
+			},
+			root: func() parser.Node {
+				return node(P[5]) // T′ → ε
+			},
+			inherited:   Attributes{"inh": 7, "syn": 9},
+			expectedVal: 7,
+		},
+		{
+			name: "Undefined",
+			g:    getTestGrammar,
+			root: func() parser.Node {
+				return node(P[2]) // E′ → ε
+			},
+			expectedError: "no rule defines attribute E′.inh\n",
+		},
+		{
+			name: "Incomplete",
+			g:    getTestGrammar,
+			root: func() parser.Node {
+				return &parser.InternalNode{NonTerminal: "F"}
+			},
+			expectedError: "no rule defines attribute F.val\n",
+		},
+		{
+			name: "CircularDependency",
+			g: func() *Grammar {
+				g := New()
+				_ = g.Declare("E′", "inh", Inherited)
+				_ = g.Declare("E′", "syn", Synthesized)
+				_ = g.Declare("T", "val", Synthesized)
+				_ = g.Declare("T′", "inh", Inherited)
+				_ = g.Declare("T′", "syn", Synthesized)
+				_ = g.Declare("F", "val", Synthesized)
+				_ = g.AddRule(&Rule{Production: P[1], Target: Ref{3, "inh"}, Args: []Ref{{3, "syn"}}, Compute: pass})
+				_ = g.AddRule(&Rule{Production: P[1], Target: Ref{0, "syn"}, Args: []Ref{{2, "val"}}, Compute: pass})
+				_ = g.AddRule(&Rule{Production: P[2], Target: Ref{0, "syn"}, Args: []Ref{{0, "inh"}}, Compute: pass})
+				_ = g.AddRule(&Rule{Production: P[3], Target: Ref{2, "inh"}, Args: []Ref{{1, "val"}}, Compute: pass})
+				_ = g.AddRule(&Rule{Production: P[3], Target: Ref{0, "val"}, Args: []Ref{{2, "syn"}}, Compute: pass})
+				_ = g.AddRule(&Rule{Production: P[5], Target: Ref{0, "syn"}, Args: []Ref{{0, "inh"}}, Compute: pass})
+				_ = g.AddRule(&Rule{Production: P[7], Target: Ref{0, "val"}, Args: []Ref{{1, Lexeme}}, Compute: atoi})
+				return g
+			},
+			root: func() parser.Node {
+				return node(P[1], // E′ → + T E′
+					leaf("+", "+", 0),
+					node(P[3], // T → F T′
+						node(P[7], leaf("id", "1", 2)), // F → id
+						node(P[5]),                     // T′ → ε
+					),
+					node(P[2]), // E′ → ε
+				)
+			},
+			inherited:     Attributes{"inh": 0},
+			expectedError: `circular dependency between attributes: E′.syn → E′.inh → E′.syn`,
+		},
+		{
+			name: "ComputeError",
+			g:    getTestGrammar,
+			root: func() parser.Node {
+				return node(P[7], leaf("id", "x", 0)) // F → id
+			},
+			expectedError: `test:1:1: $0.val: strconv.Atoi: parsing "x": invalid syntax`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := tc.root()
+			err := tc.g().Evaluate(root, tc.inherited)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+
+				val, ok := Get(root, "val")
+				assert.True(t, ok)
+				assert.Equal(t, tc.expectedVal, val)
+
+				// Every node must be annotated with its attributes.
+				parser.Traverse(root, generic.VLR, func(n parser.Node) bool {
+					_, ok := n.Annotation().(Attributes)
+					assert.True(t, ok)
+					return true
+				})
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestGrammar_Evaluate_Attributes(t *testing.T) {
+	root := getTestAST()
+	err := getTestGrammar().Evaluate(root, nil)
+	assert.NoError(t, err)
+
+	// E′ → + T E′
+	rest := root.Children[1].(*parser.InternalNode)
+	assert.Equal(t, Attributes{"inh": 2, "syn": 14}, rest.Annotation())
+
+	// T → F T′
+	term := rest.Children[1].(*parser.InternalNode)
+	assert.Equal(t, Attributes{"val": 12}, term.Annotation())
+
+	// T′ → * F T′
+	tail := term.Children[1].(*parser.InternalNode)
+	assert.Equal(t, Attributes{"inh": 3, "syn": 12}, tail.Annotation())
+
+	// "*"
+	assert.Equal(t, Attributes{Lexeme: "*"}, tail.Children[0].Annotation())
+}
+
+func TestGrammar_Parse(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             parser.Parser
+		expectedError string
+		expectedVal   any
+	}{
+		{
+			name: "ParseFailed",
+			p: predictive.New(parsertest.Grammars[0], &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutError: errors.New("cannot read rune")},
+				},
+			}),
+			expectedError: `cannot read rune`,
+		},
+		{
+			name: "EvaluateFailed",
+			p: predictive.New(parsertest.Grammars[0], &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutToken: lexer.Token{Terminal: grammar.Terminal("id"), Lexeme: "x", Pos: lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1}}},
+					{OutToken: lexer.Token{Terminal: grammar.Endmarker}},
+				},
+			}),
+			expectedError: `test:1:1: $0.val: strconv.Atoi: parsing "x": invalid syntax`,
+		},
+		{
+			name: "Success",
+			p: predictive.New(parsertest.Grammars[0], &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutToken: lexer.Token{Terminal: grammar.Terminal("("), Lexeme: "(", Pos: lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1}}},
+					{OutToken: lexer.Token{Terminal: grammar.Terminal("id"), Lexeme: "2", Pos: lexer.Position{Filename: "test", Offset: 1, Line: 1, Column: 2}}},
+					{OutToken: lexer.Token{Terminal: grammar.Terminal("+"), Lexeme: "+", Pos: lexer.Position{Filename: "test", Offset: 3, Line: 1, Column: 4}}},
+					{OutToken: lexer.Token{Terminal: grammar.Terminal("id"), Lexeme: "3", Pos: lexer.Position{Filename: "test", Offset: 5, Line: 1, Column: 6}}},
+					{OutToken: lexer.Token{Terminal: grammar.Terminal(")"), Lexeme: ")", Pos: lexer.Position{Filename: "test", Offset: 6, Line: 1, Column: 7}}},
+					{OutToken: lexer.Token{Terminal: grammar.Terminal("*"), Lexeme: "*", Pos: lexer.Position{Filename: "test", Offset: 8, Line: 1, Column: 9}}},
+					{OutToken: lexer.Token{Terminal: grammar.Terminal("id"), Lexeme: "4", Pos: lexer.Position{Filename: "test", Offset: 10, Line: 1, Column: 11}}},
+					{OutToken: lexer.Token{Terminal: grammar.Endmarker}},
+				},
+			}),
+			expectedVal: 20,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := getTestGrammar().Parse(tc.p, nil)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+
+				val, ok := Get(root, "val")
+				assert.True(t, ok)
+				assert.Equal(t, tc.expectedVal, val)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
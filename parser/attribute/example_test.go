@@ -0,0 +1,92 @@
+package attribute_test
+
+import (
+	"fmt"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+	"github.com/moorara/algo/parser/attribute"
+)
+
+func Example() {
+	// D → T L
+	// T → int
+	// L → id , L
+	// L → id
+	decl := &grammar.Production{Head: "D", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("T"), grammar.NonTerminal("L")}}
+	typ := &grammar.Production{Head: "T", Body: grammar.String[grammar.Symbol]{grammar.Terminal("int")}}
+	list := &grammar.Production{Head: "L", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal(","), grammar.NonTerminal("L")}}
+	last := &grammar.Production{Head: "L", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}}
+
+	g := attribute.New()
+	_ = g.Declare("D", "decls", attribute.Synthesized)
+	_ = g.Declare("T", "type", attribute.Synthesized)
+	_ = g.Declare("L", "type", attribute.Inherited)
+	_ = g.Declare("L", "decls", attribute.Synthesized)
+
+	pass := func(args []any) (any, error) {
+		return args[0], nil
+	}
+
+	format := func(args []any) (any, error) {
+		s := fmt.Sprintf("%s %s", args[0], args[1])
+		if len(args) > 2 {
+			s += "; " + args[2].(string)
+		}
+		return s, nil
+	}
+
+	rules := []*attribute.Rule{
+		{Production: decl, Target: attribute.Ref{Index: 2, Name: "type"}, Args: []attribute.Ref{{Index: 1, Name: "type"}}, Compute: pass},
+		{Production: decl, Target: attribute.Ref{Index: 0, Name: "decls"}, Args: []attribute.Ref{{Index: 2, Name: "decls"}}, Compute: pass},
+		{Production: typ, Target: attribute.Ref{Index: 0, Name: "type"}, Args: []attribute.Ref{{Index: 1, Name: attribute.Lexeme}}, Compute: pass},
+		{Production: list, Target: attribute.Ref{Index: 3, Name: "type"}, Args: []attribute.Ref{{Index: 0, Name: "type"}}, Compute: pass},
+		{Production: list, Target: attribute.Ref{Index: 0, Name: "decls"}, Args: []attribute.Ref{{Index: 0, Name: "type"}, {Index: 1, Name: attribute.Lexeme}, {Index: 3, Name: "decls"}}, Compute: format},
+		{Production: last, Target: attribute.Ref{Index: 0, Name: "decls"}, Args: []attribute.Ref{{Index: 0, Name: "type"}, {Index: 1, Name: attribute.Lexeme}}, Compute: format},
+	}
+
+	for _, r := range rules {
+		if err := g.AddRule(r); err != nil {
+			panic(err)
+		}
+	}
+
+	// int x, y
+	root := &parser.InternalNode{
+		NonTerminal: "D",
+		Production:  decl,
+		Children: []parser.Node{
+			&parser.InternalNode{
+				NonTerminal: "T",
+				Production:  typ,
+				Children: []parser.Node{
+					&parser.LeafNode{Terminal: "int", Lexeme: "int", Position: lexer.Position{Offset: 0, Line: 1, Column: 1}},
+				},
+			},
+			&parser.InternalNode{
+				NonTerminal: "L",
+				Production:  list,
+				Children: []parser.Node{
+					&parser.LeafNode{Terminal: "id", Lexeme: "x", Position: lexer.Position{Offset: 4, Line: 1, Column: 5}},
+					&parser.LeafNode{Terminal: ",", Lexeme: ",", Position: lexer.Position{Offset: 5, Line: 1, Column: 6}},
+					&parser.InternalNode{
+						NonTerminal: "L",
+						Production:  last,
+						Children: []parser.Node{
+							&parser.LeafNode{Terminal: "id", Lexeme: "y", Position: lexer.Position{Offset: 7, Line: 1, Column: 8}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := g.Evaluate(root, nil); err != nil {
+		panic(err)
+	}
+
+	decls, _ := attribute.Get(root, "decls")
+	fmt.Println(decls)
+	// Output: int x; int y
+}
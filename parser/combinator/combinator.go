@@ -23,7 +23,11 @@ package combinator
 
 import (
 	"fmt"
+	"math"
 	"slices"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
 )
 
 // Parser is the type for a function that receives an input and returns an output.
@@ -37,6 +41,36 @@ type Input interface {
 	Remaining() Input
 }
 
+// TokenInput is an input to a parser function that consists of tokens instead of runes.
+type TokenInput interface {
+	Input
+	// Token returns the current token from input along with its position in the input.
+	Token() (lexer.Token, int)
+}
+
+// offset returns the position of an input, treating the end of input as the farthest position.
+func offset(in Input) int {
+	if in == nil {
+		return math.MaxInt
+	}
+
+	_, pos := in.Current()
+	return pos
+}
+
+// check returns an error if a parser cannot read from an input,
+// either because there is no input left or because reading the input failed.
+func check(in Input) error {
+	switch in := in.(type) {
+	case nil:
+		return errEOF
+	case *errorInput:
+		return fail(in)
+	default:
+		return nil
+	}
+}
+
 // Output is the output of a parser function.
 type Output struct {
 	Result    Result
@@ -109,13 +143,13 @@ var E Parser = func(in Input) (*Output, error) {
 // ExpectRune creates a parser that returns a successful result only if the input starts with the given rune.
 func ExpectRune(r rune) Parser {
 	return func(in Input) (*Output, error) {
-		if in == nil {
-			return nil, errEOF
+		if err := check(in); err != nil {
+			return nil, err
 		}
 
 		curr, pos := in.Current()
 		if curr != r {
-			return nil, &syntaxError{pos, curr}
+			return nil, &syntaxError{Pos: pos, Rune: curr}
 		}
 
 		return &Output{
@@ -128,13 +162,13 @@ func ExpectRune(r rune) Parser {
 // NotExpectRune creates a parser that returns a successful result only if the input does not start with the given rune.
 func NotExpectRune(r rune) Parser {
 	return func(in Input) (*Output, error) {
-		if in == nil {
-			return nil, errEOF
+		if err := check(in); err != nil {
+			return nil, err
 		}
 
 		curr, pos := in.Current()
 		if curr == r {
-			return nil, &syntaxError{pos, curr}
+			return nil, &syntaxError{Pos: pos, Rune: curr}
 		}
 
 		return &Output{
@@ -147,13 +181,13 @@ func NotExpectRune(r rune) Parser {
 // ExpectRuneIn creates a parser that returns a successful result only if the input starts with any of the given runes.
 func ExpectRuneIn(runes ...rune) Parser {
 	return func(in Input) (*Output, error) {
-		if in == nil {
-			return nil, errEOF
+		if err := check(in); err != nil {
+			return nil, err
 		}
 
 		curr, pos := in.Current()
 		if !slices.Contains(runes, curr) {
-			return nil, &syntaxError{pos, curr}
+			return nil, &syntaxError{Pos: pos, Rune: curr}
 		}
 
 		return &Output{
@@ -166,13 +200,13 @@ func ExpectRuneIn(runes ...rune) Parser {
 // NotExpectRuneIn creates a parser that returns a successful result only if the input does not start with any of the given runes.
 func NotExpectRuneIn(runes ...rune) Parser {
 	return func(in Input) (*Output, error) {
-		if in == nil {
-			return nil, errEOF
+		if err := check(in); err != nil {
+			return nil, err
 		}
 
 		curr, pos := in.Current()
 		if slices.Contains(runes, curr) {
-			return nil, &syntaxError{pos, curr}
+			return nil, &syntaxError{Pos: pos, Rune: curr}
 		}
 
 		return &Output{
@@ -189,13 +223,13 @@ func ExpectRuneInRange(lo, hi rune) Parser {
 	}
 
 	return func(in Input) (*Output, error) {
-		if in == nil {
-			return nil, errEOF
+		if err := check(in); err != nil {
+			return nil, err
 		}
 
 		curr, pos := in.Current()
 		if curr < lo || hi < curr {
-			return nil, &syntaxError{pos, curr}
+			return nil, &syntaxError{Pos: pos, Rune: curr}
 		}
 
 		return &Output{
@@ -212,13 +246,13 @@ func NotExpectRuneInRange(lo, hi rune) Parser {
 	}
 
 	return func(in Input) (*Output, error) {
-		if in == nil {
-			return nil, errEOF
+		if err := check(in); err != nil {
+			return nil, err
 		}
 
 		curr, pos := in.Current()
 		if lo <= curr && curr <= hi {
-			return nil, &syntaxError{pos, curr}
+			return nil, &syntaxError{Pos: pos, Rune: curr}
 		}
 
 		return &Output{
@@ -234,13 +268,13 @@ func ExpectRunes(runes ...rune) Parser {
 		var firstPos int
 
 		for i, r := range runes {
-			if in == nil {
-				return nil, errEOF
+			if err := check(in); err != nil {
+				return nil, err
 			}
 
 			curr, pos := in.Current()
 			if curr != r {
-				return nil, &syntaxError{pos, curr}
+				return nil, &syntaxError{Pos: pos, Rune: curr}
 			}
 
 			// Save only the first position
@@ -265,13 +299,13 @@ func NotExpectRunes(runes ...rune) Parser {
 		val := make([]rune, len(runes))
 
 		for i, r := range runes {
-			if in == nil {
-				return nil, errEOF
+			if err := check(in); err != nil {
+				return nil, err
 			}
 
 			curr, pos := in.Current()
 			if curr == r {
-				return nil, &syntaxError{pos, curr}
+				return nil, &syntaxError{Pos: pos, Rune: curr}
 			}
 
 			// Accumulate the parsed runes
@@ -318,11 +352,39 @@ func NotExpectString(s string) Parser {
 	}
 }
 
+// ExpectToken creates a parser that returns a successful result only if the input starts with a token for the given terminal.
+// The input must be a TokenInput, and the value of the result is the matched lexer.Token.
+func ExpectToken(a grammar.Terminal) Parser {
+	return func(in Input) (*Output, error) {
+		if err := check(in); err != nil {
+			return nil, err
+		}
+
+		tin, ok := in.(TokenInput)
+		if !ok {
+			return nil, fail(in)
+		}
+
+		token, pos := tin.Token()
+		if !token.Terminal.Equal(a) {
+			return nil, &syntaxError{Pos: pos, Token: &token}
+		}
+
+		return &Output{
+			Result:    Result{token, pos, nil},
+			Remaining: in.Remaining(),
+		}, nil
+	}
+}
+
 // ALT composes a parser that alternates a sequence of parsers.
 // It applies the first parser to the input and if it does not succeed,
 // it applies the next parser to the same input, and continues parsing to the last parser.
 // It stops at the first successful parsing and returns its result.
 //
+// If none of the parsers succeeds, the error from the parser that failed farthest in the input is returned.
+// If more than one parser failed at the farthest position, their expected labels are combined.
+//
 //   - EBNF Operator: Alternation
 //   - EBNF Notation: p | q
 func ALT(p ...Parser) Parser {
@@ -331,10 +393,12 @@ func ALT(p ...Parser) Parser {
 	}
 
 	return func(in Input) (*Output, error) {
-		if in == nil {
-			return nil, errEOF
+		if err := check(in); err != nil {
+			return nil, err
 		}
 
+		var e *syntaxError
+
 		for _, parse := range p {
 			out, err := parse(in)
 			if err == nil {
@@ -342,14 +406,21 @@ func ALT(p ...Parser) Parser {
 			}
 
 			// A semantic error means parsing passed syntax but failed a semantic check.
-			// Stop here and propagate the error instead of treating it as an optional miss.
-			if _, ok := err.(*semanticError); ok {
+			// A committed error means parsing failed after a cut.
+			// Stop here and propagate the error instead of trying the next alternative.
+			if isFatal(err) {
 				return nil, err
 			}
+
+			// Keep track of the farthest failure among all alternatives.
+			if se, ok := toSyntaxError(err); ok {
+				e = farthest(e, se)
+			} else {
+				e = farthest(e, fail(in))
+			}
 		}
 
-		curr, pos := in.Current()
-		return nil, &syntaxError{pos, curr}
+		return nil, e
 	}
 }
 
@@ -390,7 +461,7 @@ func CONCAT(p ...Parser) Parser {
 //   - EBNF Operator: Optional
 //   - EBNF Notation: [ p ] or p?
 //
-// OPT returns an error only if a SemanticError is encountered.
+// OPT returns an error only if a semantic error or an error after a cut is encountered.
 func OPT(p Parser) Parser {
 	return func(in Input) (*Output, error) {
 		out, err := p(in)
@@ -399,8 +470,9 @@ func OPT(p Parser) Parser {
 		}
 
 		// A semantic error means parsing passed syntax but failed a semantic check.
+		// A committed error means parsing failed after a cut.
 		// Stop here and propagate the error instead of treating it as an optional miss.
-		if _, ok := err.(*semanticError); ok {
+		if isFatal(err) {
 			return nil, err
 		}
 
@@ -419,7 +491,7 @@ func OPT(p Parser) Parser {
 //   - EBNF Operator: Repetition (Kleene Star)
 //   - EBNF Notation: { p } or p*
 //
-// REP returns an error only if a SemanticError is encountered.
+// REP returns an error only if a semantic error or an error after a cut is encountered.
 func REP(p Parser) Parser {
 	return func(in Input) (*Output, error) {
 		var l List
@@ -428,8 +500,9 @@ func REP(p Parser) Parser {
 			out, err := p(in)
 			if err != nil {
 				// A semantic error means parsing passed syntax but failed a semantic check.
+				// A committed error means parsing failed after a cut.
 				// Stop here and propagate the error instead of treating it as an optional miss.
-				if _, ok := err.(*semanticError); ok {
+				if isFatal(err) {
 					return nil, err
 				}
 
@@ -463,8 +536,8 @@ func REP(p Parser) Parser {
 //   - EBNF Notation: p+
 func REP1(p Parser) Parser {
 	return func(in Input) (*Output, error) {
-		if in == nil {
-			return nil, errEOF
+		if err := check(in); err != nil {
+			return nil, err
 		}
 
 		out, err := p.REP()(in)
@@ -476,7 +549,7 @@ func REP1(p Parser) Parser {
 		res, ok := out.Result.Val.(List)
 		if !ok || len(res) == 0 {
 			curr, pos := in.Current()
-			return nil, &syntaxError{pos, curr}
+			return nil, &syntaxError{Pos: pos, Rune: curr}
 		}
 
 		return out, nil
@@ -714,3 +787,62 @@ func (p Parser) Bind(f BindFunc) Parser {
 // BindFunc is a function that receives a parsing result and returns a new parser.
 // It is more powerful than MapFunc as it can produce a new parser based on the value of the parsing result.
 type BindFunc func(Result) Parser
+
+// Label composes a parser that applies parser p to the input and names it for error messages.
+//
+// If parser p fails without consuming any input, the error reports the name as what was expected at that position.
+// When alternatives fail at the same position, ALT combines their names into an "expected one of" message.
+// If parser p fails after consuming some input, the more specific error from parser p is returned as is.
+func (p Parser) Label(name string) Parser {
+	return func(in Input) (*Output, error) {
+		out, err := p(in)
+		if err == nil {
+			return out, nil
+		}
+
+		e, ok := toSyntaxError(err)
+		if !ok || e.Committed || e.offset() != offset(in) {
+			return nil, err
+		}
+
+		labeled := *e
+		labeled.Expected = []string{name}
+
+		return nil, &labeled
+	}
+}
+
+// Cut composes a parser that applies parser p to the input and commits to it.
+//
+// Cut is used after a prefix of a production rule that uniquely identifies it.
+// Once the prefix is parsed, any failure of parser p means the input is invalid,
+// and there is no point in trying other alternatives.
+// The syntax error from parser p is then committed, causing combinators like ALT, OPT, REP, and REP1
+// to stop backtracking and return the error immediately.
+// This results in more precise error messages pointing to where parsing actually failed.
+//
+// Example:
+//
+//	// Production rule: stmt → "if" cond "then" stmt | assign
+//	stmt := ALT(
+//		CONCAT(ExpectString("if"), CONCAT(cond, ExpectString("then"), stmt).Cut()),
+//		assign,
+//	)
+func (p Parser) Cut() Parser {
+	return func(in Input) (*Output, error) {
+		out, err := p(in)
+		if err == nil {
+			return out, nil
+		}
+
+		e, ok := toSyntaxError(err)
+		if !ok {
+			return nil, err
+		}
+
+		committed := *e
+		committed.Committed = true
+
+		return nil, &committed
+	}
+}
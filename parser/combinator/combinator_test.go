@@ -2,10 +2,15 @@ package combinator
 
 import (
 	"errors"
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
 )
 
 // stringInput implements the input interface for strings.
@@ -798,6 +803,118 @@ func TestNotExpectString(t *testing.T) {
 	}
 }
 
+func TestExpectToken(t *testing.T) {
+	newTokenInput := func(tokens ...lexer.Token) Input {
+		mocks := make([]parsertest.NextTokenMock, 0, len(tokens)+1)
+		for _, token := range tokens {
+			mocks = append(mocks, parsertest.NextTokenMock{OutToken: token})
+		}
+		mocks = append(mocks, parsertest.NextTokenMock{OutError: io.EOF})
+
+		in, _ := NewTokenInput(&parsertest.MockLexer{NextTokenMocks: mocks})
+		return in
+	}
+
+	tokens := getTestTokens()
+
+	tests := []struct {
+		name          string
+		in            Input
+		a             grammar.Terminal
+		expectedOut   *Output
+		expectedError string
+	}{
+		{
+			name:          "NoInput",
+			in:            nil,
+			a:             "id",
+			expectedError: "end of input",
+		},
+		{
+			name:          "NotTokenInput",
+			in:            newStringInput("ab"),
+			a:             "id",
+			expectedError: "0: unexpected rune 'a'",
+		},
+		{
+			name:          "InputNotMatching",
+			in:            newTokenInput(tokens...),
+			a:             "num",
+			expectedError: `0: unexpected token "id" "x"`,
+		},
+		{
+			name: "Successful_WithoutRemaining",
+			in:   newTokenInput(tokens[2]),
+			a:    "num",
+			expectedOut: &Output{
+				Result:    Result{tokens[2], 0, nil},
+				Remaining: nil,
+			},
+		},
+		{
+			name: "Successful_WithRemaining",
+			in:   newTokenInput(tokens...),
+			a:    "id",
+			expectedOut: &Output{
+				Result:    Result{tokens[0], 0, nil},
+				Remaining: newTokenInput(tokens...).Remaining(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := ExpectToken(tc.a)(tc.in)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedOut.Result, out.Result)
+
+				if tc.expectedOut.Remaining == nil {
+					assert.Nil(t, out.Remaining)
+				} else {
+					expectedToken, expectedPos := tc.expectedOut.Remaining.(TokenInput).Token()
+					token, pos := out.Remaining.(TokenInput).Token()
+					assert.Equal(t, expectedToken, token)
+					assert.Equal(t, expectedPos, pos)
+				}
+			} else {
+				assert.Nil(t, out)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestExpectToken_Combinators(t *testing.T) {
+	tokens := getTestTokens()
+
+	in, err := NewTokenInput(&parsertest.MockLexer{
+		NextTokenMocks: []parsertest.NextTokenMock{
+			{OutToken: tokens[0]},
+			{OutToken: tokens[1]},
+			{OutToken: lexer.Token{Terminal: "+", Lexeme: "+", Pos: lexer.Position{Filename: "test", Offset: 4, Line: 1, Column: 5}}},
+			{OutError: io.EOF},
+		},
+	})
+	assert.NoError(t, err)
+
+	// Production rule: assign → id "=" ( num | id )
+	assign := CONCAT(
+		ExpectToken("id"),
+		ExpectToken("="),
+		ALT(
+			ExpectToken("num").Label("number"),
+			ExpectToken("id").Label("identifier"),
+		),
+	)
+
+	out, err := assign(in)
+	assert.Nil(t, out)
+	assert.EqualError(t, err, `2: unexpected token "+" "+", expected one of: number, identifier`)
+	assert.EqualError(t, NewLineIndex("test", "").Error(err), `test:1:5: unexpected token "+" "+", expected one of: number, identifier`)
+}
+
 func TestALT(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -858,6 +975,54 @@ func TestALT(t *testing.T) {
 			},
 			expectedError: "0: invalid semantic",
 		},
+		{
+			name: "FarthestFailure",
+			in:   newStringInput("abc"),
+			p: []Parser{
+				ExpectString("a0"),
+				ExpectString("ab0"),
+				ExpectString("0"),
+			},
+			expectedError: "2: unexpected rune 'c'",
+		},
+		{
+			name: "FarthestFailure_EOF",
+			in:   newStringInput("ab"),
+			p: []Parser{
+				ExpectString("a0"),
+				ExpectString("abc"),
+			},
+			expectedError: "end of input",
+		},
+		{
+			name: "FarthestFailure_Labels",
+			in:   newStringInput("ab"),
+			p: []Parser{
+				ExpectRuneInRange('0', '9').Label("digit"),
+				ExpectRune('(').Label("'('"),
+				ExpectRune('-').Label("'-'"),
+			},
+			expectedError: "0: unexpected rune 'a', expected one of: digit, '(', '-'",
+		},
+		{
+			name: "CommittedError",
+			in:   newStringInput("abc"),
+			p: []Parser{
+				ExpectRune('a').CONCAT(ExpectString("bd").Cut()),
+				ExpectString("abc"),
+			},
+			expectedError: "2: unexpected rune 'c'",
+		},
+		{
+			name: "OtherError",
+			in:   newStringInput("ab"),
+			p: []Parser{
+				func(Input) (*Output, error) {
+					return nil, errors.New("unknown error")
+				},
+			},
+			expectedError: "0: unexpected rune 'a'",
+		},
 		{
 			name: "Successful_FirstParser",
 			in:   newStringInput("ab"),
@@ -2228,3 +2393,179 @@ func TestParser_Bind(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_Label(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            Input
+		p             Parser
+		label         string
+		expectedOut   *Output
+		expectedError string
+	}{
+		{
+			name:          "NoInput",
+			in:            nil,
+			p:             ExpectRuneInRange('0', '9'),
+			label:         "digit",
+			expectedError: "end of input, expected digit",
+		},
+		{
+			name:          "InputNotMatching",
+			in:            newStringInput("ab"),
+			p:             ExpectRuneInRange('0', '9'),
+			label:         "digit",
+			expectedError: "0: unexpected rune 'a', expected digit",
+		},
+		{
+			name:          "InputPartiallyMatching",
+			in:            newStringInput("ab"),
+			p:             ExpectString("ac"),
+			label:         "keyword",
+			expectedError: "1: unexpected rune 'b'",
+		},
+		{
+			name:          "InputPartiallyMatching_EOF",
+			in:            newStringInput("ab"),
+			p:             ExpectString("abc"),
+			label:         "keyword",
+			expectedError: "end of input",
+		},
+		{
+			name:          "CommittedError",
+			in:            newStringInput("ab"),
+			p:             ExpectRune('0').Cut(),
+			label:         "zero",
+			expectedError: "0: unexpected rune 'a'",
+		},
+		{
+			name: "SemanticError",
+			in:   newStringInput("ab"),
+			p: ExpectString("ab").Map(func(r Result) (Result, error) {
+				return Result{}, errors.New("invalid semantic")
+			}),
+			label:         "keyword",
+			expectedError: "0: invalid semantic",
+		},
+		{
+			name:  "Successful",
+			in:    newStringInput("ab"),
+			p:     ExpectString("ab"),
+			label: "keyword",
+			expectedOut: &Output{
+				Result:    Result{"ab", 0, nil},
+				Remaining: nil,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := tc.p.Label(tc.label)(tc.in)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedOut, out)
+			} else {
+				assert.Nil(t, out)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestParser_Cut(t *testing.T) {
+	// Production rule: call → id "(" id ")"
+	id := ExpectRuneInRange('a', 'z').Label("identifier")
+	call := id.CONCAT(ExpectRune('('), CONCAT(id, ExpectRune(')').Label("')'")).Cut())
+
+	tests := []struct {
+		name          string
+		in            Input
+		p             Parser
+		expectedOut   *Output
+		expectedError string
+	}{
+		{
+			name:          "NoInput",
+			in:            nil,
+			p:             ExpectRune('a').Cut(),
+			expectedError: "end of input",
+		},
+		{
+			name:          "InputNotMatching",
+			in:            newStringInput("ab"),
+			p:             ExpectRune('0').Cut(),
+			expectedError: "0: unexpected rune 'a'",
+		},
+		{
+			name: "SemanticError",
+			in:   newStringInput("ab"),
+			p: ExpectString("ab").Map(func(r Result) (Result, error) {
+				return Result{}, errors.New("invalid semantic")
+			}).Cut(),
+			expectedError: "0: invalid semantic",
+		},
+		{
+			name:        "ALT_BeforeCut",
+			in:          newStringInput("f"),
+			p:           ALT(call, id),
+			expectedOut: &Output{Result: Result{'f', 0, nil}, Remaining: nil},
+		},
+		{
+			name:          "ALT_AfterCut",
+			in:            newStringInput("f(x]"),
+			p:             ALT(call, id),
+			expectedError: "3: unexpected rune ']', expected ')'",
+		},
+		{
+			name:          "OPT_AfterCut",
+			in:            newStringInput("f(x]"),
+			p:             OPT(call),
+			expectedError: "3: unexpected rune ']', expected ')'",
+		},
+		{
+			name:          "REP_AfterCut",
+			in:            newStringInput("f(x)g(1)"),
+			p:             REP(call),
+			expectedError: "6: unexpected rune '1', expected identifier",
+		},
+		{
+			name:          "REP1_AfterCut",
+			in:            newStringInput("f(x)g(1)"),
+			p:             REP1(call),
+			expectedError: "6: unexpected rune '1', expected identifier",
+		},
+		{
+			name: "Successful",
+			in:   newStringInput("f(x)"),
+			p:    call.Flatten(),
+			expectedOut: &Output{
+				Result: Result{
+					Val: List{
+						Result{'f', 0, nil},
+						Result{'(', 1, nil},
+						Result{'x', 2, nil},
+						Result{')', 3, nil},
+					},
+					Pos: 0,
+				},
+				Remaining: nil,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := tc.p(tc.in)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedOut, out)
+			} else {
+				assert.Nil(t, out)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
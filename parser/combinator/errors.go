@@ -3,6 +3,11 @@ package combinator
 import (
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/moorara/algo/lexer"
 )
 
 // errEOF is returned when the end of input is reached unexpectedly.
@@ -12,11 +17,66 @@ var errEOF = errors.New("end of input")
 type syntaxError struct {
 	Pos  int
 	Rune rune
+	// Token is the unexpected token if the input is a TokenInput.
+	Token *lexer.Token
+	// EOF indicates that the error occurred at the end of input, where there is no position or rune.
+	EOF bool
+	// Expected is the list of labels for the rules that were expected at the position of the error.
+	Expected []string
+	// Committed indicates that the error occurred after a cut, so alternatives must not be tried.
+	Committed bool
+	// Err is the error from reading the input at the position of the error, if any.
+	// Such an error is always fatal, since the rest of the input is not available.
+	Err error
 }
 
 // Error implements the error interface.
 func (e *syntaxError) Error() string {
-	return fmt.Sprintf("%d: unexpected rune %q", e.Pos, e.Rune)
+	if e.EOF {
+		return e.describe()
+	}
+
+	return fmt.Sprintf("%d: %s", e.Pos, e.describe())
+}
+
+// describe returns a description of the error without its position.
+func (e *syntaxError) describe() string {
+	var b strings.Builder
+
+	switch {
+	case e.Err != nil:
+		b.WriteString(e.Err.Error())
+	case e.EOF:
+		b.WriteString("end of input")
+	case e.Token != nil:
+		fmt.Fprintf(&b, "unexpected token %s %q", e.Token.Terminal, e.Token.Lexeme)
+	default:
+		fmt.Fprintf(&b, "unexpected rune %q", e.Rune)
+	}
+
+	switch len(e.Expected) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&b, ", expected %s", e.Expected[0])
+	default:
+		fmt.Fprintf(&b, ", expected one of: %s", strings.Join(e.Expected, ", "))
+	}
+
+	return b.String()
+}
+
+// Unwrap implements the unwrapper interface.
+func (e *syntaxError) Unwrap() error {
+	return e.Err
+}
+
+// offset returns the position of the error, treating the end of input as the farthest position.
+func (e *syntaxError) offset() int {
+	if e.EOF {
+		return math.MaxInt
+	}
+
+	return e.Pos
 }
 
 // semanticError represents a semantic error encountered during parsing.
@@ -27,10 +87,105 @@ type semanticError struct {
 
 // Error implements the error interface.
 func (e *semanticError) Error() string {
-	return fmt.Sprintf("%d: %s", e.Pos, e.Err)
+	return fmt.Sprintf("%d: %s", e.Pos, e.describe())
+}
+
+// describe returns a description of the error without its position.
+func (e *semanticError) describe() string {
+	return e.Err.Error()
 }
 
 // Unwrap implements the unwrapper interface.
 func (e *semanticError) Unwrap() error {
 	return e.Err
 }
+
+// positionError is a parsing error with a line and column position.
+type positionError struct {
+	Pos  lexer.Position
+	Desc string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *positionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Desc)
+}
+
+// Unwrap implements the unwrapper interface.
+func (e *positionError) Unwrap() error {
+	return e.Err
+}
+
+// fail returns the error for a parser that cannot be applied to an input.
+func fail(in Input) *syntaxError {
+	switch in := in.(type) {
+	case nil:
+		return &syntaxError{EOF: true}
+	case *errorInput:
+		return &syntaxError{Pos: in.pos, Err: in.err}
+	}
+
+	if tin, ok := in.(TokenInput); ok {
+		token, pos := tin.Token()
+		return &syntaxError{Pos: pos, Token: &token}
+	}
+
+	curr, pos := in.Current()
+	return &syntaxError{Pos: pos, Rune: curr}
+}
+
+// toSyntaxError converts a syntactic error to a *syntaxError.
+// The end of input error is converted to a *syntaxError with the EOF flag set.
+func toSyntaxError(err error) (*syntaxError, bool) {
+	if err == errEOF {
+		return &syntaxError{EOF: true}, true
+	}
+
+	e, ok := err.(*syntaxError)
+	return e, ok
+}
+
+// isFatal determines whether or not an error must stop backtracking.
+//
+// A semantic error means parsing passed syntax but failed a semantic check.
+// A committed syntax error means parsing failed after a cut.
+// A syntax error from reading the input means the rest of the input is not available.
+// In all cases, combinators like ALT, OPT, REP, and REP1 must stop parsing and propagate the error
+// instead of treating it as an optional miss.
+func isFatal(err error) bool {
+	switch e := err.(type) {
+	case *semanticError:
+		return true
+	case *syntaxError:
+		return e.Committed || e.Err != nil
+	default:
+		return false
+	}
+}
+
+// farthest merges two syntax errors from alternative parsers into the one that occurred farthest in the input.
+// If both errors occurred at the same position, their expected labels are combined.
+func farthest(a, b *syntaxError) *syntaxError {
+	switch {
+	case a == nil:
+		return b
+	case a.offset() < b.offset():
+		return b
+	case a.offset() > b.offset():
+		return a
+	}
+
+	expected := append([]string{}, a.Expected...)
+	for _, label := range b.Expected {
+		if !slices.Contains(expected, label) {
+			expected = append(expected, label)
+		}
+	}
+
+	merged := *a
+	merged.Expected = expected
+	merged.Committed = false
+
+	return &merged
+}
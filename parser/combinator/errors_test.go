@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/lexer"
 )
 
 func TestSyntaxError(t *testing.T) {
@@ -21,6 +23,48 @@ func TestSyntaxError(t *testing.T) {
 			},
 			expectedError: "0: unexpected rune 'a'",
 		},
+		{
+			name: "EOF",
+			e: &syntaxError{
+				EOF: true,
+			},
+			expectedError: "end of input",
+		},
+		{
+			name: "Token",
+			e: &syntaxError{
+				Pos:      1,
+				Token:    &lexer.Token{Terminal: "=", Lexeme: "=", Pos: lexer.Position{Filename: "test", Offset: 2, Line: 1, Column: 3}},
+				Expected: []string{"'('"},
+			},
+			expectedError: `1: unexpected token "=" "=", expected '('`,
+		},
+		{
+			name: "Expected",
+			e: &syntaxError{
+				Pos:      2,
+				Rune:     'x',
+				Expected: []string{"num"},
+			},
+			expectedError: "2: unexpected rune 'x', expected num",
+		},
+		{
+			name: "ExpectedOneOf",
+			e: &syntaxError{
+				Pos:      2,
+				Rune:     'x',
+				Expected: []string{"num", "ident", "'('"},
+			},
+			expectedError: "2: unexpected rune 'x', expected one of: num, ident, '('",
+		},
+		{
+			name: "EOF_ExpectedOneOf",
+			e: &syntaxError{
+				EOF:      true,
+				Expected: []string{"num", "ident"},
+			},
+			expectedError: "end of input, expected one of: num, ident",
+		},
 	}
 
 	for _, tc := range tests {
@@ -55,3 +99,186 @@ func TestSemanticError(t *testing.T) {
 		})
 	}
 }
+
+func TestPositionError(t *testing.T) {
+	tests := []struct {
+		name           string
+		e              *positionError
+		expectedError  string
+		expectedUnwrap error
+	}{
+		{
+			name: "OK",
+			e: &positionError{
+				Pos:  lexer.Position{Filename: "test", Offset: 5, Line: 2, Column: 3},
+				Desc: "unexpected rune 'a'",
+				Err:  &syntaxError{Pos: 5, Rune: 'a'},
+			},
+			expectedError:  "test:2:3: unexpected rune 'a'",
+			expectedUnwrap: &syntaxError{Pos: 5, Rune: 'a'},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedError, tc.e.Error())
+			assert.Equal(t, tc.expectedUnwrap, tc.e.Unwrap())
+		})
+	}
+}
+
+func TestFail(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            Input
+		expectedError *syntaxError
+	}{
+		{
+			name:          "EOF",
+			in:            nil,
+			expectedError: &syntaxError{EOF: true},
+		},
+		{
+			name:          "OK",
+			in:            newStringInput("ab"),
+			expectedError: &syntaxError{Pos: 0, Rune: 'a'},
+		},
+		{
+			name: "TokenInput",
+			in: &tokenInput{
+				s: &stream[lexer.Token]{
+					buf: getTestTokens(),
+				},
+				pos: 1,
+			},
+			expectedError: &syntaxError{Pos: 1, Token: &getTestTokens()[1]},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedError, fail(tc.in))
+		})
+	}
+}
+
+func TestToSyntaxError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedOK    bool
+		expectedError *syntaxError
+	}{
+		{
+			name:          "EOF",
+			err:           errEOF,
+			expectedOK:    true,
+			expectedError: &syntaxError{EOF: true},
+		},
+		{
+			name:          "SyntaxError",
+			err:           &syntaxError{Pos: 1, Rune: 'b'},
+			expectedOK:    true,
+			expectedError: &syntaxError{Pos: 1, Rune: 'b'},
+		},
+		{
+			name:       "SemanticError",
+			err:        &semanticError{Pos: 1, Err: errors.New("invalid semantic")},
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e, ok := toSyntaxError(tc.err)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedError, e)
+		})
+	}
+}
+
+func TestIsFatal(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedFatal bool
+	}{
+		{
+			name:          "EOF",
+			err:           errEOF,
+			expectedFatal: false,
+		},
+		{
+			name:          "SyntaxError",
+			err:           &syntaxError{Pos: 1, Rune: 'b'},
+			expectedFatal: false,
+		},
+		{
+			name:          "CommittedSyntaxError",
+			err:           &syntaxError{Pos: 1, Rune: 'b', Committed: true},
+			expectedFatal: true,
+		},
+		{
+			name:          "SemanticError",
+			err:           &semanticError{Pos: 1, Err: errors.New("invalid semantic")},
+			expectedFatal: true,
+		},
+		{
+			name:          "OtherError",
+			err:           errors.New("unknown error"),
+			expectedFatal: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedFatal, isFatal(tc.err))
+		})
+	}
+}
+
+func TestFarthest(t *testing.T) {
+	tests := []struct {
+		name          string
+		a, b          *syntaxError
+		expectedError *syntaxError
+	}{
+		{
+			name:          "FirstNil",
+			a:             nil,
+			b:             &syntaxError{Pos: 1, Rune: 'b'},
+			expectedError: &syntaxError{Pos: 1, Rune: 'b'},
+		},
+		{
+			name:          "FirstFarther",
+			a:             &syntaxError{Pos: 2, Rune: 'c'},
+			b:             &syntaxError{Pos: 1, Rune: 'b', Expected: []string{"num"}},
+			expectedError: &syntaxError{Pos: 2, Rune: 'c'},
+		},
+		{
+			name:          "SecondFarther",
+			a:             &syntaxError{Pos: 1, Rune: 'b', Expected: []string{"num"}},
+			b:             &syntaxError{Pos: 2, Rune: 'c'},
+			expectedError: &syntaxError{Pos: 2, Rune: 'c'},
+		},
+		{
+			name:          "EOFFarther",
+			a:             &syntaxError{Pos: 2, Rune: 'c'},
+			b:             &syntaxError{EOF: true},
+			expectedError: &syntaxError{EOF: true},
+		},
+		{
+			name:          "SamePosition",
+			a:             &syntaxError{Pos: 1, Rune: 'b', Expected: []string{"num", "ident"}},
+			b:             &syntaxError{Pos: 1, Rune: 'b', Expected: []string{"ident", "string"}},
+			expectedError: &syntaxError{Pos: 1, Rune: 'b', Expected: []string{"num", "ident", "string"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedError, farthest(tc.a, tc.b))
+		})
+	}
+}
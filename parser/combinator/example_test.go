@@ -2,7 +2,11 @@ package combinator_test
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/lexer/dfa"
+	"github.com/moorara/algo/lexer/input"
 	"github.com/moorara/algo/parser/combinator"
 )
 
@@ -91,6 +95,103 @@ func ExampleParser_Bind() {
 	}
 }
 
+func ExampleMemo_Rule() {
+	m := combinator.NewMemo()
+
+	digit := combinator.ExpectRuneInRange('0', '9').Map(toDigit)
+	num := digit.REP1().Map(toNum)
+
+	// Production rule: expr → expr "-" num | num
+	// The left-recursive rule makes subtraction left-associative.
+	var expr combinator.Parser
+	expr = m.Rule(func() combinator.Parser {
+		return expr.CONCAT(combinator.ExpectRune('-'), num).Map(evalSub).ALT(num)
+	})
+
+	out, err := m.Parse(expr, newStringInput("20 - 5 - 3"))
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	n := out.Result.Val.(int)
+	fmt.Println(n)
+	// Output: 12
+}
+
+func ExampleParser_Label() {
+	digit := combinator.ExpectRuneInRange('0', '9').Label("digit")
+	letter := combinator.ExpectRuneInRange('a', 'z').Label("letter")
+	operand := combinator.ALT(digit, letter, combinator.ExpectRune('(').Label("'('"))
+
+	// Production rule: expr → operand "+" "\n" operand
+	expr := combinator.CONCAT(operand, combinator.ExpectRune('+'), combinator.ExpectRune('\n'), operand)
+
+	src := "1+\n  *"
+	_, err := expr(newStringInput(src))
+
+	// Convert the offset in the error to a line and column.
+	x := combinator.NewLineIndex("expr", src)
+	fmt.Println(x.Error(err))
+	// Output: expr:2:3: unexpected rune '*', expected one of: digit, letter, '('
+}
+
+func ExampleExpectToken() {
+	rules := []dfa.Rule{
+		{Terminal: "id", Regex: `[a-z]+`},
+		{Terminal: "num", Regex: `[0-9]+`},
+		{Terminal: "=", Regex: `=`},
+		{Terminal: ";", Regex: `;`},
+	}
+
+	T, err := dfa.BuildTable(rules, []string{`\s+`})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	src, err := input.New("assign", strings.NewReader("x = 27; y = x;"), 4096)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	in, err := combinator.NewTokenInput(&dfa.Lexer{In: src, T: T})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	// Production rule: assign → id "=" ( num | id ) ";"
+	assign := combinator.CONCAT(
+		combinator.ExpectToken("id"),
+		combinator.ExpectToken("="),
+		combinator.ALT(combinator.ExpectToken("num"), combinator.ExpectToken("id")),
+		combinator.ExpectToken(";"),
+	).Map(func(r combinator.Result) (combinator.Result, error) {
+		lhs, _ := r.Get(0)
+		rhs, _ := r.Get(2)
+
+		return combinator.Result{
+			Val: fmt.Sprintf("%s ← %s", lhs.Val.(lexer.Token).Lexeme, rhs.Val.(lexer.Token).Lexeme),
+			Pos: lhs.Pos,
+		}, nil
+	})
+
+	out, err := assign.REP1()(in)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	for _, r := range out.Result.Val.(combinator.List) {
+		fmt.Println(r.Val)
+	}
+	// Output:
+	// x ← 27
+	// y ← x
+}
+
 func toDigit(r combinator.Result) (combinator.Result, error) {
 	v := r.Val.(rune)
 	digit := int(v - '0')
@@ -142,6 +243,19 @@ func evalExpr(r combinator.Result) (combinator.Result, error) {
 	}, nil
 }
 
+func evalSub(r combinator.Result) (combinator.Result, error) {
+	r0, _ := r.Get(0)
+	r2, _ := r.Get(2)
+
+	n0 := r0.Val.(int)
+	n2 := r2.Val.(int)
+
+	return combinator.Result{
+		Val: int(n0 - n2),
+		Pos: r0.Pos,
+	}, nil
+}
+
 // stringInput implements the combinator.Input interface for strings.
 type stringInput struct {
 	pos   int
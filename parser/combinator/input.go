@@ -0,0 +1,255 @@
+package combinator
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"unicode/utf8"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+)
+
+// NewStringInput creates an input for parsing a string.
+// Positions are the offsets of runes in the string.
+// If the string is empty, it returns nil.
+func NewStringInput(s string) Input {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return &textInput{s: s}
+}
+
+// textInput implements the Input interface for strings.
+type textInput struct {
+	s   string
+	i   int // The byte offset of the current rune.
+	pos int // The rune offset of the current rune.
+}
+
+func (in *textInput) Current() (rune, int) {
+	r, _ := utf8.DecodeRuneInString(in.s[in.i:])
+	return r, in.pos
+}
+
+func (in *textInput) Remaining() Input {
+	_, size := utf8.DecodeRuneInString(in.s[in.i:])
+	if in.i+size >= len(in.s) {
+		return nil
+	}
+
+	return &textInput{
+		s:   in.s,
+		i:   in.i + size,
+		pos: in.pos + 1,
+	}
+}
+
+// NewBytesInput creates an input for parsing a UTF-8 encoded byte slice.
+// Positions are the offsets of runes in the byte slice.
+// If the byte slice is empty, it returns nil.
+//
+// The byte slice is not copied, so it should not be modified while parsing.
+func NewBytesInput(b []byte) Input {
+	if len(b) == 0 {
+		return nil
+	}
+
+	return &bytesInput{b: b}
+}
+
+// bytesInput implements the Input interface for byte slices.
+type bytesInput struct {
+	b   []byte
+	i   int // The byte offset of the current rune.
+	pos int // The rune offset of the current rune.
+}
+
+func (in *bytesInput) Current() (rune, int) {
+	r, _ := utf8.DecodeRune(in.b[in.i:])
+	return r, in.pos
+}
+
+func (in *bytesInput) Remaining() Input {
+	_, size := utf8.DecodeRune(in.b[in.i:])
+	if in.i+size >= len(in.b) {
+		return nil
+	}
+
+	return &bytesInput{
+		b:   in.b,
+		i:   in.i + size,
+		pos: in.pos + 1,
+	}
+}
+
+// stream lazily reads values from a source and buffers them.
+// Since parsers may backtrack to any earlier position, all values read are kept in the buffer.
+type stream[T any] struct {
+	next func() (T, error)
+	buf  []T
+	err  error
+}
+
+// at returns the value at index i, reading from the source as needed.
+// The second return value is false if the source ends before index i.
+func (s *stream[T]) at(i int) (T, bool) {
+	for len(s.buf) <= i && s.err == nil {
+		v, err := s.next()
+		if err != nil {
+			s.err = err
+			break
+		}
+
+		s.buf = append(s.buf, v)
+	}
+
+	if i < len(s.buf) {
+		return s.buf[i], true
+	}
+
+	var zero T
+	return zero, false
+}
+
+// error returns the error that ended the stream, or nil if the stream ended normally.
+func (s *stream[T]) error() error {
+	if errors.Is(s.err, io.EOF) {
+		return nil
+	}
+
+	return s.err
+}
+
+// end returns the input after the last value of the stream at index i.
+// If the stream ended normally, there is no input left and it returns nil.
+// Otherwise, it returns an input that fails with the error that ended the stream.
+func (s *stream[T]) end(i int) Input {
+	if err := s.error(); err != nil {
+		return &errorInput{pos: i, err: err}
+	}
+
+	return nil
+}
+
+// NewReaderInput creates an input for parsing the UTF-8 encoded text from a reader.
+// Runes are read on demand through a buffered reader.
+// Positions are the offsets of runes in the text.
+//
+// All runes read are kept in memory, so parsers can backtrack to any earlier position.
+//
+// If the reader is empty, it returns nil.
+// If reading the first rune fails, it returns the error.
+// Any subsequent read error fails the parser that reaches its position with a syntax error wrapping the read error.
+func NewReaderInput(r io.Reader) (Input, error) {
+	br := bufio.NewReader(r)
+	s := &stream[rune]{
+		next: func() (rune, error) {
+			r, _, err := br.ReadRune()
+			return r, err
+		},
+	}
+
+	if _, ok := s.at(0); !ok {
+		return nil, s.error()
+	}
+
+	return &readerInput{s: s}, nil
+}
+
+// readerInput implements the Input interface for readers.
+type readerInput struct {
+	s   *stream[rune]
+	pos int
+}
+
+func (in *readerInput) Current() (rune, int) {
+	r, _ := in.s.at(in.pos)
+	return r, in.pos
+}
+
+func (in *readerInput) Remaining() Input {
+	if _, ok := in.s.at(in.pos + 1); !ok {
+		return in.s.end(in.pos + 1)
+	}
+
+	return &readerInput{
+		s:   in.s,
+		pos: in.pos + 1,
+	}
+}
+
+// NewTokenInput creates an input for parsing the tokens from a lexer.
+// This allows parser combinators to be layered on top of a lexer.
+// Tokens are read on demand, and positions are the indices of tokens in the token stream.
+// Use ExpectToken for matching tokens.
+//
+// All tokens read are kept in memory, so parsers can backtrack to any earlier position.
+//
+// The input ends when the lexer returns an io.EOF error or an endmarker token.
+// If the lexer has no tokens, it returns nil.
+// If reading the first token fails, it returns the error.
+// Any subsequent lexer error fails the parser that reaches its position with a syntax error wrapping the lexer error.
+func NewTokenInput(l lexer.Lexer) (Input, error) {
+	s := &stream[lexer.Token]{
+		next: func() (lexer.Token, error) {
+			token, err := l.NextToken()
+			if err == nil && token.Terminal.Equal(grammar.Endmarker) {
+				return token, io.EOF
+			}
+
+			return token, err
+		},
+	}
+
+	if _, ok := s.at(0); !ok {
+		return nil, s.error()
+	}
+
+	return &tokenInput{s: s}, nil
+}
+
+// tokenInput implements the TokenInput interface for lexers.
+type tokenInput struct {
+	s   *stream[lexer.Token]
+	pos int
+}
+
+// Current returns the first rune of the current token lexeme along with the position of the token.
+func (in *tokenInput) Current() (rune, int) {
+	token, _ := in.s.at(in.pos)
+	r, _ := utf8.DecodeRuneInString(token.Lexeme)
+	return r, in.pos
+}
+
+func (in *tokenInput) Remaining() Input {
+	if _, ok := in.s.at(in.pos + 1); !ok {
+		return in.s.end(in.pos + 1)
+	}
+
+	return &tokenInput{
+		s:   in.s,
+		pos: in.pos + 1,
+	}
+}
+
+func (in *tokenInput) Token() (lexer.Token, int) {
+	token, _ := in.s.at(in.pos)
+	return token, in.pos
+}
+
+// errorInput is the input at the position where reading from a reader or lexer failed.
+// Any parser that reads from it fails with a syntax error wrapping the read error.
+type errorInput struct {
+	pos int
+	err error
+}
+
+func (in *errorInput) Current() (rune, int) {
+	return utf8.RuneError, in.pos
+}
+
+func (in *errorInput) Remaining() Input {
+	return nil
+}
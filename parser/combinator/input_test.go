@@ -0,0 +1,376 @@
+package combinator
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
+)
+
+// collect reads all runes from an input along with their positions.
+// If reading the input fails, it returns the runes read so far along with the error.
+func collect(in Input) ([]rune, []int, error) {
+	var runes []rune
+	var positions []int
+
+	for ; in != nil; in = in.Remaining() {
+		if err := check(in); err != nil {
+			return runes, positions, err
+		}
+
+		r, pos := in.Current()
+		runes = append(runes, r)
+		positions = append(positions, pos)
+	}
+
+	return runes, positions, nil
+}
+
+func getTestTokens() []lexer.Token {
+	return []lexer.Token{
+		{Terminal: "id", Lexeme: "x", Pos: lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1}},
+		{Terminal: "=", Lexeme: "=", Pos: lexer.Position{Filename: "test", Offset: 2, Line: 1, Column: 3}},
+		{Terminal: "num", Lexeme: "42", Pos: lexer.Position{Filename: "test", Offset: 4, Line: 1, Column: 5}},
+	}
+}
+
+func TestNewStringInput(t *testing.T) {
+	tests := []struct {
+		name              string
+		s                 string
+		expectedRunes     []rune
+		expectedPositions []int
+	}{
+		{
+			name:              "Empty",
+			s:                 "",
+			expectedRunes:     nil,
+			expectedPositions: nil,
+		},
+		{
+			name:              "ASCII",
+			s:                 "ab",
+			expectedRunes:     []rune{'a', 'b'},
+			expectedPositions: []int{0, 1},
+		},
+		{
+			name:              "Unicode",
+			s:                 "αβ→γ",
+			expectedRunes:     []rune{'α', 'β', '→', 'γ'},
+			expectedPositions: []int{0, 1, 2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := NewStringInput(tc.s)
+			runes, positions, err := collect(in)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRunes, runes)
+			assert.Equal(t, tc.expectedPositions, positions)
+		})
+	}
+}
+
+func TestNewBytesInput(t *testing.T) {
+	tests := []struct {
+		name              string
+		b                 []byte
+		expectedRunes     []rune
+		expectedPositions []int
+	}{
+		{
+			name:              "Empty",
+			b:                 []byte{},
+			expectedRunes:     nil,
+			expectedPositions: nil,
+		},
+		{
+			name:              "ASCII",
+			b:                 []byte("ab"),
+			expectedRunes:     []rune{'a', 'b'},
+			expectedPositions: []int{0, 1},
+		},
+		{
+			name:              "Unicode",
+			b:                 []byte("αβ→γ"),
+			expectedRunes:     []rune{'α', 'β', '→', 'γ'},
+			expectedPositions: []int{0, 1, 2, 3},
+		},
+		{
+			name:              "InvalidUTF8",
+			b:                 []byte{'a', 0xff, 'b'},
+			expectedRunes:     []rune{'a', '�', 'b'},
+			expectedPositions: []int{0, 1, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := NewBytesInput(tc.b)
+			runes, positions, err := collect(in)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRunes, runes)
+			assert.Equal(t, tc.expectedPositions, positions)
+		})
+	}
+}
+
+func TestNewReaderInput(t *testing.T) {
+	tests := []struct {
+		name              string
+		r                 io.Reader
+		expectedError     string
+		expectedRunes     []rune
+		expectedPositions []int
+		expectedEndError  string
+	}{
+		{
+			name:          "FirstReadFails",
+			r:             iotest.ErrReader(errors.New("io error")),
+			expectedError: "io error",
+		},
+		{
+			name:              "Empty",
+			r:                 strings.NewReader(""),
+			expectedRunes:     nil,
+			expectedPositions: nil,
+		},
+		{
+			name:              "OK",
+			r:                 strings.NewReader("αβ→γ"),
+			expectedRunes:     []rune{'α', 'β', '→', 'γ'},
+			expectedPositions: []int{0, 1, 2, 3},
+		},
+		{
+			name:              "OneByteReader",
+			r:                 iotest.OneByteReader(strings.NewReader("αβ→γ")),
+			expectedRunes:     []rune{'α', 'β', '→', 'γ'},
+			expectedPositions: []int{0, 1, 2, 3},
+		},
+		{
+			name:              "SubsequentReadFails",
+			r:                 io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(errors.New("io error"))),
+			expectedRunes:     []rune{'a', 'b'},
+			expectedPositions: []int{0, 1},
+			expectedEndError:  "2: io error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in, err := NewReaderInput(tc.r)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, in)
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			runes, positions, err := collect(in)
+			assert.Equal(t, tc.expectedRunes, runes)
+			assert.Equal(t, tc.expectedPositions, positions)
+
+			if tc.expectedEndError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedEndError)
+			}
+		})
+	}
+}
+
+func TestReaderInput_Backtracking(t *testing.T) {
+	in, err := NewReaderInput(strings.NewReader("abc"))
+	assert.NoError(t, err)
+
+	// Read the input to the end and then read it again from the beginning.
+	runes1, _, _ := collect(in)
+	runes2, _, _ := collect(in)
+
+	assert.Equal(t, []rune("abc"), runes1)
+	assert.Equal(t, []rune("abc"), runes2)
+}
+
+func TestNewTokenInput(t *testing.T) {
+	tokens := getTestTokens()
+
+	tests := []struct {
+		name             string
+		l                lexer.Lexer
+		expectedError    string
+		expectedTokens   []lexer.Token
+		expectedEndError string
+	}{
+		{
+			name: "FirstTokenFails",
+			l: &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutError: errors.New("invalid character")},
+				},
+			},
+			expectedError: "invalid character",
+		},
+		{
+			name: "Empty_EOF",
+			l: &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutError: io.EOF},
+				},
+			},
+			expectedTokens: nil,
+		},
+		{
+			name: "Empty_Endmarker",
+			l: &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutToken: lexer.Token{Terminal: grammar.Endmarker}},
+				},
+			},
+			expectedTokens: nil,
+		},
+		{
+			name: "OK_EOF",
+			l: &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutToken: tokens[0]},
+					{OutToken: tokens[1]},
+					{OutToken: tokens[2]},
+					{OutError: io.EOF},
+				},
+			},
+			expectedTokens: tokens,
+		},
+		{
+			name: "OK_Endmarker",
+			l: &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutToken: tokens[0]},
+					{OutToken: tokens[1]},
+					{OutToken: tokens[2]},
+					{OutToken: lexer.Token{Terminal: grammar.Endmarker}},
+				},
+			},
+			expectedTokens: tokens,
+		},
+		{
+			name: "SubsequentTokenFails",
+			l: &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutToken: tokens[0]},
+					{OutError: errors.New("invalid character")},
+				},
+			},
+			expectedTokens:   tokens[:1],
+			expectedEndError: "1: invalid character",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in, err := NewTokenInput(tc.l)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, in)
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var tokens []lexer.Token
+			for i := 0; in != nil; i, in = i+1, in.Remaining() {
+				if err := check(in); err != nil {
+					assert.EqualError(t, err, tc.expectedEndError)
+					break
+				}
+
+				token, pos := in.(TokenInput).Token()
+				assert.Equal(t, i, pos)
+
+				r, pos := in.Current()
+				assert.Equal(t, []rune(token.Lexeme)[0], r)
+				assert.Equal(t, i, pos)
+
+				tokens = append(tokens, token)
+			}
+
+			assert.Equal(t, tc.expectedTokens, tokens)
+		})
+	}
+}
+
+func TestInput_ReadError(t *testing.T) {
+	ioErr := errors.New("io error")
+	lexErr := errors.New("invalid character")
+	tokens := getTestTokens()
+
+	readerInput := func(r io.Reader) Input {
+		in, err := NewReaderInput(r)
+		assert.NoError(t, err)
+		return in
+	}
+
+	tokenInput := func(l lexer.Lexer) Input {
+		in, err := NewTokenInput(l)
+		assert.NoError(t, err)
+		return in
+	}
+
+	tests := []struct {
+		name          string
+		p             Parser
+		in            Input
+		expectedError string
+		expectedIs    error
+	}{
+		{
+			name:          "ReaderInput_REP1",
+			p:             ExpectRuneInRange('a', 'z').REP1(),
+			in:            readerInput(io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(ioErr))),
+			expectedError: "2: io error",
+			expectedIs:    ioErr,
+		},
+		{
+			name:          "ReaderInput_ALT",
+			p:             CONCAT(ExpectRune('a'), ALT(ExpectRune('b'), E)),
+			in:            readerInput(io.MultiReader(strings.NewReader("a"), iotest.ErrReader(ioErr))),
+			expectedError: "1: io error",
+			expectedIs:    ioErr,
+		},
+		{
+			name: "TokenInput_REP1",
+			p:    ExpectToken("id").ALT(ExpectToken("="), ExpectToken("num")).REP1(),
+			in: tokenInput(&parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutToken: tokens[0]},
+					{OutToken: tokens[1]},
+					{OutError: lexErr},
+				},
+			}),
+			expectedError: "2: invalid character",
+			expectedIs:    lexErr,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := tc.p(tc.in)
+
+			assert.Nil(t, out)
+			assert.EqualError(t, err, tc.expectedError)
+			assert.ErrorIs(t, err, tc.expectedIs)
+		})
+	}
+}
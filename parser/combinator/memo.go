@@ -0,0 +1,278 @@
+package combinator
+
+/*
+ * Packrat parsing memoizes the result of applying each rule at each position of the input.
+ * This guarantees that no rule is evaluated more than once at the same position,
+ * so backtracking through alternations takes linear time instead of exponential time.
+ *
+ * Plain packrat parsing, like any recursive descent parsing, cannot handle left-recursive rules.
+ * A left-recursive rule calls itself at the same position before consuming any input and loops forever.
+ * The memoization table can be used for detecting and supporting left recursion as follows.
+ *
+ *   1. Before evaluating a rule at a position, a failure is memoized for the rule at that position.
+ *      A left-recursive call finds this failure in the table and marks the rule as the head of a left recursion.
+ *   2. The result of the first evaluation, which cannot use the left-recursive alternatives, is the seed.
+ *   3. The seed is memoized and the rule is evaluated again.
+ *      This time, the left-recursive call returns the seed, and the result grows the seed by one step.
+ *   4. Step 3 is repeated as long as the result consumes more input than the previous one.
+ *
+ * For indirect left recursion, all rules involved in the recursion between the head and the left-recursive call
+ * are re-evaluated in each step of growing the seed, instead of using their memoized results.
+ *
+ * For more details, see "Packrat Parsers Can Support Left Recursion" by Alessandro Warth, James R. Douglass, and Todd Millstein.
+ */
+
+// Memo is a packrat memoization table for a set of rules.
+//
+// A memo table is tied to a single input, since the results are memoized by their positions in the input.
+// Call Reset or Parse before parsing a new input with the rules of a memo table.
+type Memo struct {
+	rules   int
+	entries map[memoKey]*memoEntry
+	heads   map[int]*head
+	stack   *leftRecursion
+}
+
+// NewMemo creates a new packrat memoization table.
+func NewMemo() *Memo {
+	m := new(Memo)
+	m.Reset()
+
+	return m
+}
+
+// Reset clears all memoized results, so the rules of the memo table can be applied to a new input.
+func (m *Memo) Reset() {
+	m.entries = map[memoKey]*memoEntry{}
+	m.heads = map[int]*head{}
+	m.stack = nil
+}
+
+// Parse clears all memoized results and then applies parser p to the input.
+func (m *Memo) Parse(p Parser, in Input) (*Output, error) {
+	m.Reset()
+	return p(in)
+}
+
+// Rule creates a memoized parser for a rule.
+// The result of applying the rule at each position of the input is memoized, so the rule is evaluated at most once per position.
+// Rules can be left-recursive, both directly and indirectly.
+//
+// The parser for the rule is created by calling f the first time the rule is applied.
+// This allows rules to refer to themselves or to other rules that are defined later.
+//
+// Example:
+//
+//	// Production rule: expr → expr "-" num | num
+//	var expr Parser
+//	expr = m.Rule(func() Parser {
+//		return expr.CONCAT(ExpectRune('-'), num).ALT(num)
+//	})
+func (m *Memo) Rule(f func() Parser) Parser {
+	m.rules++
+	r := &rule{
+		id: m.rules,
+		f:  f,
+	}
+
+	return func(in Input) (*Output, error) {
+		return m.apply(r, in)
+	}
+}
+
+// rule is a memoized rule.
+type rule struct {
+	id int
+	f  func() Parser
+	p  Parser
+}
+
+// parse evaluates the body of a rule.
+func (r *rule) parse(in Input) (*Output, error) {
+	if r.p == nil {
+		r.p = r.f()
+	}
+
+	return r.p(in)
+}
+
+// memoKey identifies the result of applying a rule at a position.
+type memoKey struct {
+	rule, pos int
+}
+
+// answer is the memoized output of a parser.
+type answer struct {
+	out *Output
+	err error
+}
+
+// result returns a copy of the memoized output, so callers can modify it freely.
+func (a answer) result() (*Output, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	return &Output{
+		Result:    a.out.Result,
+		Remaining: a.out.Remaining,
+	}, nil
+}
+
+// memoEntry is an entry in a memo table.
+// While a rule is being evaluated for the first time at a position, lr is set and ans is not yet available.
+type memoEntry struct {
+	ans answer
+	lr  *leftRecursion
+}
+
+// leftRecursion tracks a rule evaluation that may be left-recursive.
+// Rule evaluations in progress form a stack, linked by the next field.
+type leftRecursion struct {
+	seed answer
+	rule int
+	head *head
+	next *leftRecursion
+}
+
+// head is the rule where a left recursion starts.
+// involved is the set of other rules in the left recursion, and eval is the set of rules to be re-evaluated.
+type head struct {
+	rule     int
+	involved map[int]bool
+	eval     map[int]bool
+}
+
+// apply applies a rule to an input using the memoized results.
+func (m *Memo) apply(r *rule, in Input) (*Output, error) {
+	pos := offset(in)
+
+	e := m.recall(r, in, pos)
+	if e == nil {
+		// Memoize a failure before evaluating the rule for detecting left recursion.
+		lr := &leftRecursion{
+			seed: answer{err: fail(in)},
+			rule: r.id,
+			next: m.stack,
+		}
+
+		m.stack = lr
+		e = &memoEntry{lr: lr}
+		m.entries[memoKey{r.id, pos}] = e
+
+		out, err := r.parse(in)
+		m.stack = m.stack.next
+
+		if lr.head != nil {
+			lr.seed = answer{out, err}
+			return m.answer(r, in, pos, e)
+		}
+
+		e.lr, e.ans = nil, answer{out, err}
+		return e.ans.result()
+	}
+
+	if e.lr != nil {
+		// A left-recursive call.
+		m.setup(r, e.lr)
+		return e.lr.seed.result()
+	}
+
+	return e.ans.result()
+}
+
+// recall returns the memoized result of a rule at a position, taking into account the left recursion growing at that position.
+func (m *Memo) recall(r *rule, in Input, pos int) *memoEntry {
+	e := m.entries[memoKey{r.id, pos}]
+
+	h, ok := m.heads[pos]
+	if !ok {
+		return e
+	}
+
+	// Do not evaluate any rule that is not involved in the left recursion.
+	if e == nil && r.id != h.rule && !h.involved[r.id] {
+		return &memoEntry{
+			ans: answer{err: fail(in)},
+		}
+	}
+
+	// Re-evaluate the rules involved in the left recursion once per growing step.
+	if h.eval[r.id] {
+		delete(h.eval, r.id)
+
+		out, err := r.parse(in)
+		if e == nil {
+			e = new(memoEntry)
+			m.entries[memoKey{r.id, pos}] = e
+		}
+
+		e.lr, e.ans = nil, answer{out, err}
+	}
+
+	return e
+}
+
+// setup marks a rule as the head of a left recursion and the rules on the stack up to the head as involved.
+func (m *Memo) setup(r *rule, lr *leftRecursion) {
+	if lr.head == nil {
+		lr.head = &head{
+			rule:     r.id,
+			involved: map[int]bool{},
+			eval:     map[int]bool{},
+		}
+	}
+
+	for s := m.stack; s != nil && s.head != lr.head; s = s.next {
+		s.head = lr.head
+		lr.head.involved[s.rule] = true
+	}
+}
+
+// answer returns the result of a left-recursive rule after its seed is evaluated.
+func (m *Memo) answer(r *rule, in Input, pos int, e *memoEntry) (*Output, error) {
+	h, seed := e.lr.head, e.lr.seed
+
+	// Only the head of a left recursion grows the seed.
+	if h.rule != r.id {
+		return seed.result()
+	}
+
+	e.lr, e.ans = nil, seed
+	if seed.err != nil {
+		return seed.result()
+	}
+
+	return m.grow(r, in, pos, e, h)
+}
+
+// grow repeatedly evaluates the head of a left recursion as long as the result consumes more input.
+func (m *Memo) grow(r *rule, in Input, pos int, e *memoEntry, h *head) (*Output, error) {
+	m.heads[pos] = h
+
+	for {
+		h.eval = make(map[int]bool, len(h.involved))
+		for id := range h.involved {
+			h.eval[id] = true
+		}
+
+		out, err := r.parse(in)
+		if err != nil {
+			// A fatal error must be propagated instead of being treated as the end of growing.
+			if isFatal(err) {
+				e.ans = answer{err: err}
+			}
+			break
+		}
+
+		if offset(out.Remaining) <= offset(e.ans.out.Remaining) {
+			break
+		}
+
+		e.ans = answer{out, nil}
+	}
+
+	delete(m.heads, pos)
+
+	return e.ans.result()
+}
@@ -0,0 +1,276 @@
+package combinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// num → [0-9]
+var num = ExpectRuneInRange('0', '9').Map(func(r Result) (Result, error) {
+	return Result{int(r.Val.(rune) - '0'), r.Pos, nil}, nil
+})
+
+// binary returns a map function for evaluating a binary operation.
+func binary(op func(int, int) (int, error)) MapFunc {
+	return func(r Result) (Result, error) {
+		r0, _ := r.Get(0)
+		r2, _ := r.Get(2)
+
+		v, err := op(r0.Val.(int), r2.Val.(int))
+		if err != nil {
+			return Result{}, err
+		}
+
+		return Result{v, r0.Pos, nil}, nil
+	}
+}
+
+var (
+	sub = binary(func(a, b int) (int, error) { return a - b, nil })
+	add = binary(func(a, b int) (int, error) { return a + b, nil })
+	mul = binary(func(a, b int) (int, error) { return a * b, nil })
+	div = binary(func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return a / b, nil
+	})
+)
+
+func TestNewMemo(t *testing.T) {
+	m := NewMemo()
+
+	assert.NotNil(t, m)
+	assert.NotNil(t, m.entries)
+	assert.NotNil(t, m.heads)
+	assert.Nil(t, m.stack)
+}
+
+func TestMemo_Reset(t *testing.T) {
+	m := NewMemo()
+	a := m.Rule(func() Parser { return num })
+
+	_, err := a(newStringInput("1"))
+	assert.NoError(t, err)
+	assert.Len(t, m.entries, 1)
+
+	m.Reset()
+	assert.Len(t, m.entries, 0)
+	assert.Len(t, m.heads, 0)
+	assert.Nil(t, m.stack)
+}
+
+func TestMemo_Parse(t *testing.T) {
+	m := NewMemo()
+
+	// expr → expr "-" num | num
+	var expr Parser
+	expr = m.Rule(func() Parser {
+		return expr.CONCAT(ExpectRune('-'), num).Map(sub).ALT(num)
+	})
+
+	tests := []struct {
+		in          Input
+		expectedVal int
+	}{
+		{newStringInput("9-5"), 4},
+		{newStringInput("8-1-2"), 5},
+		{newStringInput("7"), 7},
+	}
+
+	for _, tc := range tests {
+		out, err := m.Parse(expr, tc.in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedVal, out.Result.Val)
+		assert.Nil(t, out.Remaining)
+	}
+}
+
+func TestMemo_Rule(t *testing.T) {
+	tests := []struct {
+		name              string
+		parser            func(*Memo) Parser
+		in                Input
+		expectedVal       any
+		expectedRemaining Input
+		expectedError     string
+	}{
+		{
+			name: "NotLeftRecursive",
+			parser: func(m *Memo) Parser {
+				// list → num "," list | num
+				var list Parser
+				list = m.Rule(func() Parser {
+					return num.CONCAT(ExpectRune(','), list).Map(add).ALT(num)
+				})
+				return list
+			},
+			in:          newStringInput("1,2,3"),
+			expectedVal: 6,
+		},
+		{
+			name: "DirectLeftRecursion",
+			parser: func(m *Memo) Parser {
+				// expr → expr "-" num | num
+				var expr Parser
+				expr = m.Rule(func() Parser {
+					return expr.CONCAT(ExpectRune('-'), num).Map(sub).ALT(num)
+				})
+				return expr
+			},
+			in:          newStringInput("9-5-3"),
+			expectedVal: 1,
+		},
+		{
+			name: "DirectLeftRecursion_PartialInput",
+			parser: func(m *Memo) Parser {
+				// expr → expr "-" num | num
+				var expr Parser
+				expr = m.Rule(func() Parser {
+					return expr.CONCAT(ExpectRune('-'), num).Map(sub).ALT(num)
+				})
+				return expr
+			},
+			in:          newStringInput("9-5-x"),
+			expectedVal: 4,
+			expectedRemaining: &stringInput{
+				pos:   3,
+				runes: []rune("-x"),
+			},
+		},
+		{
+			name: "IndirectLeftRecursion",
+			parser: func(m *Memo) Parser {
+				// x    → expr
+				// expr → x "-" num | num
+				var x, expr Parser
+				x = m.Rule(func() Parser {
+					return expr
+				})
+				expr = m.Rule(func() Parser {
+					return x.CONCAT(ExpectRune('-'), num).Map(sub).ALT(num)
+				})
+				return x
+			},
+			in:          newStringInput("9-5-3"),
+			expectedVal: 1,
+		},
+		{
+			name: "NestedLeftRecursion",
+			parser: func(m *Memo) Parser {
+				// term → term "+" fact | term "-" fact | fact
+				// fact → fact "*" num | num
+				var term, fact Parser
+				term = m.Rule(func() Parser {
+					return ALT(
+						term.CONCAT(ExpectRune('+'), fact).Map(add),
+						term.CONCAT(ExpectRune('-'), fact).Map(sub),
+						fact,
+					)
+				})
+				fact = m.Rule(func() Parser {
+					return fact.CONCAT(ExpectRune('*'), num).Map(mul).ALT(num)
+				})
+				return term
+			},
+			in:          newStringInput("1+2*3-4*2"),
+			expectedVal: -1,
+		},
+		{
+			name: "LeftRecursion_Failure",
+			parser: func(m *Memo) Parser {
+				// expr → expr "-" num | num
+				var expr Parser
+				expr = m.Rule(func() Parser {
+					return expr.CONCAT(ExpectRune('-'), num).Map(sub).ALT(num)
+				})
+				return expr
+			},
+			in:            newStringInput("-1"),
+			expectedError: `0: unexpected rune '-'`,
+		},
+		{
+			name: "LeftRecursion_SemanticError",
+			parser: func(m *Memo) Parser {
+				// expr → expr "/" num | num
+				var expr Parser
+				expr = m.Rule(func() Parser {
+					return expr.CONCAT(ExpectRune('/'), num).Map(div).ALT(num)
+				})
+				return expr
+			},
+			in:            newStringInput("8/2/0"),
+			expectedError: `0: division by zero`,
+		},
+		{
+			name: "EndOfInput",
+			parser: func(m *Memo) Parser {
+				return m.Rule(func() Parser {
+					return num
+				})
+			},
+			in:            nil,
+			expectedError: `end of input`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.parser(NewMemo())
+			out, err := p(tc.in)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedVal, out.Result.Val)
+				assert.Equal(t, tc.expectedRemaining, out.Remaining)
+			} else {
+				assert.Nil(t, out)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestMemo_Rule_Memoization(t *testing.T) {
+	m := NewMemo()
+
+	calls := 0
+	digits := m.Rule(func() Parser {
+		return func(in Input) (*Output, error) {
+			calls++
+			return num.REP1()(in)
+		}
+	})
+
+	// s → digits "+" | digits "-" | digits
+	s := ALT(
+		digits.CONCAT(ExpectRune('+')),
+		digits.CONCAT(ExpectRune('-')),
+		digits,
+	)
+
+	out, err := s(newStringInput("123"))
+
+	assert.NoError(t, err)
+	assert.Nil(t, out.Remaining)
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemo_Rule_ResultIsCopied(t *testing.T) {
+	m := NewMemo()
+	a := m.Rule(func() Parser { return num })
+
+	in := newStringInput("1")
+	out1, err := a.Map(func(r Result) (Result, error) {
+		return Result{r.Val.(int) * 10, r.Pos, nil}, nil
+	})(in)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, out1.Result.Val)
+
+	out2, err := a(in)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, out2.Result.Val)
+}
@@ -0,0 +1,77 @@
+package combinator
+
+import (
+	"sort"
+
+	"github.com/moorara/algo/lexer"
+)
+
+// LineIndex maps positions in a source text to line and column numbers.
+//
+// Positions in parsing results and errors are offsets of runes in the input.
+// LineIndex converts them into positions that are more meaningful to humans.
+type LineIndex struct {
+	filename string
+	starts   []int // The offsets of the first runes of lines.
+}
+
+// NewLineIndex creates a new line index for a source text.
+// The filename is optional and only used for reporting positions.
+func NewLineIndex(filename, src string) *LineIndex {
+	starts := []int{0}
+
+	var i int
+	for _, r := range src {
+		i++
+		if r == '\n' {
+			starts = append(starts, i)
+		}
+	}
+
+	return &LineIndex{
+		filename: filename,
+		starts:   starts,
+	}
+}
+
+// Position returns the line and column position of an offset in the source text.
+// Line and column numbers start from one.
+func (x *LineIndex) Position(pos int) lexer.Position {
+	// Find the last line starting at or before the offset.
+	i := sort.Search(len(x.starts), func(i int) bool {
+		return x.starts[i] > pos
+	}) - 1
+
+	if i < 0 {
+		i = 0
+	}
+
+	return lexer.Position{
+		Filename: x.filename,
+		Offset:   pos,
+		Line:     i + 1,
+		Column:   pos - x.starts[i] + 1,
+	}
+}
+
+// Error converts the position of a parsing error from an offset to a line and column position.
+// Errors without a position (e.g., the end of input) are returned as they are.
+func (x *LineIndex) Error(err error) error {
+	switch e := err.(type) {
+	case *syntaxError:
+		if e.EOF {
+			return err
+		}
+		// The positions of tokens are indices in the token stream, so the position of the token itself is used.
+		if e.Token != nil {
+			return &positionError{Pos: e.Token.Pos, Desc: e.describe(), Err: err}
+		}
+		return &positionError{Pos: x.Position(e.Pos), Desc: e.describe(), Err: err}
+
+	case *semanticError:
+		return &positionError{Pos: x.Position(e.Pos), Desc: e.describe(), Err: err}
+
+	default:
+		return err
+	}
+}
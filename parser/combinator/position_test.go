@@ -0,0 +1,150 @@
+package combinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/lexer"
+)
+
+func TestNewLineIndex(t *testing.T) {
+	tests := []struct {
+		name           string
+		filename       string
+		src            string
+		expectedStarts []int
+	}{
+		{
+			name:           "Empty",
+			filename:       "",
+			src:            "",
+			expectedStarts: []int{0},
+		},
+		{
+			name:           "SingleLine",
+			filename:       "test",
+			src:            "a + b",
+			expectedStarts: []int{0},
+		},
+		{
+			name:           "MultipleLines",
+			filename:       "test",
+			src:            "λ + b\nc\n\nd",
+			expectedStarts: []int{0, 6, 8, 9},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			x := NewLineIndex(tc.filename, tc.src)
+
+			assert.NotNil(t, x)
+			assert.Equal(t, tc.filename, x.filename)
+			assert.Equal(t, tc.expectedStarts, x.starts)
+		})
+	}
+}
+
+func TestLineIndex_Position(t *testing.T) {
+	x := NewLineIndex("test", "λ + b\nc\n\nd")
+
+	tests := []struct {
+		name             string
+		pos              int
+		expectedPosition lexer.Position
+	}{
+		{
+			name:             "FirstRune",
+			pos:              0,
+			expectedPosition: lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1},
+		},
+		{
+			name:             "FirstLine",
+			pos:              4,
+			expectedPosition: lexer.Position{Filename: "test", Offset: 4, Line: 1, Column: 5},
+		},
+		{
+			name:             "Newline",
+			pos:              5,
+			expectedPosition: lexer.Position{Filename: "test", Offset: 5, Line: 1, Column: 6},
+		},
+		{
+			name:             "SecondLine",
+			pos:              6,
+			expectedPosition: lexer.Position{Filename: "test", Offset: 6, Line: 2, Column: 1},
+		},
+		{
+			name:             "EmptyLine",
+			pos:              8,
+			expectedPosition: lexer.Position{Filename: "test", Offset: 8, Line: 3, Column: 1},
+		},
+		{
+			name:             "LastLine",
+			pos:              9,
+			expectedPosition: lexer.Position{Filename: "test", Offset: 9, Line: 4, Column: 1},
+		},
+		{
+			name:             "Negative",
+			pos:              -1,
+			expectedPosition: lexer.Position{Filename: "test", Offset: -1, Line: 1, Column: 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPosition, x.Position(tc.pos))
+		})
+	}
+}
+
+func TestLineIndex_Error(t *testing.T) {
+	x := NewLineIndex("test", "a\nb + c")
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedError string
+	}{
+		{
+			name:          "EOF",
+			err:           errEOF,
+			expectedError: "end of input",
+		},
+		{
+			name:          "SyntaxError_EOF",
+			err:           &syntaxError{EOF: true, Expected: []string{"num"}},
+			expectedError: "end of input, expected num",
+		},
+		{
+			name:          "SyntaxError",
+			err:           &syntaxError{Pos: 4, Rune: '+', Expected: []string{"num", "ident"}},
+			expectedError: "test:2:3: unexpected rune '+', expected one of: num, ident",
+		},
+		{
+			name:          "SyntaxError_Token",
+			err:           &syntaxError{Pos: 1, Token: &lexer.Token{Terminal: "=", Lexeme: "=", Pos: lexer.Position{Filename: "input", Offset: 12, Line: 3, Column: 4}}},
+			expectedError: `input:3:4: unexpected token "=" "="`,
+		},
+		{
+			name:          "SemanticError",
+			err:           &semanticError{Pos: 6, Err: errors.New("undefined variable")},
+			expectedError: "test:2:5: undefined variable",
+		},
+		{
+			name:          "OtherError",
+			err:           errors.New("unknown error"),
+			expectedError: "unknown error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := x.Error(tc.err)
+
+			assert.EqualError(t, err, tc.expectedError)
+			assert.True(t, errors.Is(err, tc.err))
+		})
+	}
+}
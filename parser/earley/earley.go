@@ -0,0 +1,454 @@
+// Package earley provides data structures and algorithms for building Earley parsers.
+// An Earley parser is a chart parser for the class of all context-free grammars.
+//
+// Unlike predictive and LR parsers, an Earley parser does not require the grammar to be in a particular class.
+// It accepts left-recursive, right-recursive, ambiguous, and cyclic grammars as well as grammars with empty productions.
+// It runs in cubic time in the worst case, quadratic time for unambiguous grammars,
+// and linear time for most LR(k) grammars.
+//
+// An Earley parser reads the input from left to right and builds a set of Earley items for every position of the input.
+// An Earley item "A → α•β, j" states that α derives the input from position j up to the current position.
+// Three operations are applied to every set until no new item can be added:
+//
+//   - Prediction: for an item "A → α•Bβ, j" in set k, add "B → •γ, k" to set k for every production of B.
+//   - Scanning: for an item "A → α•aβ, j" in set k, add "A → αa•β, j" to set k+1 if a is the next input token.
+//   - Completion: for an item "B → γ•, i" in set k, add "A → αB•β, j" to set k for every "A → α•Bβ, j" in set i.
+//
+// Right-recursive grammars normally require quadratic time, since every completion is propagated through
+// all the items on the right-recursive chain. Leo's optimization memoizes the topmost item of such
+// deterministic chains, so that only one item is added per completion, and right recursion takes linear time.
+//
+// For more details on parsing theory, refer to
+// "An Efficient Context-Free Parsing Algorithm" by Jay Earley,
+// "A General Context-Free Parsing Algorithm Running in Linear Time on Every LR(k) Grammar" by Joop M.I.M. Leo, and
+// "Parsing Techniques: A Practical Guide (2nd Edition)" by Dick Grune and Ceriel J.H. Jacobs.
+package earley
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+)
+
+// earleyParser is an Earley parser for all context-free grammars.
+// It implements the parser.Parser interface.
+type earleyParser struct {
+	G     *grammar.CFG
+	lexer lexer.Lexer
+}
+
+// New creates a new Earley parser for a given context-free grammar (CFG).
+// It requires a lexer for lexical analysis, which reads the input tokens (terminal symbols).
+func New(G *grammar.CFG, lexer lexer.Lexer) parser.Parser {
+	return &earleyParser{
+		G:     G,
+		lexer: lexer,
+	}
+}
+
+// nextToken wraps the Lexer.NextToken method and ensures
+// an Endmarker token is returned when the end of input is reached.
+func (p *earleyParser) nextToken() (lexer.Token, error) {
+	token, err := p.lexer.NextToken()
+	if err != nil && errors.Is(err, io.EOF) {
+		token.Terminal, token.Lexeme = grammar.Endmarker, ""
+		return token, nil
+	}
+
+	return token, err
+}
+
+/*
+ * INPUT:  • A lexer for reading input string w = a₁a₂...aₙ.
+ *         • A context-free grammar G with start symbol S.
+ * OUTPUT: • If w ∈ L(G), a derivation of w; otherwise, an error indication.
+ *
+ * METHOD: Initially, add "S → •α, 0" to S₀ for every production of S.
+ *
+ *         for k = 0 to n {
+ *           for every item in Sₖ (including the ones added while processing Sₖ) {
+ *             if the item is "A → α•Bβ, j" {
+ *               add "B → •γ, k" to Sₖ for every production of B;   (prediction)
+ *               if B has been completed with origin k, add "A → αB•β, j" to Sₖ;
+ *             } else if the item is "A → α•aβ, j" and a = aₖ₊₁ {
+ *               add "A → αa•β, j" to Sₖ₊₁;                         (scanning)
+ *             } else if the item is "B → γ•, i" {
+ *               if Sᵢ has a Leo item for B with topmost item "C → δ•, m" {
+ *                 add "C → δ•, m" to Sₖ;                           (Leo's optimization)
+ *               } else {
+ *                 add "A → αB•β, j" to Sₖ for every "A → α•Bβ, j" in Sᵢ;   (completion)
+ *               }
+ *             }
+ *           }
+ *         }
+ *
+ *         if "S → α•, 0" is in Sₙ, w ∈ L(G).
+ *
+ * The set Sᵢ has a Leo item for B if it contains exactly one item with the dot before B,
+ * and that item has the form "A → α•B, j" (i.e., B is the last symbol of the production).
+ * The topmost item is the topmost item of the Leo item for A in Sⱼ, if it exists, or "A → αB•, j" otherwise.
+ */
+
+// itemKey uniquely identifies an Earley item in an Earley set.
+type itemKey struct {
+	prod   *grammar.Production
+	dot    int
+	origin int
+}
+
+// item is an Earley item "A → α•β, j".
+// Along with the item, the first derivation found for it is recorded for building the parse tree.
+type item struct {
+	itemKey
+
+	prev  *item       // The item with the dot one symbol to the left.
+	child *item       // The completed item for the non-terminal before the dot (nil for a terminal).
+	token lexer.Token // The scanned token for the terminal before the dot.
+	leo   *leoItem    // The Leo item used for completing the item, if any.
+}
+
+// String returns a string representation of an Earley item.
+func (i *item) String() string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "%s → ", i.prod.Head)
+
+	if α := i.prod.Body[:i.dot]; len(α) > 0 {
+		b.WriteString(α.String())
+	}
+
+	b.WriteRune('•')
+
+	if β := i.prod.Body[i.dot:]; len(β) > 0 {
+		b.WriteString(β.String())
+	}
+
+	fmt.Fprintf(&b, ", %d", i.origin)
+
+	return b.String()
+}
+
+// dotSymbol returns the symbol after the dot, if any.
+func (i *item) dotSymbol() (grammar.Symbol, bool) {
+	if i.dot < len(i.prod.Body) {
+		return i.prod.Body[i.dot], true
+	}
+
+	return nil, false
+}
+
+// advance returns a new item with the dot moved one symbol to the right.
+func (i *item) advance() *item {
+	return &item{
+		itemKey: itemKey{i.prod, i.dot + 1, i.origin},
+		prev:    i,
+	}
+}
+
+// leoItem is a Leo item for a non-terminal B in an Earley set.
+// It records the only item with the dot before B in the set, which has the form "A → α•B, j",
+// and the Leo item for A in set j, if any.
+type leoItem struct {
+	penult *item
+	next   *leoItem
+}
+
+// top returns the topmost item of the deterministic chain starting at the Leo item.
+func (l *leoItem) top() itemKey {
+	for l.next != nil {
+		l = l.next
+	}
+
+	return itemKey{l.penult.prod, l.penult.dot + 1, l.penult.origin}
+}
+
+// earleySet is the set of Earley items for a position in the input.
+type earleySet struct {
+	items   []*item
+	index   map[itemKey]*item
+	waiting map[grammar.NonTerminal][]*item // Items with the dot before a non-terminal.
+	scans   []*item                         // Items with the dot before a terminal.
+	nulls   map[grammar.NonTerminal]*item   // Completed items with the origin at this set.
+	leos    map[grammar.NonTerminal]*leoItem
+}
+
+func newEarleySet() *earleySet {
+	return &earleySet{
+		index:   map[itemKey]*item{},
+		waiting: map[grammar.NonTerminal][]*item{},
+		nulls:   map[grammar.NonTerminal]*item{},
+		leos:    map[grammar.NonTerminal]*leoItem{},
+	}
+}
+
+// add adds an item to the set unless the set already contains it.
+func (s *earleySet) add(i *item) {
+	if _, ok := s.index[i.itemKey]; !ok {
+		s.index[i.itemKey] = i
+		s.items = append(s.items, i)
+	}
+}
+
+// recognize implements the Earley recognizer.
+// It returns the Earley sets and the completed item for the start symbol that spans the entire input.
+func (p *earleyParser) recognize() ([]*earleySet, *item, error) {
+	prods := map[grammar.NonTerminal][]*grammar.Production{}
+	for A := range p.G.NonTerminals.All() {
+		prods[A] = grammar.OrderProductionSet(p.G.Productions.Get(A))
+	}
+
+	sets := []*earleySet{newEarleySet()}
+	for _, prod := range prods[p.G.Start] {
+		sets[0].add(&item{itemKey: itemKey{prod, 0, 0}})
+	}
+
+	// leo returns the Leo item for non-terminal B in set i, if any.
+	// It must only be called for sets that have been processed completely.
+	var leo func(int, grammar.NonTerminal) *leoItem
+	leo = func(i int, B grammar.NonTerminal) *leoItem {
+		S := sets[i]
+		if l, ok := S.leos[B]; ok {
+			return l
+		}
+
+		var l *leoItem
+		if waiting := S.waiting[B]; len(waiting) == 1 && waiting[0].dot+1 == len(waiting[0].prod.Body) {
+			l = &leoItem{penult: waiting[0]}
+			// Items predicted in the same set end the chain, which ensures that the recursion terminates.
+			if A, j := waiting[0].prod.Head, waiting[0].origin; j < i {
+				l.next = leo(j, A)
+			}
+		}
+
+		S.leos[B] = l
+
+		return l
+	}
+
+	// Read the first input token.
+	token, err := p.nextToken()
+	if err != nil {
+		return nil, nil, &parser.ParseError{Cause: err}
+	}
+
+	for k := 0; ; k++ {
+		S := sets[k]
+
+		for n := 0; n < len(S.items); n++ {
+			it := S.items[n]
+
+			if X, ok := it.dotSymbol(); ok {
+				B, ok := X.(grammar.NonTerminal)
+				if !ok {
+					S.scans = append(S.scans, it)
+					continue
+				}
+
+				// Prediction
+				S.waiting[B] = append(S.waiting[B], it)
+				for _, prod := range prods[B] {
+					S.add(&item{itemKey: itemKey{prod, 0, k}})
+				}
+
+				// B has already been completed with an empty derivation.
+				if c, ok := S.nulls[B]; ok {
+					next := it.advance()
+					next.child = c
+					S.add(next)
+				}
+
+				continue
+			}
+
+			// Completion
+			B, i := it.prod.Head, it.origin
+
+			if i == k {
+				if _, ok := S.nulls[B]; !ok {
+					S.nulls[B] = it
+				}
+			} else if l := leo(i, B); l != nil {
+				S.add(&item{itemKey: l.top(), child: it, leo: l})
+				continue
+			}
+
+			for _, w := range sets[i].waiting[B] {
+				next := w.advance()
+				next.child = it
+				S.add(next)
+			}
+		}
+
+		if token.Terminal == grammar.Endmarker {
+			for _, it := range S.items {
+				if it.prod.Head == p.G.Start && it.dot == len(it.prod.Body) && it.origin == 0 {
+					return sets, it, nil
+				}
+			}
+
+			return nil, nil, p.syntaxError(S, token)
+		}
+
+		// Scanning
+		next := newEarleySet()
+		for _, it := range S.scans {
+			if a, _ := it.dotSymbol(); a.Equal(token.Terminal) {
+				scanned := it.advance()
+				scanned.token = token
+				next.add(scanned)
+			}
+		}
+
+		if len(next.items) == 0 {
+			return nil, nil, p.syntaxError(S, token)
+		}
+
+		sets = append(sets, next)
+
+		// Read the next input token.
+		if token, err = p.nextToken(); err != nil {
+			return nil, nil, &parser.ParseError{Cause: err}
+		}
+	}
+}
+
+// syntaxError creates an error for an unexpected token, listing the terminals expected in the Earley set.
+func (p *earleyParser) syntaxError(S *earleySet, token lexer.Token) error {
+	seen := map[string]bool{}
+	expected := []string{}
+
+	for _, it := range S.scans {
+		if a, _ := it.dotSymbol(); !seen[a.String()] {
+			seen[a.String()] = true
+			expected = append(expected, a.String())
+		}
+	}
+
+	err := &parser.ParseError{
+		Description: fmt.Sprintf("unexpected string %q", token.Lexeme),
+		Pos:         token.Pos,
+	}
+
+	if len(expected) > 0 {
+		sort.Strings(expected)
+		err.Cause = fmt.Errorf("expecting %s", strings.Join(expected, ", "))
+	}
+
+	return err
+}
+
+// build constructs the parse tree for a completed item.
+func build(it *item) parser.Node {
+	if it.leo == nil {
+		return &parser.InternalNode{
+			NonTerminal: it.prod.Head,
+			Production:  it.prod,
+			Children:    children(it),
+		}
+	}
+
+	// Reconstruct the deterministic chain of completions skipped by Leo's optimization.
+	node := build(it.child)
+	for l := it.leo; l != nil; l = l.next {
+		node = &parser.InternalNode{
+			NonTerminal: l.penult.prod.Head,
+			Production:  l.penult.prod,
+			Children:    append(children(l.penult), node),
+		}
+	}
+
+	return node
+}
+
+// children constructs the parse trees for the symbols before the dot of an item.
+func children(it *item) []parser.Node {
+	if it.dot == 0 {
+		return nil
+	}
+
+	nodes := make([]parser.Node, it.dot)
+	for ; it.dot > 0; it = it.prev {
+		if it.child != nil {
+			nodes[it.dot-1] = build(it.child)
+		} else {
+			nodes[it.dot-1] = &parser.LeafNode{
+				Terminal: it.token.Terminal,
+				Lexeme:   it.token.Lexeme,
+				Position: it.token.Pos,
+			}
+		}
+	}
+
+	return nodes
+}
+
+// Parse implements the Earley parsing algorithm.
+// It analyzes a sequence of input tokens (terminal symbols) provided by a lexical analyzer.
+// It attempts to parse the input according to the production rules of a context-free grammar,
+// determining whether the input string belongs to the language defined by the grammar.
+//
+// The Parse method invokes the provided functions each time a token or a production rule is matched.
+// This allows the caller to process or react to each step of the parsing process.
+// Similar to LR parsers, tokens and productions are reported in the order of a rightmost derivation in reverse.
+// Since the input must be recognized entirely first, the functions are only invoked if the input is valid.
+//
+// If the grammar is ambiguous, the first derivation found for every item is used.
+//
+// An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue,
+// or if any of the provided functions return an error, indicating a semantic issue.
+func (p *earleyParser) Parse(tokenF parser.TokenFunc, prodF parser.ProductionFunc) error {
+	_, root, err := p.recognize()
+	if err != nil {
+		return err
+	}
+
+	parser.Traverse(build(root), generic.LRV, func(n parser.Node) bool {
+		switch n := n.(type) {
+		case *parser.LeafNode:
+			if tokenF != nil {
+				token := lexer.Token{Terminal: n.Terminal, Lexeme: n.Lexeme, Pos: n.Position}
+				if e := tokenF(&token); e != nil {
+					err = &parser.ParseError{Cause: e, Pos: n.Position}
+				}
+			}
+
+		case *parser.InternalNode:
+			if prodF != nil {
+				if e := prodF(n.Production); e != nil {
+					err = &parser.ParseError{Cause: e}
+				}
+			}
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
+// ParseAndBuildAST implements the Earley parsing algorithm.
+// It analyzes a sequence of input tokens (terminal symbols) provided by a lexical analyzer.
+// It attempts to parse the input according to the production rules of a context-free grammar,
+// constructing an abstract syntax tree (AST) that reflects the structure of the input.
+//
+// If the input string is valid, the root node of the AST is returned,
+// representing the syntactic structure of the input string.
+// If the grammar is ambiguous, the first derivation found for every item is used.
+//
+// An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue.
+func (p *earleyParser) ParseAndBuildAST() (parser.Node, error) {
+	_, root, err := p.recognize()
+	if err != nil {
+		return nil, err
+	}
+
+	return build(root), nil
+}
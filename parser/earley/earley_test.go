@@ -0,0 +1,431 @@
+package earley
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+)
+
+// mockTokens creates a mock lexer that returns a token for each lexeme on a single line.
+// Lexemes made of lowercase letters are returned as "id" tokens, unless they are listed as keywords.
+func mockTokens(keywords []string, lexemes ...string) *parsertest.MockLexer {
+	L := new(parsertest.MockLexer)
+	col := 1
+
+	for _, lexeme := range lexemes {
+		terminal := grammar.Terminal(lexeme)
+		if lexeme >= "a" && lexeme <= "z" {
+			terminal = "id"
+			for _, kw := range keywords {
+				if lexeme == kw {
+					terminal = grammar.Terminal(kw)
+				}
+			}
+		}
+
+		L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{
+			OutToken: lexer.Token{
+				Terminal: terminal,
+				Lexeme:   lexeme,
+				Pos:      lexer.Position{Filename: "test", Offset: col - 1, Line: 1, Column: col},
+			},
+		})
+
+		col += len(lexeme) + 1
+	}
+
+	L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{OutError: io.EOF})
+
+	return L
+}
+
+// bracket returns a compact bracketed representation of a parse tree.
+func bracket(n parser.Node) string {
+	switch n := n.(type) {
+	case *parser.LeafNode:
+		return n.Lexeme
+	case *parser.InternalNode:
+		s := make([]string, 0, len(n.Children)+1)
+		s = append(s, n.NonTerminal.Name())
+		for _, child := range n.Children {
+			s = append(s, bracket(child))
+		}
+		return "(" + strings.Join(s, " ") + ")"
+	default:
+		return ""
+	}
+}
+
+var grammars = []*grammar.CFG{
+	// S → a S | ε
+	grammar.NewCFG(
+		[]grammar.Terminal{"a"},
+		[]grammar.NonTerminal{"S"},
+		[]*grammar.Production{
+			{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("a"), grammar.NonTerminal("S")}},
+			{Head: "S", Body: grammar.E},
+		},
+		"S",
+	),
+	// S → A A x
+	// A → B
+	// B → ε
+	grammar.NewCFG(
+		[]grammar.Terminal{"x"},
+		[]grammar.NonTerminal{"S", "A", "B"},
+		[]*grammar.Production{
+			{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("A"), grammar.NonTerminal("A"), grammar.Terminal("x")}},
+			{Head: "A", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("B")}},
+			{Head: "B", Body: grammar.E},
+		},
+		"S",
+	),
+	// S → S | id
+	grammar.NewCFG(
+		[]grammar.Terminal{"id"},
+		[]grammar.NonTerminal{"S"},
+		[]*grammar.Production{
+			{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("S")}},
+			{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}},
+		},
+		"S",
+	),
+	// L → id = L | id ; L | id
+	grammar.NewCFG(
+		[]grammar.Terminal{"=", ";", "id"},
+		[]grammar.NonTerminal{"L"},
+		[]*grammar.Production{
+			{Head: "L", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal("="), grammar.NonTerminal("L")}},
+			{Head: "L", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal(";"), grammar.NonTerminal("L")}},
+			{Head: "L", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}},
+		},
+		"L",
+	),
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name  string
+		G     *grammar.CFG
+		lexer lexer.Lexer
+	}{
+		{
+			name:  "OK",
+			G:     parsertest.Grammars[4],
+			lexer: new(parsertest.MockLexer),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(tc.G, tc.lexer)
+			assert.NotNil(t, p)
+		})
+	}
+}
+
+func TestItem_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		i              *item
+		expectedString string
+	}{
+		{
+			name:           "Initial",
+			i:              &item{itemKey: itemKey{parsertest.Prods[4][1], 0, 0}},
+			expectedString: `E → •E "+" E, 0`,
+		},
+		{
+			name:           "Middle",
+			i:              &item{itemKey: itemKey{parsertest.Prods[4][1], 2, 1}},
+			expectedString: `E → E "+"•E, 1`,
+		},
+		{
+			name:           "Complete",
+			i:              &item{itemKey: itemKey{parsertest.Prods[4][1], 3, 2}},
+			expectedString: `E → E "+" E•, 2`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.i.String())
+		})
+	}
+}
+
+func TestEarleyParser_Parse(t *testing.T) {
+	tests := []struct {
+		name                 string
+		p                    *earleyParser
+		tokenF               parser.TokenFunc
+		prodF                parser.ProductionFunc
+		expectedTokens       []string
+		expectedProductions  []string
+		expectedErrorStrings []string
+	}{
+		{
+			name: "Success",
+			p: &earleyParser{
+				G:     parsertest.Grammars[3],
+				lexer: mockTokens(nil, "a", "+", "b"),
+			},
+			expectedTokens: []string{"a", "+", "b"},
+			expectedProductions: []string{
+				`F → "id"`,
+				`T → F`,
+				`E → T`,
+				`F → "id"`,
+				`T → F`,
+				`E → E "+" T`,
+			},
+		},
+		{
+			name: "First_NextToken_Fails",
+			p: &earleyParser{
+				G: parsertest.Grammars[3],
+				lexer: &parsertest.MockLexer{
+					NextTokenMocks: []parsertest.NextTokenMock{
+						{OutError: errors.New("cannot read rune")},
+					},
+				},
+			},
+			expectedErrorStrings: []string{
+				`cannot read rune`,
+			},
+		},
+		{
+			name: "Second_NextToken_Fails",
+			p: &earleyParser{
+				G: parsertest.Grammars[3],
+				lexer: &parsertest.MockLexer{
+					NextTokenMocks: []parsertest.NextTokenMock{
+						{OutToken: lexer.Token{Terminal: "id", Lexeme: "a"}},
+						{OutError: errors.New("input failed")},
+					},
+				},
+			},
+			expectedErrorStrings: []string{
+				`input failed`,
+			},
+		},
+		{
+			name: "Invalid_Input",
+			p: &earleyParser{
+				G:     parsertest.Grammars[3],
+				lexer: mockTokens(nil, "a", "+", ")"),
+			},
+			expectedErrorStrings: []string{
+				`test:1:5: unexpected string ")": expecting "(", "id"`,
+			},
+		},
+		{
+			name: "Unexpected_EOF",
+			p: &earleyParser{
+				G:     parsertest.Grammars[3],
+				lexer: mockTokens(nil, "(", "a"),
+			},
+			expectedErrorStrings: []string{
+				`unexpected string "": expecting ")", "*", "+"`,
+			},
+		},
+		{
+			name: "TokenFunc_Fails",
+			p: &earleyParser{
+				G:     parsertest.Grammars[3],
+				lexer: mockTokens(nil, "a", "+", "b"),
+			},
+			tokenF: func(*lexer.Token) error {
+				return errors.New("invalid token")
+			},
+			expectedErrorStrings: []string{
+				`test:1:1: invalid token`,
+			},
+		},
+		{
+			name: "ProductionFunc_Fails",
+			p: &earleyParser{
+				G:     parsertest.Grammars[3],
+				lexer: mockTokens(nil, "a", "+", "b"),
+			},
+			prodF: func(*grammar.Production) error {
+				return errors.New("invalid semantic")
+			},
+			expectedErrorStrings: []string{
+				`invalid semantic`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var tokens, prods []string
+
+			tokenF, prodF := tc.tokenF, tc.prodF
+			if tokenF == nil {
+				tokenF = func(token *lexer.Token) error {
+					tokens = append(tokens, token.Lexeme)
+					return nil
+				}
+			}
+			if prodF == nil {
+				prodF = func(prod *grammar.Production) error {
+					prods = append(prods, prod.String())
+					return nil
+				}
+			}
+
+			err := tc.p.Parse(tokenF, prodF)
+
+			if len(tc.expectedErrorStrings) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedTokens, tokens)
+				assert.Equal(t, tc.expectedProductions, prods)
+			} else {
+				assert.Error(t, err)
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			}
+		})
+	}
+}
+
+func TestEarleyParser_ParseAndBuildAST(t *testing.T) {
+	tests := []struct {
+		name                 string
+		p                    *earleyParser
+		expectedAST          string
+		expectedErrorStrings []string
+	}{
+		{
+			name: "LL(1)",
+			p: &earleyParser{
+				G:     parsertest.Grammars[0],
+				lexer: mockTokens(nil, "a", "*", "b"),
+			},
+			expectedAST: "(E (T (F a) (T′ * (F b) (T′))) (E′))",
+		},
+		{
+			name: "LeftRecursive",
+			p: &earleyParser{
+				G:     parsertest.Grammars[3],
+				lexer: mockTokens(nil, "a", "+", "b", "*", "(", "c", ")"),
+			},
+			expectedAST: "(E (E (T (F a))) + (T (T (F b)) * (F ( (E (T (F c))) ))))",
+		},
+		{
+			name: "Ambiguous",
+			p: &earleyParser{
+				G:     parsertest.Grammars[4],
+				lexer: mockTokens(nil, "a", "+", "b", "*", "c"),
+			},
+			expectedAST: "(E (E (E a) + (E b)) * (E c))",
+		},
+		{
+			name: "RightRecursive",
+			p: &earleyParser{
+				G:     grammars[0],
+				lexer: mockTokens([]string{"a"}, "a", "a", "a"),
+			},
+			expectedAST: "(S a (S a (S a (S))))",
+		},
+		{
+			name: "EmptyString",
+			p: &earleyParser{
+				G:     grammars[0],
+				lexer: mockTokens(nil),
+			},
+			expectedAST: "(S)",
+		},
+		{
+			name: "Nullable",
+			p: &earleyParser{
+				G:     grammars[1],
+				lexer: mockTokens([]string{"x"}, "x"),
+			},
+			expectedAST: "(S (A (B)) (A (B)) x)",
+		},
+		{
+			name: "Cyclic",
+			p: &earleyParser{
+				G:     grammars[2],
+				lexer: mockTokens(nil, "a"),
+			},
+			expectedAST: "(S a)",
+		},
+		{
+			name: "LeoChain",
+			p: &earleyParser{
+				G:     grammars[3],
+				lexer: mockTokens(nil, "a", "=", "b", ";", "c", "=", "d"),
+			},
+			expectedAST: "(L a = (L b ; (L c = (L d))))",
+		},
+		{
+			name: "Invalid_Input",
+			p: &earleyParser{
+				G:     parsertest.Grammars[4],
+				lexer: mockTokens(nil, "a", "+", "+"),
+			},
+			expectedErrorStrings: []string{
+				`test:1:5: unexpected string "+": expecting "(", "id"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ast, err := tc.p.ParseAndBuildAST()
+
+			if len(tc.expectedErrorStrings) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAST, bracket(ast))
+			} else {
+				assert.Nil(t, ast)
+				assert.Error(t, err)
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			}
+		})
+	}
+}
+
+func TestEarleyParser_LeoOptimization(t *testing.T) {
+	// countItems returns the largest number of items in an Earley set for a right-recursive input of length n.
+	countItems := func(n int) int {
+		lexemes := make([]string, n)
+		for i := range lexemes {
+			lexemes[i] = "a"
+		}
+
+		p := &earleyParser{
+			G:     grammars[0],
+			lexer: mockTokens([]string{"a"}, lexemes...),
+		}
+
+		sets, _, err := p.recognize()
+		assert.NoError(t, err)
+
+		largest := 0
+		for _, S := range sets {
+			largest = max(largest, len(S.items))
+		}
+
+		return largest
+	}
+
+	// Without Leo's optimization, the size of the Earley sets grows linearly with the input length.
+	assert.Equal(t, countItems(10), countItems(100))
+}
@@ -41,6 +41,16 @@ Cause:      Multiple conflicts in the parsing table:
               6. Shift/Reduce conflict in ACTION[4, "+"]
               7. Shift/Reduce conflict in ACTION[5, "*"]
               8. Shift/Reduce conflict in ACTION[5, "+"]
+Examples:   The parser reaches each conflict with these inputs:
+              1. "(" "id" "*" "id"•"*" "id" ")"
+              2. "(" "id" "*" "id"•"+" "id" ")"
+              3. "id" "*" "id"•"*" "id"
+              4. "id" "*" "id"•"+" "id"
+              5. "(" "id" "+" "id"•"*" "id" ")"
+              6. "(" "id" "+" "id"•"+" "id" ")"
+              7. "id" "+" "id"•"*" "id"
+              8. "id" "+" "id"•"+" "id"
+            A single input means it reaches the conflict with every action, so the grammar is likely ambiguous.
 Resolution: Specify associativity and precedence for these Terminals/Productions:
               • "*" vs. "*", "+"
               • "+" vs. "*", "+"
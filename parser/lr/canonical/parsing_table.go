@@ -84,7 +84,7 @@ func BuildParsingTable(G *grammar.CFG, precedences lr.PrecedenceLevels) (*lr.Par
 
 	// Try resolving any conflicts in the ACTION parsing table.
 	if err := table.ResolveConflicts(); err != nil {
-		return table, err
+		return table, lr.AddCounterexamples(G1.CFG, table, err)
 	}
 
 	return table, nil
@@ -0,0 +1,155 @@
+package lr
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/moorara/algo/grammar"
+)
+
+// GenerateGo generates a Go source file containing the static ACTION and GOTO tables
+// and a constructor that creates an LR parser using them.
+//
+// The generated file belongs to the package pkg.
+// All generated identifiers are prefixed with name, and the constructor is named New<Name>Parser.
+// This allows the parsing table to be built once (e.g., using go generate) instead of every time a program starts.
+//
+//	//go:generate go run ./gen
+//
+//	T, _ := lookahead.BuildParsingTable(G, precedences)
+//	_ = T.GenerateGo(f, "expr", "expr")
+//
+//	p := expr.NewExprParser(L)
+//	root, err := p.ParseAndBuildAST()
+func (t *ParsingTable) GenerateGo(w io.Writer, pkg, name string) error {
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("invalid package name %q", pkg)
+	}
+
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("invalid name %q", name)
+	}
+
+	d, err := t.data()
+	if err != nil {
+		return err
+	}
+
+	r, size := utf8.DecodeRuneInString(name)
+	exported := string(unicode.ToUpper(r)) + name[size:]
+
+	var b bytes.Buffer
+
+	fmt.Fprintln(&b, "// Code generated by lr.ParsingTable.GenerateGo. DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintln(&b, "import (")
+	fmt.Fprintln(&b, "\t\"github.com/moorara/algo/grammar\"")
+	fmt.Fprintln(&b, "\t\"github.com/moorara/algo/lexer\"")
+	fmt.Fprintln(&b, "\t\"github.com/moorara/algo/parser/lr\"")
+	fmt.Fprintln(&b, ")")
+	fmt.Fprintln(&b)
+
+	fmt.Fprintf(&b, "var %sStates = []lr.State{", name)
+	for i, s := range d.States {
+		if i > 0 {
+			fmt.Fprint(&b, ", ")
+		}
+		fmt.Fprintf(&b, "%d", s)
+	}
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "var %sTerminals = []grammar.Terminal{", name)
+	for i, a := range t.Terminals {
+		if i > 0 {
+			fmt.Fprint(&b, ", ")
+		}
+		if a == grammar.Endmarker {
+			fmt.Fprint(&b, "grammar.Endmarker")
+		} else {
+			fmt.Fprint(&b, strconv.Quote(string(a)))
+		}
+	}
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "var %sNonTerminals = []grammar.NonTerminal{", name)
+	for i, A := range t.NonTerminals {
+		if i > 0 {
+			fmt.Fprint(&b, ", ")
+		}
+		fmt.Fprint(&b, strconv.Quote(string(A)))
+	}
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "var %sProductions = []*grammar.Production{\n", name)
+	for _, pd := range d.Productions {
+		fmt.Fprintf(&b, "\t{Head: %s, Body: ", strconv.Quote(d.NonTerminals[pd.Head]))
+		if len(pd.Body) == 0 {
+			fmt.Fprint(&b, "grammar.E")
+		} else {
+			fmt.Fprint(&b, "grammar.String[grammar.Symbol]{")
+			for i, k := range pd.Body {
+				if i > 0 {
+					fmt.Fprint(&b, ", ")
+				}
+				if k < len(d.Terminals) {
+					fmt.Fprintf(&b, "grammar.Terminal(%s)", strconv.Quote(d.Terminals[k]))
+				} else {
+					fmt.Fprintf(&b, "grammar.NonTerminal(%s)", strconv.Quote(d.NonTerminals[k-len(d.Terminals)]))
+				}
+			}
+			fmt.Fprint(&b, "}")
+		}
+		fmt.Fprintln(&b, "},")
+	}
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprintln(&b, "// {state, terminal, action type, next state or production}")
+	fmt.Fprintf(&b, "var %sActions = [][4]int{\n", name)
+	for _, a := range d.Actions {
+		fmt.Fprintf(&b, "\t{%d, %d, %d, %d},\n", a[0], a[1], a[2], a[3])
+	}
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprintln(&b, "// {state, non-terminal, next state}")
+	fmt.Fprintf(&b, "var %sGotos = [][3]int{\n", name)
+	for _, g := range d.Gotos {
+		fmt.Fprintf(&b, "\t{%d, %d, %d},\n", g[0], g[1], g[2])
+	}
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "// New%sParser creates a new LR parser using the static parsing table.\n", exported)
+	fmt.Fprintln(&b, "// It requires a lexer for lexical analysis, which reads the input tokens (terminal symbols).")
+	fmt.Fprintf(&b, "func New%sParser(L lexer.Lexer) *lr.Parser {\n", exported)
+	fmt.Fprintf(&b, "\tT := lr.NewParsingTable(%sStates, %sTerminals, %sNonTerminals, lr.PrecedenceLevels{})\n\n", name, name, name)
+	fmt.Fprintf(&b, "\tfor _, a := range %sActions {\n", name)
+	fmt.Fprintln(&b, "\t\taction := &lr.Action{Type: lr.ActionType(a[2])}")
+	fmt.Fprintln(&b, "\t\tswitch action.Type {")
+	fmt.Fprintln(&b, "\t\tcase lr.SHIFT:")
+	fmt.Fprintln(&b, "\t\t\taction.State = lr.State(a[3])")
+	fmt.Fprintln(&b, "\t\tcase lr.REDUCE:")
+	fmt.Fprintf(&b, "\t\t\taction.Production = %sProductions[a[3]]\n", name)
+	fmt.Fprintln(&b, "\t\t}")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "\t\tT.AddACTION(lr.State(a[0]), %sTerminals[a[1]], action)\n", name)
+	fmt.Fprint(&b, "\t}\n\n")
+	fmt.Fprintf(&b, "\tfor _, g := range %sGotos {\n", name)
+	fmt.Fprintf(&b, "\t\tT.SetGOTO(lr.State(g[0]), %sNonTerminals[g[1]], lr.State(g[2]))\n", name)
+	fmt.Fprint(&b, "\t}\n\n")
+	fmt.Fprintln(&b, "\treturn &lr.Parser{L: L, T: T}")
+	fmt.Fprintln(&b, "}")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+	return err
+}
@@ -0,0 +1,132 @@
+package lr
+
+import (
+	"bytes"
+	"go/format"
+	goparser "go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+)
+
+func TestParsingTable_GenerateGo(t *testing.T) {
+	T := NewParsingTable([]State{0, 1, 2}, []grammar.Terminal{"a", grammar.Endmarker}, []grammar.NonTerminal{"S"}, PrecedenceLevels{})
+	T.AddACTION(0, "a", &Action{Type: SHIFT, State: 2})
+	T.AddACTION(0, grammar.Endmarker, &Action{Type: REDUCE, Production: &grammar.Production{Head: "S", Body: grammar.E}})
+	T.AddACTION(1, grammar.Endmarker, &Action{Type: ACCEPT})
+	T.AddACTION(2, grammar.Endmarker, &Action{Type: REDUCE, Production: &grammar.Production{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("a")}}})
+	T.SetGOTO(0, "S", 1)
+
+	tests := []struct {
+		name           string
+		T              *ParsingTable
+		pkg            string
+		prefix         string
+		expectedSource string
+		expectedError  string
+	}{
+		{
+			name:          "InvalidPackage",
+			T:             T,
+			pkg:           "foo-bar",
+			prefix:        "foo",
+			expectedError: `invalid package name "foo-bar"`,
+		},
+		{
+			name:          "InvalidName",
+			T:             T,
+			pkg:           "foo",
+			prefix:        "1foo",
+			expectedError: `invalid name "1foo"`,
+		},
+		{
+			name:   "OK",
+			T:      T,
+			pkg:    "foo",
+			prefix: "foo",
+			expectedSource: `// Code generated by lr.ParsingTable.GenerateGo. DO NOT EDIT.
+
+package foo
+
+import (
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser/lr"
+)
+
+var fooStates = []lr.State{0, 1, 2}
+
+var fooTerminals = []grammar.Terminal{"a", grammar.Endmarker}
+
+var fooNonTerminals = []grammar.NonTerminal{"S"}
+
+var fooProductions = []*grammar.Production{
+	{Head: "S", Body: grammar.E},
+	{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("a")}},
+}
+
+// {state, terminal, action type, next state or production}
+var fooActions = [][4]int{
+	{0, 0, 1, 2},
+	{0, 1, 2, 0},
+	{1, 1, 3, 0},
+	{2, 1, 2, 1},
+}
+
+// {state, non-terminal, next state}
+var fooGotos = [][3]int{
+	{0, 0, 1},
+}
+
+// NewFooParser creates a new LR parser using the static parsing table.
+// It requires a lexer for lexical analysis, which reads the input tokens (terminal symbols).
+func NewFooParser(L lexer.Lexer) *lr.Parser {
+	T := lr.NewParsingTable(fooStates, fooTerminals, fooNonTerminals, lr.PrecedenceLevels{})
+
+	for _, a := range fooActions {
+		action := &lr.Action{Type: lr.ActionType(a[2])}
+		switch action.Type {
+		case lr.SHIFT:
+			action.State = lr.State(a[3])
+		case lr.REDUCE:
+			action.Production = fooProductions[a[3]]
+		}
+
+		T.AddACTION(lr.State(a[0]), fooTerminals[a[1]], action)
+	}
+
+	for _, g := range fooGotos {
+		T.SetGOTO(lr.State(g[0]), fooNonTerminals[g[1]], lr.State(g[2]))
+	}
+
+	return &lr.Parser{L: L, T: T}
+}
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := tc.T.GenerateGo(&b, tc.pkg, tc.prefix)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedSource, b.String())
+
+				// The generated source must be valid and formatted Go code.
+				_, err = goparser.ParseFile(token.NewFileSet(), "", b.Bytes(), goparser.AllErrors)
+				assert.NoError(t, err)
+
+				formatted, err := format.Source(b.Bytes())
+				assert.NoError(t, err)
+				assert.Equal(t, b.String(), string(formatted))
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
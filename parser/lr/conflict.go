@@ -14,10 +14,25 @@ import (
 // A conflict occurs when the grammar is ambiguous, resulting in multiple actions
 // being associated with a specific state s and terminal a in the ACTION table.
 // A conflict is either a shift/reduce conflict or a reduce/reduce conflict.
+//
+// If counterexamples are enabled (see AddCounterexamples),
+// a counterexample is included in the error message to explain why the conflict exists.
 type ConflictError struct {
 	State    State
 	Terminal grammar.Terminal
 	Actions  set.Set[*Action]
+
+	counterexample func() *Counterexample
+}
+
+// Counterexample returns a counterexample explaining the conflict.
+// It returns nil if counterexamples are not enabled for the conflict or no counterexample can be found.
+func (e *ConflictError) Counterexample() *Counterexample {
+	if e.counterexample == nil {
+		return nil
+	}
+
+	return e.counterexample()
 }
 
 // IsShiftReduce returns true if the conflict is a shift/reduce conflict.
@@ -75,6 +90,21 @@ func (e *ConflictError) Error() string {
 		}
 	}
 
+	if c := e.Counterexample(); c != nil {
+		b.WriteString("Examples:   The parser reaches the conflict with these derivations:\n")
+
+		for i, d := range c.Derivations {
+			fmt.Fprintf(&b, "              %d. %s\n", i+1, d.Example())
+			fmt.Fprintf(&b, "                 %s\n", d)
+		}
+
+		if c.HasSameInput() {
+			b.WriteString("            The examples are identical, so the grammar is likely ambiguous.\n")
+		} else {
+			b.WriteString("            The examples differ, so the conflict may be due to the limited lookahead of the parser.\n")
+		}
+	}
+
 	handles := e.handles()
 	union := handles.Union()
 
@@ -154,6 +184,22 @@ func (e AggregatedConflictError) Error() string {
 		}
 	}
 
+	if e.hasCounterexamples() {
+		b.WriteString("Examples:   The parser reaches each conflict with these inputs:\n")
+
+		sameInput := false
+		for i, err := range e {
+			if c := err.Counterexample(); c != nil {
+				fmt.Fprintf(&b, "              %d. %s\n", i+1, c)
+				sameInput = sameInput || c.HasSameInput()
+			}
+		}
+
+		if sameInput {
+			b.WriteString("            A single input means it reaches the conflict with every action, so the grammar is likely ambiguous.\n")
+		}
+	}
+
 	// Group handles by state.
 	handles := map[State]*precedenceHandleGroup{}
 	for _, err := range e {
@@ -188,6 +234,17 @@ func (e AggregatedConflictError) Error() string {
 	return b.String()
 }
 
+// hasCounterexamples determines whether or not any of the conflict errors has a counterexample.
+func (e AggregatedConflictError) hasCounterexamples() bool {
+	for _, err := range e {
+		if err.Counterexample() != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Unwrap implements the unwrap interface for AggregatedConflictError.
 // It returns the slice of accumulated errors wrapped in the AggregatedConflictError instance.
 // If there are no errors, it returns nil, indicating that e does not wrap any error.
@@ -0,0 +1,558 @@
+package lr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/sort"
+)
+
+// Derivation is a partial derivation of a sentential form from the start symbol of a grammar,
+// which leads the parser to the point where a conflicting action has to be taken.
+//
+// A derivation is represented as a chain of LR(0) items.
+// Each item derives the symbol right after the dot of its preceding item.
+// The last item is the one responsible for the conflicting action.
+// For example, the chain
+//
+//	E → E "+"•E
+//	E → E•"*" E
+//
+// derives the sentential form E "+" E "*" E, in which the conflict point is right after the second E.
+//
+// The example input for the derivation is obtained by replacing each non-terminal in the sentential form
+// with a shortest string of terminals derivable from it.
+// Whenever possible, the strings are chosen so that the conflicting terminal comes right after the conflict point.
+// For the chain above and the production E → id, the example input is "id" "+" "id"•"*" "id".
+type Derivation struct {
+	Action *Action
+	Items  []*Item0
+	// Input is the example input for the derivation.
+	// A non-terminal that does not derive any string of terminals is kept as is.
+	Input grammar.String[grammar.Symbol]
+	// Pos is the position of the conflict point in the input.
+	Pos int
+}
+
+// Prefix returns the symbols of the sentential form derived by d before the conflict point.
+func (d *Derivation) Prefix() grammar.String[grammar.Symbol] {
+	α := grammar.String[grammar.Symbol]{}
+	for _, item := range d.Items {
+		α = α.Concat(item.Body[:item.Dot])
+	}
+
+	return α
+}
+
+// Suffix returns the symbols of the sentential form derived by d after the conflict point.
+func (d *Derivation) Suffix() grammar.String[grammar.Symbol] {
+	last := d.Items[len(d.Items)-1]
+	β := last.Body[last.Dot:].Concat()
+
+	for i := len(d.Items) - 2; i >= 0; i-- {
+		item := d.Items[i]
+		β = β.Concat(item.Body[item.Dot+1:])
+	}
+
+	return β
+}
+
+// Example returns the example input for d with a dot marking the conflict point.
+func (d *Derivation) Example() string {
+	var b bytes.Buffer
+
+	if α := d.Input[:d.Pos]; len(α) > 0 {
+		b.WriteString(α.String())
+	}
+
+	b.WriteRune('•')
+
+	if β := d.Input[d.Pos:]; len(β) > 0 {
+		b.WriteString(β.String())
+	}
+
+	return b.String()
+}
+
+// String returns a string representation of a derivation.
+// Each nested production is enclosed in square brackets in place of the symbol it derives.
+func (d *Derivation) String() string {
+	s := d.Items[len(d.Items)-1].String()
+
+	for i := len(d.Items) - 2; i >= 0; i-- {
+		item := d.Items[i]
+
+		var b bytes.Buffer
+
+		fmt.Fprintf(&b, "%s → ", item.Head)
+
+		if α := item.Body[:item.Dot]; len(α) > 0 {
+			b.WriteString(α.String())
+			b.WriteRune(' ')
+		}
+
+		fmt.Fprintf(&b, "[%s]", s)
+
+		if β := item.Body[item.Dot+1:]; len(β) > 0 {
+			b.WriteRune(' ')
+			b.WriteString(β.String())
+		}
+
+		s = b.String()
+	}
+
+	return s
+}
+
+// Counterexample explains a conflict in an LR parsing table by concrete examples.
+// It consists of one derivation for each of the conflicting actions.
+//
+// Each derivation is a shortest derivation that leads the parser to the conflict for its action.
+// If all derivations have the same example input, the same input reaches the conflict with every action,
+// which strongly suggests that the grammar is ambiguous.
+// This is not a proof of ambiguity though, since the derivations are searched independently of each other.
+// If the example inputs differ, the conflict may be only due to the limited lookahead of the parser.
+type Counterexample struct {
+	Derivations []*Derivation
+}
+
+// HasSameInput returns true if all derivations of the counterexample have the same example input.
+func (c *Counterexample) HasSameInput() bool {
+	for _, d := range c.Derivations[1:] {
+		if d.Example() != c.Derivations[0].Example() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns a string representation of a counterexample.
+// A counterexample whose derivations have the same example input is represented by that single example.
+func (c *Counterexample) String() string {
+	if c.HasSameInput() {
+		return c.Derivations[0].Example()
+	}
+
+	examples := make([]string, len(c.Derivations))
+	for i, d := range c.Derivations {
+		examples[i] = d.Example()
+	}
+
+	return strings.Join(examples, " vs. ")
+}
+
+// AddCounterexamples enables counterexamples for every conflict in an error returned by ResolveConflicts.
+// G is the augmented grammar, and T is the parsing table constructed for it.
+// The error is returned as is, so this function can wrap the result of ResolveConflicts directly.
+//
+// Searching for counterexamples can be expensive for large grammars.
+// Hence, the search is deferred until a counterexample is requested, either directly or by generating the error message.
+// A conflict error that is never reported does not incur the cost of the search.
+func AddCounterexamples(G *grammar.CFG, T *ParsingTable, err error) error {
+	var errs AggregatedConflictError
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	finder := sync.OnceValue(func() *counterexampleFinder {
+		return newCounterexampleFinder(G, T)
+	})
+
+	for _, e := range errs {
+		e.counterexample = sync.OnceValue(func() *Counterexample {
+			return finder().Find(e)
+		})
+	}
+
+	return err
+}
+
+// searchKey uniquely identifies a node in the search space of the counterexample finder.
+type searchKey struct {
+	state     State
+	prod      int
+	dot       int
+	lookahead grammar.Terminal
+}
+
+// searchNode is a node in the search space of the counterexample finder.
+// It represents the LR(1) item [A → α•β, a] in a state of the parsing table.
+type searchNode struct {
+	searchKey
+	prev *searchNode
+}
+
+// counterexampleFinder searches for counterexamples for the conflicts in an LR parsing table.
+//
+// The search is a breadth-first search over pairs of states and LR(1) items, starting from
+// the initial item [S′ → •S, $] in the initial state. A node has two kinds of successors:
+//
+//   - A transition: [A → α•Xβ, a] in state s leads to [A → αX•β, a] in the state the table moves to from s on X.
+//   - A production step: [A → α•Bβ, a] in state s leads to [B → •γ, b] in s for each b in FIRST(βa).
+//
+// The path from the initial node to a node involved in a conflict is a shortest derivation
+// that leads the parser to the conflict, where the lookahead of the node is the conflicting terminal.
+type counterexampleFinder struct {
+	G      *grammar.CFG
+	T      *ParsingTable
+	FIRST  grammar.FIRST
+	prods  []*grammar.Production
+	byHead map[grammar.NonTerminal][]int
+
+	// shortest[A] is a shortest string of terminals derivable from A.
+	shortest map[grammar.NonTerminal]grammar.String[grammar.Symbol]
+	// shortestFrom[{A, a}] is a shortest string of terminals derivable from A that starts with a.
+	shortestFrom map[startKey]grammar.String[grammar.Symbol]
+}
+
+// startKey identifies the strings derivable from a non-terminal that start with a terminal.
+type startKey struct {
+	A grammar.NonTerminal
+	a grammar.Terminal
+}
+
+func newCounterexampleFinder(G *grammar.CFG, T *ParsingTable) *counterexampleFinder {
+	f := &counterexampleFinder{
+		G:            G,
+		T:            T,
+		FIRST:        G.ComputeFIRST(),
+		prods:        G.OrderProductions(),
+		byHead:       map[grammar.NonTerminal][]int{},
+		shortest:     map[grammar.NonTerminal]grammar.String[grammar.Symbol]{},
+		shortestFrom: map[startKey]grammar.String[grammar.Symbol]{},
+	}
+
+	for i, p := range f.prods {
+		f.byHead[p.Head] = append(f.byHead[p.Head], i)
+	}
+
+	f.computeShortest()
+	f.computeShortestFrom()
+
+	return f
+}
+
+// computeShortest computes a shortest string of terminals derivable from every non-terminal.
+// It repeatedly applies all productions until no shorter string is found.
+func (f *counterexampleFinder) computeShortest() {
+	for updated := true; updated; {
+		updated = false
+
+		for _, p := range f.prods {
+			w, ok := f.expand(p.Body)
+			if !ok {
+				continue
+			}
+
+			if v, ok := f.shortest[p.Head]; !ok || len(w) < len(v) {
+				f.shortest[p.Head] = w
+				updated = true
+			}
+		}
+	}
+}
+
+// computeShortestFrom computes a shortest string of terminals derivable from every non-terminal that starts with each terminal.
+// It repeatedly applies all productions until no shorter string is found.
+func (f *counterexampleFinder) computeShortestFrom() {
+	for updated := true; updated; {
+		updated = false
+
+		for _, p := range f.prods {
+			for i, X := range p.Body {
+				rest, ok := f.expand(p.Body[i+1:])
+				if !ok {
+					break
+				}
+
+				// Strings derivable from X that start with a terminal.
+				starts := map[grammar.Terminal]grammar.String[grammar.Symbol]{}
+				switch X := X.(type) {
+				case grammar.Terminal:
+					starts[X] = grammar.String[grammar.Symbol]{X}
+				case grammar.NonTerminal:
+					for key, w := range f.shortestFrom {
+						if key.A.Equal(X) {
+							starts[key.a] = w
+						}
+					}
+				}
+
+				for a, w := range starts {
+					key := startKey{p.Head, a}
+					if v, ok := f.shortestFrom[key]; !ok || len(w)+len(rest) < len(v) {
+						f.shortestFrom[key] = w.Concat(rest)
+						updated = true
+					}
+				}
+
+				// X must derive the empty string for the next symbol to start the string.
+				if !f.nullable(X) {
+					break
+				}
+			}
+		}
+	}
+}
+
+// nullable determines whether or not a symbol derives the empty string.
+func (f *counterexampleFinder) nullable(X grammar.Symbol) bool {
+	A, ok := X.(grammar.NonTerminal)
+	if !ok {
+		return false
+	}
+
+	w, ok := f.shortest[A]
+	return ok && len(w) == 0
+}
+
+// expand replaces every non-terminal in a string of symbols with a shortest string of terminals derivable from it.
+// The second return value is false if any of the non-terminals does not derive a string of terminals (yet).
+func (f *counterexampleFinder) expand(α grammar.String[grammar.Symbol]) (grammar.String[grammar.Symbol], bool) {
+	w := grammar.String[grammar.Symbol]{}
+
+	for _, X := range α {
+		switch X := X.(type) {
+		case grammar.Terminal:
+			w = w.Append(X)
+		case grammar.NonTerminal:
+			v, ok := f.shortest[X]
+			if !ok {
+				return nil, false
+			}
+			w = w.Concat(v)
+		}
+	}
+
+	return w, true
+}
+
+// expandFrom replaces every non-terminal in a string of symbols with a string of terminals derivable from it,
+// such that the resulting string starts with terminal a. If a is the endmarker, the resulting string is empty.
+// The second return value is false if no such string exists.
+func (f *counterexampleFinder) expandFrom(α grammar.String[grammar.Symbol], a grammar.Terminal) (grammar.String[grammar.Symbol], bool) {
+	for i, X := range α {
+		rest, ok := f.expand(α[i+1:])
+		if !ok {
+			return nil, false
+		}
+
+		switch X := X.(type) {
+		case grammar.Terminal:
+			if X.Equal(a) {
+				return rest.Prepend(X), true
+			}
+			return nil, false
+
+		case grammar.NonTerminal:
+			if w, ok := f.shortestFrom[startKey{X, a}]; ok {
+				return w.Concat(rest), true
+			}
+		}
+
+		if !f.nullable(X) {
+			return nil, false
+		}
+	}
+
+	return grammar.String[grammar.Symbol]{}, a.Equal(grammar.Endmarker)
+}
+
+// example generates the example input for a derivation of a conflict on terminal a.
+// Non-terminals are replaced by shortest strings of terminals derivable from them.
+// If possible, the part after the conflict point is chosen to start with the conflicting terminal.
+func (f *counterexampleFinder) example(d *Derivation, a grammar.Terminal) {
+	α, β := d.Prefix(), d.Suffix()
+
+	prefix, ok := f.expand(α)
+	if !ok {
+		prefix = α
+	}
+
+	suffix, ok := f.expandFrom(β, a)
+	if !ok {
+		if suffix, ok = f.expand(β); !ok {
+			suffix = β
+		}
+	}
+
+	d.Input = prefix.Concat(suffix)
+	d.Pos = len(prefix)
+}
+
+// Find searches for a counterexample for a conflict error.
+// It returns nil if no derivation can be found for any of the conflicting actions.
+func (f *counterexampleFinder) Find(e *ConflictError) *Counterexample {
+	actions := generic.Collect1(e.Actions.All())
+	sort.Insertion(actions, cmpAction)
+
+	// Derivations found with the conflicting terminal as the lookahead.
+	exact := make([]*searchNode, len(actions))
+	// Derivations found regardless of the lookahead, used when no exact derivation exists.
+	// This happens when the conflict is only due to the approximate lookaheads of SLR(1) or LALR(1) tables.
+	fallback := make([]*searchNode, len(actions))
+
+	remaining := len(actions)
+	visited := map[searchKey]bool{}
+
+	var queue []*searchNode
+	enqueue := func(n *searchNode) {
+		if !visited[n.searchKey] {
+			visited[n.searchKey] = true
+			queue = append(queue, n)
+		}
+	}
+
+	for _, i := range f.byHead[f.G.Start] {
+		enqueue(&searchNode{
+			searchKey: searchKey{state: 0, prod: i, dot: 0, lookahead: grammar.Endmarker},
+		})
+	}
+
+	for len(queue) > 0 && remaining > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		if n.state == e.State {
+			for i, a := range actions {
+				if f.matches(n, a, e.Terminal) {
+					if fallback[i] == nil {
+						fallback[i] = n
+					}
+
+					// The lookahead of a shifting item is irrelevant, since the conflicting terminal is the one to shift.
+					if exact[i] == nil && (a.Type == SHIFT || n.lookahead == e.Terminal) {
+						exact[i] = n
+						remaining--
+					}
+				}
+			}
+		}
+
+		body := f.prods[n.prod].Body
+		if n.dot == len(body) {
+			continue
+		}
+
+		X := body[n.dot]
+
+		// Transition on X.
+		if next, ok := f.next(n.state, X); ok {
+			enqueue(&searchNode{
+				searchKey: searchKey{state: next, prod: n.prod, dot: n.dot + 1, lookahead: n.lookahead},
+				prev:      n,
+			})
+		}
+
+		// Production steps for X.
+		if B, ok := X.(grammar.NonTerminal); ok {
+			βa := body[n.dot+1:].Append(n.lookahead)
+			lookaheads := generic.Collect1(f.FIRST(βa).Terminals.All())
+			sort.Quick(lookaheads, grammar.CmpTerminal)
+
+			for _, i := range f.byHead[B] {
+				for _, b := range lookaheads {
+					enqueue(&searchNode{
+						searchKey: searchKey{state: n.state, prod: i, dot: 0, lookahead: b},
+						prev:      n,
+					})
+				}
+			}
+		}
+	}
+
+	c := &Counterexample{
+		Derivations: make([]*Derivation, len(actions)),
+	}
+
+	for i, a := range actions {
+		n := exact[i]
+		if n == nil {
+			n = fallback[i]
+		}
+
+		if n == nil {
+			return nil
+		}
+
+		c.Derivations[i] = f.derivation(a, n)
+		f.example(c.Derivations[i], e.Terminal)
+	}
+
+	return c
+}
+
+// matches determines whether or not the item of a search node is responsible for a conflicting action on terminal a.
+func (f *counterexampleFinder) matches(n *searchNode, action *Action, a grammar.Terminal) bool {
+	p := f.prods[n.prod]
+
+	switch action.Type {
+	case SHIFT:
+		return n.dot < len(p.Body) && p.Body[n.dot].Equal(a)
+	case REDUCE:
+		return n.dot == len(p.Body) && p.Equal(action.Production)
+	case ACCEPT:
+		return n.dot == len(p.Body) && p.Head.Equal(f.G.Start)
+	default:
+		return false
+	}
+}
+
+// next returns the state the parsing table moves to from state s on symbol X.
+func (f *counterexampleFinder) next(s State, X grammar.Symbol) (State, bool) {
+	switch X := X.(type) {
+	case grammar.Terminal:
+		for _, a := range f.T.ACTIONS(s, X) {
+			if a.Type == SHIFT {
+				return a.State, true
+			}
+		}
+
+	case grammar.NonTerminal:
+		if next, err := f.T.GOTO(s, X); err == nil {
+			return next, true
+		}
+	}
+
+	return ErrState, false
+}
+
+// derivation reconstructs the derivation for a conflicting action from the path to a search node.
+func (f *counterexampleFinder) derivation(action *Action, n *searchNode) *Derivation {
+	path := []*searchNode{}
+	for ; n != nil; n = n.prev {
+		path = append(path, n)
+	}
+
+	items := []*Item0{}
+	for i := len(path) - 1; i >= 0; i-- {
+		item := &Item0{
+			Production: f.prods[path[i].prod],
+			Start:      f.G.Start,
+			Dot:        path[i].dot,
+		}
+
+		// A production step starts a new item, whereas a transition advances the last one.
+		if len(items) == 0 || item.Dot == 0 {
+			items = append(items, item)
+		} else {
+			items[len(items)-1] = item
+		}
+	}
+
+	// Omit the item for the augmented start production, unless it is the only one.
+	if len(items) > 1 {
+		items = items[1:]
+	}
+
+	return &Derivation{
+		Action: action,
+		Items:  items,
+	}
+}
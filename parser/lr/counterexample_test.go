@@ -0,0 +1,338 @@
+package lr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+)
+
+var derivations = []*Derivation{
+	{
+		Action: &Action{Type: SHIFT, State: 5},
+		Items: []*Item0{
+			{Production: parsertest.Prods[4][1], Start: "E′", Dot: 2}, // E → E "+"•E
+			{Production: parsertest.Prods[4][2], Start: "E′", Dot: 1}, // E → E•"*" E
+		},
+		Input: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal("+"), grammar.Terminal("id"), grammar.Terminal("*"), grammar.Terminal("id")},
+		Pos:   3,
+	},
+	{
+		Action: &Action{Type: REDUCE, Production: parsertest.Prods[4][1]},
+		Items: []*Item0{
+			{Production: parsertest.Prods[4][2], Start: "E′", Dot: 0}, // E → •E "*" E
+			{Production: parsertest.Prods[4][1], Start: "E′", Dot: 3}, // E → E "+" E•
+		},
+		Input: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal("+"), grammar.Terminal("id"), grammar.Terminal("*"), grammar.Terminal("id")},
+		Pos:   3,
+	},
+	{
+		Action: &Action{Type: REDUCE, Production: parsertest.Prods[2][5]},
+		Items: []*Item0{
+			{Production: parsertest.Prods[2][2], Start: "S′", Dot: 0}, // S → •R
+			{Production: parsertest.Prods[2][5], Start: "S′", Dot: 1}, // R → L•
+		},
+		Input: grammar.String[grammar.Symbol]{grammar.Terminal("id")},
+		Pos:   1,
+	},
+	{
+		Action: &Action{Type: SHIFT, State: 6},
+		Items: []*Item0{
+			{Production: parsertest.Prods[2][1], Start: "S′", Dot: 1}, // S → L•"=" R
+		},
+		Input: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal("="), grammar.Terminal("id")},
+		Pos:   1,
+	},
+}
+
+func TestDerivation_Prefix(t *testing.T) {
+	tests := []struct {
+		name           string
+		d              *Derivation
+		expectedPrefix string
+	}{
+		{
+			name:           "Shift",
+			d:              derivations[0],
+			expectedPrefix: `E "+" E`,
+		},
+		{
+			name:           "Reduce",
+			d:              derivations[1],
+			expectedPrefix: `E "+" E`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPrefix, tc.d.Prefix().String())
+		})
+	}
+}
+
+func TestDerivation_Suffix(t *testing.T) {
+	tests := []struct {
+		name           string
+		d              *Derivation
+		expectedSuffix string
+	}{
+		{
+			name:           "Shift",
+			d:              derivations[0],
+			expectedSuffix: `"*" E`,
+		},
+		{
+			name:           "Reduce",
+			d:              derivations[1],
+			expectedSuffix: `"*" E`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSuffix, tc.d.Suffix().String())
+		})
+	}
+}
+
+func TestDerivation_Example(t *testing.T) {
+	tests := []struct {
+		name            string
+		d               *Derivation
+		expectedExample string
+	}{
+		{
+			name:            "Shift",
+			d:               derivations[0],
+			expectedExample: `"id" "+" "id"•"*" "id"`,
+		},
+		{
+			name:            "Reduce",
+			d:               derivations[1],
+			expectedExample: `"id" "+" "id"•"*" "id"`,
+		},
+		{
+			name:            "EmptySuffix",
+			d:               derivations[2],
+			expectedExample: `"id"•`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedExample, tc.d.Example())
+		})
+	}
+}
+
+func TestDerivation_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		d              *Derivation
+		expectedString string
+	}{
+		{
+			name:           "Shift",
+			d:              derivations[0],
+			expectedString: `E → E "+" [E → E•"*" E]`,
+		},
+		{
+			name:           "Reduce",
+			d:              derivations[1],
+			expectedString: `E → [E → E "+" E•] "*" E`,
+		},
+		{
+			name:           "SingleItem",
+			d:              derivations[3],
+			expectedString: `S → L•"=" R`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.d.String())
+		})
+	}
+}
+
+func TestCounterexample_HasSameInput(t *testing.T) {
+	tests := []struct {
+		name                 string
+		c                    *Counterexample
+		expectedHasSameInput bool
+	}{
+		{
+			name: "SameInput",
+			c: &Counterexample{
+				Derivations: []*Derivation{derivations[0], derivations[1]},
+			},
+			expectedHasSameInput: true,
+		},
+		{
+			name: "DifferentInputs",
+			c: &Counterexample{
+				Derivations: []*Derivation{derivations[3], derivations[2]},
+			},
+			expectedHasSameInput: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedHasSameInput, tc.c.HasSameInput())
+		})
+	}
+}
+
+func TestCounterexample_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		c              *Counterexample
+		expectedString string
+	}{
+		{
+			name: "SameInput",
+			c: &Counterexample{
+				Derivations: []*Derivation{derivations[0], derivations[1]},
+			},
+			expectedString: `"id" "+" "id"•"*" "id"`,
+		},
+		{
+			name: "DifferentInputs",
+			c: &Counterexample{
+				Derivations: []*Derivation{derivations[3], derivations[2]},
+			},
+			expectedString: `"id"•"=" "id" vs. "id"•`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.c.String())
+		})
+	}
+}
+
+func TestAddCounterexamples(t *testing.T) {
+	pt := getTestParsingTables()
+
+	tests := []struct {
+		name                    string
+		G                       *grammar.CFG
+		T                       *ParsingTable
+		err                     error
+		expectedError           error
+		expectedCounterexamples []string
+	}{
+		{
+			name:          "Nil",
+			G:             parsertest.Grammars[4],
+			err:           nil,
+			expectedError: nil,
+		},
+		{
+			name:          "NotConflictError",
+			G:             parsertest.Grammars[4],
+			err:           errors.New("error on parsing"),
+			expectedError: errors.New("error on parsing"),
+		},
+		{
+			name: "ConflictError",
+			G:    augment(parsertest.Grammars[4]),
+			T:    pt[2],
+			err:  pt[2].ResolveConflicts(),
+			expectedCounterexamples: []string{
+				`"id" "*" "id"•"+" "id"`,
+				`"id" "*" "id"•"*" "id"`,
+				`"id" "+" "id"•"+" "id"`,
+				`"id" "+" "id"•"*" "id"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := AddCounterexamples(tc.G, tc.T, tc.err)
+
+			if tc.expectedCounterexamples == nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+
+			var errs AggregatedConflictError
+			assert.ErrorAs(t, err, &errs)
+			assert.Len(t, errs, len(tc.expectedCounterexamples))
+
+			for i, e := range errs {
+				c := e.Counterexample()
+				assert.NotNil(t, c)
+				assert.True(t, c.HasSameInput())
+				assert.Equal(t, tc.expectedCounterexamples[i], c.String())
+				// The counterexample is derived only once.
+				assert.Same(t, c, e.Counterexample())
+			}
+		})
+	}
+}
+
+func TestCounterexampleFinder_expandFrom(t *testing.T) {
+	// E → T E′, E′ → + T E′ | ε, T → F T′, T′ → * F T′ | ε, F → ( E ) | id
+	f := newCounterexampleFinder(parsertest.Grammars[0], nil)
+
+	tests := []struct {
+		name             string
+		α                grammar.String[grammar.Symbol]
+		a                grammar.Terminal
+		expectedOK       bool
+		expectedExpanded string
+	}{
+		{
+			name:             "Shortest",
+			α:                grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal(")")},
+			a:                "id",
+			expectedOK:       true,
+			expectedExpanded: `"id" ")"`,
+		},
+		{
+			name:             "StartsWithTerminal",
+			α:                grammar.String[grammar.Symbol]{grammar.NonTerminal("E"), grammar.Terminal(")")},
+			a:                "(",
+			expectedOK:       true,
+			expectedExpanded: `"(" "id" ")" ")"`,
+		},
+		{
+			name:             "SkipsNullable",
+			α:                grammar.String[grammar.Symbol]{grammar.NonTerminal("T′"), grammar.NonTerminal("E′"), grammar.Terminal(")")},
+			a:                "+",
+			expectedOK:       true,
+			expectedExpanded: `"+" "id" ")"`,
+		},
+		{
+			name:             "EndOfInput",
+			α:                grammar.String[grammar.Symbol]{grammar.NonTerminal("T′"), grammar.NonTerminal("E′")},
+			a:                grammar.Endmarker,
+			expectedOK:       true,
+			expectedExpanded: `ε`,
+		},
+		{
+			name:       "NotDerivable",
+			α:          grammar.String[grammar.Symbol]{grammar.NonTerminal("T′"), grammar.Terminal(")")},
+			a:          "+",
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w, ok := f.expandFrom(tc.α, tc.a)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			if tc.expectedOK {
+				assert.Equal(t, tc.expectedExpanded, w.String())
+			}
+		})
+	}
+}
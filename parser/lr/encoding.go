@@ -0,0 +1,271 @@
+package lr
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/sort"
+)
+
+// tableData is the serializable form of an LR parsing table.
+//
+// Terminals, non-terminals, and productions are stored once and referenced by their indices.
+// A symbol in a production body is referenced by its index in the list of terminals,
+// or by the number of terminals plus its index in the list of non-terminals.
+type tableData struct {
+	States       []State          `json:"states"`
+	Terminals    []string         `json:"terminals"`
+	NonTerminals []string         `json:"nonTerminals"`
+	Productions  []productionData `json:"productions"`
+	Actions      [][4]int         `json:"actions"` // {state, terminal, action type, next state or production}
+	Gotos        [][3]int         `json:"gotos"`   // {state, non-terminal, next state}
+}
+
+// productionData is the serializable form of a production rule.
+type productionData struct {
+	Head int   `json:"head"`
+	Body []int `json:"body"`
+}
+
+// data converts the parsing table into its serializable form.
+func (t *ParsingTable) data() (*tableData, error) {
+	d := &tableData{
+		States:       t.States,
+		Terminals:    make([]string, len(t.Terminals)),
+		NonTerminals: make([]string, len(t.NonTerminals)),
+		Productions:  []productionData{},
+		Actions:      [][4]int{},
+		Gotos:        [][3]int{},
+	}
+
+	symbols := make(map[grammar.Symbol]int)
+
+	for i, a := range t.Terminals {
+		d.Terminals[i] = string(a)
+		symbols[a] = i
+	}
+
+	for i, A := range t.NonTerminals {
+		d.NonTerminals[i] = string(A)
+		symbols[A] = len(t.Terminals) + i
+	}
+
+	// Ensure no entry is left out.
+	states := make(map[State]bool)
+	for _, s := range t.States {
+		states[s] = true
+	}
+
+	for s, row := range t.actions.All() {
+		for a, actions := range row.All() {
+			if _, ok := symbols[a]; (!ok || !states[s]) && actions.Size() > 0 {
+				return nil, fmt.Errorf("entry ACTION[%d, %s] is not in the parsing table", s, a)
+			}
+		}
+	}
+
+	for s, row := range t.gotos.All() {
+		for A, next := range row.All() {
+			if _, ok := symbols[A]; (!ok || !states[s]) && next != ErrState {
+				return nil, fmt.Errorf("entry GOTO[%d, %s] is not in the parsing table", s, A)
+			}
+		}
+	}
+
+	prods := make(map[string]int)
+
+	production := func(p *grammar.Production) (int, error) {
+		key := p.String()
+		if i, ok := prods[key]; ok {
+			return i, nil
+		}
+
+		head, ok := symbols[p.Head]
+		if !ok {
+			return 0, fmt.Errorf("non-terminal %s is not in the parsing table", p.Head)
+		}
+
+		pd := productionData{
+			Head: head - len(t.Terminals),
+			Body: make([]int, len(p.Body)),
+		}
+
+		for i, X := range p.Body {
+			if pd.Body[i], ok = symbols[X]; !ok {
+				return 0, fmt.Errorf("symbol %s is not in the parsing table", X)
+			}
+		}
+
+		i := len(d.Productions)
+		d.Productions = append(d.Productions, pd)
+		prods[key] = i
+
+		return i, nil
+	}
+
+	for _, s := range t.States {
+		for i, a := range t.Terminals {
+			set, ok := t.getActions(s, a)
+			if !ok {
+				continue
+			}
+
+			actions := generic.Collect1(set.All())
+			sort.Quick(actions, cmpAction)
+
+			for _, action := range actions {
+				var arg int
+
+				switch action.Type {
+				case SHIFT:
+					arg = int(action.State)
+				case REDUCE:
+					p, err := production(action.Production)
+					if err != nil {
+						return nil, err
+					}
+					arg = p
+				}
+
+				d.Actions = append(d.Actions, [4]int{int(s), i, int(action.Type), arg})
+			}
+		}
+
+		for i, A := range t.NonTerminals {
+			if next, err := t.GOTO(s, A); err == nil {
+				d.Gotos = append(d.Gotos, [3]int{int(s), i, int(next)})
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// load populates the parsing table from its serializable form.
+func (t *ParsingTable) load(d *tableData) error {
+	terminals := make([]grammar.Terminal, len(d.Terminals))
+	for i, a := range d.Terminals {
+		terminals[i] = grammar.Terminal(a)
+	}
+
+	nonTerminals := make([]grammar.NonTerminal, len(d.NonTerminals))
+	for i, A := range d.NonTerminals {
+		nonTerminals[i] = grammar.NonTerminal(A)
+	}
+
+	prods := make([]*grammar.Production, len(d.Productions))
+	for i, pd := range d.Productions {
+		if pd.Head < 0 || pd.Head >= len(nonTerminals) {
+			return fmt.Errorf("invalid non-terminal %d in production %d", pd.Head, i)
+		}
+
+		body := make(grammar.String[grammar.Symbol], len(pd.Body))
+		for j, k := range pd.Body {
+			switch {
+			case 0 <= k && k < len(terminals):
+				body[j] = terminals[k]
+			case len(terminals) <= k && k < len(terminals)+len(nonTerminals):
+				body[j] = nonTerminals[k-len(terminals)]
+			default:
+				return fmt.Errorf("invalid symbol %d in production %d", k, i)
+			}
+		}
+
+		prods[i] = &grammar.Production{
+			Head: nonTerminals[pd.Head],
+			Body: body,
+		}
+	}
+
+	table := NewParsingTable(d.States, terminals, nonTerminals, PrecedenceLevels{})
+
+	for _, a := range d.Actions {
+		if a[1] < 0 || a[1] >= len(terminals) {
+			return fmt.Errorf("invalid terminal %d in ACTION[%d]", a[1], a[0])
+		}
+
+		action := &Action{Type: ActionType(a[2])}
+
+		switch action.Type {
+		case SHIFT:
+			action.State = State(a[3])
+		case REDUCE:
+			if a[3] < 0 || a[3] >= len(prods) {
+				return fmt.Errorf("invalid production %d in ACTION[%d, %s]", a[3], a[0], terminals[a[1]])
+			}
+			action.Production = prods[a[3]]
+		case ACCEPT:
+		default:
+			return fmt.Errorf("invalid action type %d in ACTION[%d, %s]", a[2], a[0], terminals[a[1]])
+		}
+
+		table.AddACTION(State(a[0]), terminals[a[1]], action)
+	}
+
+	for _, g := range d.Gotos {
+		if g[1] < 0 || g[1] >= len(nonTerminals) {
+			return fmt.Errorf("invalid non-terminal %d in GOTO[%d]", g[1], g[0])
+		}
+
+		table.SetGOTO(State(g[0]), nonTerminals[g[1]], State(g[2]))
+	}
+
+	*t = *table
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It encodes the ACTION and GOTO tables along with the states, terminals, and non-terminals.
+// Precedence levels are not encoded, since they are only needed for resolving conflicts.
+func (t *ParsingTable) MarshalJSON() ([]byte, error) {
+	d, err := t.data()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(d)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It decodes a parsing table previously encoded by MarshalJSON.
+func (t *ParsingTable) UnmarshalJSON(b []byte) error {
+	d := new(tableData)
+	if err := json.Unmarshal(b, d); err != nil {
+		return err
+	}
+
+	return t.load(d)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It encodes the ACTION and GOTO tables along with the states, terminals, and non-terminals in a compact binary form.
+// Precedence levels are not encoded, since they are only needed for resolving conflicts.
+func (t *ParsingTable) MarshalBinary() ([]byte, error) {
+	d, err := t.data()
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(d); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It decodes a parsing table previously encoded by MarshalBinary.
+func (t *ParsingTable) UnmarshalBinary(b []byte) error {
+	d := new(tableData)
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(d); err != nil {
+		return err
+	}
+
+	return t.load(d)
+}
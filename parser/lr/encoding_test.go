@@ -0,0 +1,187 @@
+package lr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+)
+
+func TestParsingTable_JSON(t *testing.T) {
+	pt := getTestParsingTables()
+
+	tests := []struct {
+		name string
+		pt   *ParsingTable
+	}{
+		{
+			name: "E→E+T",
+			pt:   pt[0],
+		},
+		{
+			name: "E→E+E",
+			pt:   pt[1],
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.pt.MarshalJSON()
+			assert.NoError(t, err)
+
+			T := new(ParsingTable)
+			err = T.UnmarshalJSON(b)
+			assert.NoError(t, err)
+
+			assert.True(t, T.Equal(tc.pt))
+			assert.Equal(t, tc.pt.States, T.States)
+			assert.Equal(t, tc.pt.Terminals, T.Terminals)
+			assert.Equal(t, tc.pt.NonTerminals, T.NonTerminals)
+		})
+	}
+}
+
+func TestParsingTable_Binary(t *testing.T) {
+	pt := getTestParsingTables()
+
+	tests := []struct {
+		name string
+		pt   *ParsingTable
+	}{
+		{
+			name: "E→E+T",
+			pt:   pt[0],
+		},
+		{
+			name: "E→E+E",
+			pt:   pt[1],
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.pt.MarshalBinary()
+			assert.NoError(t, err)
+
+			T := new(ParsingTable)
+			err = T.UnmarshalBinary(b)
+			assert.NoError(t, err)
+
+			assert.True(t, T.Equal(tc.pt))
+			assert.Equal(t, tc.pt.States, T.States)
+			assert.Equal(t, tc.pt.Terminals, T.Terminals)
+			assert.Equal(t, tc.pt.NonTerminals, T.NonTerminals)
+		})
+	}
+}
+
+func TestParsingTable_Marshal_Error(t *testing.T) {
+	T0 := NewParsingTable([]State{0}, []grammar.Terminal{"a"}, []grammar.NonTerminal{"A"}, PrecedenceLevels{})
+	T0.AddACTION(0, "b", &Action{Type: SHIFT, State: 0})
+
+	T1 := NewParsingTable([]State{0}, []grammar.Terminal{"a"}, []grammar.NonTerminal{"A"}, PrecedenceLevels{})
+	T1.SetGOTO(1, "A", 0)
+
+	T2 := NewParsingTable([]State{0}, []grammar.Terminal{"a"}, []grammar.NonTerminal{"A"}, PrecedenceLevels{})
+	T2.AddACTION(0, "a", &Action{
+		Type: REDUCE,
+		Production: &grammar.Production{
+			Head: "A",
+			Body: grammar.String[grammar.Symbol]{grammar.Terminal("b")},
+		},
+	})
+
+	tests := []struct {
+		name          string
+		T             *ParsingTable
+		expectedError string
+	}{
+		{
+			name:          "MissingACTION",
+			T:             T0,
+			expectedError: `entry ACTION[0, "b"] is not in the parsing table`,
+		},
+		{
+			name:          "MissingGOTO",
+			T:             T1,
+			expectedError: `entry GOTO[1, A] is not in the parsing table`,
+		},
+		{
+			name:          "MissingSymbol",
+			T:             T2,
+			expectedError: `symbol "b" is not in the parsing table`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.T.MarshalJSON()
+			assert.EqualError(t, err, tc.expectedError)
+
+			_, err = tc.T.MarshalBinary()
+			assert.EqualError(t, err, tc.expectedError)
+
+			err = tc.T.GenerateGo(nil, "pkg", "name")
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestParsingTable_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedError string
+	}{
+		{
+			name:          "InvalidJSON",
+			data:          `{`,
+			expectedError: `unexpected end of JSON input`,
+		},
+		{
+			name:          "InvalidHead",
+			data:          `{"states":[0],"terminals":["a"],"nonTerminals":["A"],"productions":[{"head":1,"body":[0]}]}`,
+			expectedError: `invalid non-terminal 1 in production 0`,
+		},
+		{
+			name:          "InvalidSymbol",
+			data:          `{"states":[0],"terminals":["a"],"nonTerminals":["A"],"productions":[{"head":0,"body":[2]}]}`,
+			expectedError: `invalid symbol 2 in production 0`,
+		},
+		{
+			name:          "InvalidTerminal",
+			data:          `{"states":[0],"terminals":["a"],"nonTerminals":["A"],"actions":[[0,1,1,0]]}`,
+			expectedError: `invalid terminal 1 in ACTION[0]`,
+		},
+		{
+			name:          "InvalidProduction",
+			data:          `{"states":[0],"terminals":["a"],"nonTerminals":["A"],"actions":[[0,0,2,0]]}`,
+			expectedError: `invalid production 0 in ACTION[0, "a"]`,
+		},
+		{
+			name:          "InvalidActionType",
+			data:          `{"states":[0],"terminals":["a"],"nonTerminals":["A"],"actions":[[0,0,4,0]]}`,
+			expectedError: `invalid action type 4 in ACTION[0, "a"]`,
+		},
+		{
+			name:          "InvalidNonTerminal",
+			data:          `{"states":[0],"terminals":["a"],"nonTerminals":["A"],"gotos":[[0,1,0]]}`,
+			expectedError: `invalid non-terminal 1 in GOTO[0]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			T := new(ParsingTable)
+			err := T.UnmarshalJSON([]byte(tc.data))
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestParsingTable_UnmarshalBinary(t *testing.T) {
+	T := new(ParsingTable)
+	err := T.UnmarshalBinary([]byte{0x00})
+	assert.Error(t, err)
+}
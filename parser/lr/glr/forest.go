@@ -0,0 +1,315 @@
+package glr
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+)
+
+// Forest is a shared packed parse forest (SPPF).
+// It compactly represents all parse trees of an input string.
+//
+// Every symbol node in the forest represents a grammar symbol deriving a span of the input.
+// Symbol nodes for the same symbol and the same span are shared among all parse trees.
+// An ambiguous symbol node has more than one packed node, each representing an alternative derivation.
+type Forest struct {
+	Root *SymbolNode
+
+	nodes     map[spanKey]*SymbolNode
+	positions []lexer.Position
+}
+
+// spanKey uniquely identifies a symbol node in a forest.
+type spanKey struct {
+	symbol     grammar.Symbol
+	start, end int
+}
+
+func newForest() *Forest {
+	return &Forest{
+		nodes: map[spanKey]*SymbolNode{},
+	}
+}
+
+// symbolNode returns the symbol node for a non-terminal spanning the input from start to end.
+// The node is created if it does not already exist.
+func (f *Forest) symbolNode(A grammar.NonTerminal, start, end int) *SymbolNode {
+	key := spanKey{A, start, end}
+	if n, ok := f.nodes[key]; ok {
+		return n
+	}
+
+	n := &SymbolNode{
+		Symbol: A,
+		Start:  start,
+		End:    end,
+		Pos:    f.positions[start],
+	}
+
+	f.nodes[key] = n
+
+	return n
+}
+
+// terminalNode returns the symbol node for the i-th token of the input.
+// The node is created if it does not already exist.
+func (f *Forest) terminalNode(token lexer.Token, i int) *SymbolNode {
+	key := spanKey{token.Terminal, i, i + 1}
+	if n, ok := f.nodes[key]; ok {
+		return n
+	}
+
+	n := &SymbolNode{
+		Symbol: token.Terminal,
+		Start:  i,
+		End:    i + 1,
+		Lexeme: token.Lexeme,
+		Pos:    token.Pos,
+	}
+
+	f.nodes[key] = n
+
+	return n
+}
+
+// Ambiguous determines whether or not the forest represents more than one parse tree.
+// It returns true if any symbol node reachable from the root has more than one alternative.
+func (f *Forest) Ambiguous() bool {
+	visited := map[*SymbolNode]bool{}
+
+	var ambiguous func(*SymbolNode) bool
+	ambiguous = func(n *SymbolNode) bool {
+		if visited[n] {
+			return false
+		}
+		visited[n] = true
+
+		if len(n.Alternatives) > 1 {
+			return true
+		}
+
+		for _, alt := range n.Alternatives {
+			for _, child := range alt.Children {
+				if ambiguous(child) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	return ambiguous(f.Root)
+}
+
+// Trees returns an iterator sequence of all parse trees in the forest.
+// Each parse tree is a newly built abstract syntax tree (AST).
+//
+// The number of parse trees can grow exponentially with the length of the input,
+// so the trees are built lazily as the sequence is iterated.
+// If the grammar is cyclic (e.g., A → A), the forest represents infinitely many parse trees.
+// In this case, the derivations that repeat a symbol node within itself are skipped.
+func (f *Forest) Trees() iter.Seq[parser.Node] {
+	return func(yield func(parser.Node) bool) {
+		f.Root.trees(map[*SymbolNode]bool{}, yield)
+	}
+}
+
+// ChooseFunc is a function invoked for every ambiguous symbol node in a forest.
+// It receives the symbol node and its alternatives, and returns the alternative to keep.
+type ChooseFunc func(*SymbolNode, []*PackedNode) *PackedNode
+
+// Disambiguate builds a single parse tree from the forest.
+//
+// For every ambiguous symbol node, the provided ChooseFunc is invoked once to select one of the alternatives.
+// The selection applies to all occurrences of the shared symbol node in the parse tree.
+// If the ChooseFunc is nil, the first alternative is selected.
+//
+// An error is returned if the ChooseFunc does not return one of the given alternatives,
+// or if the selected alternatives form a cyclic derivation.
+func (f *Forest) Disambiguate(choose ChooseFunc) (parser.Node, error) {
+	chosen := map[*SymbolNode]*PackedNode{}
+	active := map[*SymbolNode]bool{}
+
+	var build func(*SymbolNode) (parser.Node, error)
+	build = func(n *SymbolNode) (parser.Node, error) {
+		if n.IsTerminal() {
+			return n.leaf(), nil
+		}
+
+		if active[n] {
+			return nil, fmt.Errorf("cyclic derivation for %s", n)
+		}
+
+		alt, ok := chosen[n]
+		if !ok {
+			if len(n.Alternatives) == 1 || choose == nil {
+				alt = n.Alternatives[0]
+			} else if alt = choose(n, n.Alternatives); !n.hasAlternative(alt) {
+				return nil, fmt.Errorf("no alternative chosen for %s", n)
+			}
+
+			chosen[n] = alt
+		}
+
+		active[n] = true
+		defer delete(active, n)
+
+		in := &parser.InternalNode{
+			NonTerminal: n.Symbol.(grammar.NonTerminal),
+			Production:  alt.Production,
+		}
+
+		for _, child := range alt.Children {
+			c, err := build(child)
+			if err != nil {
+				return nil, err
+			}
+
+			in.Children = append(in.Children, c)
+		}
+
+		return in, nil
+	}
+
+	return build(f.Root)
+}
+
+// SymbolNode is a node in a shared packed parse forest.
+// It represents a grammar symbol deriving the input tokens from Start (inclusive) to End (exclusive).
+//
+// A symbol node for a terminal symbol represents a single input token.
+// A symbol node for a non-terminal symbol has one packed node for every alternative derivation.
+type SymbolNode struct {
+	Symbol       grammar.Symbol
+	Start, End   int
+	Lexeme       string
+	Pos          lexer.Position
+	Alternatives []*PackedNode
+}
+
+// String returns a string representation of a symbol node.
+func (n *SymbolNode) String() string {
+	return fmt.Sprintf("%s [%d, %d)", n.Symbol, n.Start, n.End)
+}
+
+// IsTerminal determines whether or not the symbol node represents a terminal symbol.
+func (n *SymbolNode) IsTerminal() bool {
+	return n.Symbol.IsTerminal()
+}
+
+// IsAmbiguous determines whether or not the symbol node has more than one alternative derivation.
+func (n *SymbolNode) IsAmbiguous() bool {
+	return len(n.Alternatives) > 1
+}
+
+// addAlternative adds a packed node for a production and its children,
+// unless an identical alternative already exists.
+func (n *SymbolNode) addAlternative(prod *grammar.Production, children []*SymbolNode) {
+	for _, alt := range n.Alternatives {
+		if alt.equal(prod, children) {
+			return
+		}
+	}
+
+	n.Alternatives = append(n.Alternatives, &PackedNode{
+		Production: prod,
+		Children:   append([]*SymbolNode{}, children...),
+	})
+}
+
+func (n *SymbolNode) hasAlternative(alt *PackedNode) bool {
+	for _, a := range n.Alternatives {
+		if a == alt {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n *SymbolNode) leaf() *parser.LeafNode {
+	return &parser.LeafNode{
+		Terminal: n.Symbol.(grammar.Terminal),
+		Lexeme:   n.Lexeme,
+		Position: n.Pos,
+	}
+}
+
+// trees yields all parse trees rooted at the symbol node.
+// The active set tracks the symbol nodes being expanded to skip cyclic derivations.
+func (n *SymbolNode) trees(active map[*SymbolNode]bool, yield func(parser.Node) bool) bool {
+	if n.IsTerminal() {
+		return yield(n.leaf())
+	}
+
+	if active[n] {
+		return true
+	}
+
+	for _, alt := range n.Alternatives {
+		active[n] = true
+
+		ok := alt.trees(active, 0, nil, func(children []parser.Node) bool {
+			// The node is no longer being expanded once its tree is complete.
+			delete(active, n)
+			defer func() { active[n] = true }()
+
+			return yield(&parser.InternalNode{
+				NonTerminal: n.Symbol.(grammar.NonTerminal),
+				Production:  alt.Production,
+				Children:    children,
+			})
+		})
+
+		delete(active, n)
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PackedNode is an alternative derivation of a symbol node in a shared packed parse forest.
+// It represents a production and the symbol nodes for the symbols in the body of the production.
+type PackedNode struct {
+	Production *grammar.Production
+	Children   []*SymbolNode
+}
+
+// String returns a string representation of a packed node.
+func (p *PackedNode) String() string {
+	return p.Production.String()
+}
+
+func (p *PackedNode) equal(prod *grammar.Production, children []*SymbolNode) bool {
+	if !p.Production.Equal(prod) || len(p.Children) != len(children) {
+		return false
+	}
+
+	for i := range p.Children {
+		if p.Children[i] != children[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// trees yields the children of all parse trees for the packed node,
+// using the cartesian product of the parse trees of its children.
+func (p *PackedNode) trees(active map[*SymbolNode]bool, i int, prefix []parser.Node, yield func([]parser.Node) bool) bool {
+	if i == len(p.Children) {
+		return yield(prefix)
+	}
+
+	return p.Children[i].trees(active, func(child parser.Node) bool {
+		// The full slice expression ensures every tree gets its own copy of the children.
+		return p.trees(active, i+1, append(prefix[:i:i], child), yield)
+	})
+}
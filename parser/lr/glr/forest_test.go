@@ -0,0 +1,168 @@
+package glr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+)
+
+func TestSymbolNode(t *testing.T) {
+	tests := []struct {
+		name              string
+		n                 *SymbolNode
+		expectedString    string
+		expectedTerminal  bool
+		expectedAmbiguous bool
+	}{
+		{
+			name: "Terminal",
+			n: &SymbolNode{
+				Symbol: grammar.Terminal("id"),
+				Start:  0,
+				End:    1,
+				Lexeme: "a",
+			},
+			expectedString:    `"id" [0, 1)`,
+			expectedTerminal:  true,
+			expectedAmbiguous: false,
+		},
+		{
+			name: "NonTerminal",
+			n: &SymbolNode{
+				Symbol: grammar.NonTerminal("E"),
+				Start:  0,
+				End:    3,
+				Alternatives: []*PackedNode{
+					{Production: parsertest.Prods[4][1]},
+					{Production: parsertest.Prods[4][2]},
+				},
+			},
+			expectedString:    `E [0, 3)`,
+			expectedTerminal:  false,
+			expectedAmbiguous: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.n.String())
+			assert.Equal(t, tc.expectedTerminal, tc.n.IsTerminal())
+			assert.Equal(t, tc.expectedAmbiguous, tc.n.IsAmbiguous())
+		})
+	}
+}
+
+func TestPackedNode_String(t *testing.T) {
+	p := &PackedNode{Production: parsertest.Prods[4][1]}
+	assert.Equal(t, `E → E "+" E`, p.String())
+}
+
+func TestForest_Disambiguate(t *testing.T) {
+	// Prefer the alternative with the operator of lower precedence at the top.
+	preferSum := func(n *SymbolNode, alts []*PackedNode) *PackedNode {
+		for _, alt := range alts {
+			if alt.Production.Equal(parsertest.Prods[4][1]) {
+				return alt
+			}
+		}
+		return alts[0]
+	}
+
+	tests := []struct {
+		name          string
+		L             lexer.Lexer
+		G             *grammar.CFG
+		choose        ChooseFunc
+		expectedTree  string
+		expectedError string
+	}{
+		{
+			name:         "FirstAlternative",
+			L:            mockTokens("a"),
+			G:            parsertest.Grammars[4],
+			choose:       nil,
+			expectedTree: "(E a)",
+		},
+		{
+			name:         "Chosen",
+			L:            mockTokens("a", "*", "b", "+", "c"),
+			G:            parsertest.Grammars[4],
+			choose:       preferSum,
+			expectedTree: "(E (E (E a) * (E b)) + (E c))",
+		},
+		{
+			name:         "Shared",
+			L:            mockTokens("(", "a", "+", "b", "*", "c", ")", "*", "(", "d", ")"),
+			G:            parsertest.Grammars[4],
+			choose:       preferSum,
+			expectedTree: "(E (E ( (E (E a) + (E (E b) * (E c))) )) * (E ( (E d) )))",
+		},
+		{
+			name: "InvalidChoice",
+			L:    mockTokens("a", "+", "b", "*", "c"),
+			G:    parsertest.Grammars[4],
+			choose: func(*SymbolNode, []*PackedNode) *PackedNode {
+				return nil
+			},
+			expectedError: "no alternative chosen for E [0, 5)",
+		},
+		{
+			name: "CyclicDerivation",
+			L:    mockTokens("a"),
+			G:    cyclic,
+			choose: func(n *SymbolNode, alts []*PackedNode) *PackedNode {
+				for _, alt := range alts {
+					if len(alt.Children) == 1 && alt.Children[0] == n {
+						return alt
+					}
+				}
+				return alts[0]
+			},
+			expectedError: "cyclic derivation for S [0, 1)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.L, tc.G, nil)
+			assert.NoError(t, err)
+
+			f, err := p.Parse()
+			assert.NoError(t, err)
+
+			tree, err := f.Disambiguate(tc.choose)
+
+			if len(tc.expectedError) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedTree, bracket(tree))
+				assert.Equal(t, lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1}, tree.Pos())
+			} else {
+				assert.Nil(t, tree)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestForest_Trees_Break(t *testing.T) {
+	p, err := New(mockTokens("a", "+", "b", "+", "c", "+", "d"), parsertest.Grammars[4], nil)
+	assert.NoError(t, err)
+
+	f, err := p.Parse()
+	assert.NoError(t, err)
+
+	count := 0
+	for tree := range f.Trees() {
+		assert.IsType(t, &parser.InternalNode{}, tree)
+		if count++; count == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,297 @@
+// Package glr provides data structures and algorithms for building Generalized LR (GLR) parsers.
+// A GLR parser is a bottom-up parser for the class of all context-free grammars, including ambiguous ones.
+//
+// A GLR parser uses an ordinary LR parsing table, but it does not require the table to be free of conflicts.
+// Whenever an entry of the ACTION table contains more than one action, the parser forks and pursues all of them.
+// Parallel stacks are merged into a graph-structured stack (GSS), so common prefixes and suffixes are shared.
+// A stack that reaches an error entry simply dies, and the input is rejected only if all stacks die.
+//
+// Instead of a single parse tree, a GLR parser produces a shared packed parse forest (SPPF).
+// Sub-trees for the same symbol over the same span of input are shared,
+// and alternative derivations of an ambiguous symbol are packed in the same node.
+// The forest represents all parse trees of the input compactly,
+// even when the number of parse trees is exponential in the length of the input.
+//
+// For more details on parsing theory, refer to
+// "Efficient Parsing for Natural Language" by Masaru Tomita and
+// "Parsing Techniques: A Practical Guide (2nd Edition)" by Dick Grune and Ceriel J.H. Jacobs.
+package glr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+	"github.com/moorara/algo/parser/lr"
+	"github.com/moorara/algo/parser/lr/lookahead"
+)
+
+// New creates a new GLR parser for a given context-free grammar (CFG).
+// It requires a lexer for lexical analysis, which reads the input tokens (terminal symbols).
+//
+// The parser uses an LALR parsing table for the grammar.
+// Conflicts that can be resolved using the precedence levels are resolved,
+// and the remaining conflicting actions are kept in the parsing table.
+func New(L lexer.Lexer, G *grammar.CFG, precedences lr.PrecedenceLevels) (*Parser, error) {
+	T, err := lookahead.BuildParsingTable(G, precedences)
+	if err != nil {
+		// Conflicts are expected and handled by the GLR parser.
+		if cerr := new(lr.AggregatedConflictError); !errors.As(err, cerr) {
+			return nil, &parser.ParseError{
+				Cause: err,
+			}
+		}
+	}
+
+	return &Parser{
+		L: L,
+		T: T,
+	}, nil
+}
+
+// Parser is a Generalized LR (GLR) parser for all context-free grammars.
+// The parsing table may contain conflicting actions.
+type Parser struct {
+	L lexer.Lexer
+	T *lr.ParsingTable
+}
+
+// nextToken wraps the Lexer.NextToken method and ensures
+// an Endmarker token is returned when the end of input is reached.
+func (p *Parser) nextToken() (lexer.Token, error) {
+	token, err := p.L.NextToken()
+	if err != nil && errors.Is(err, io.EOF) {
+		token.Terminal, token.Lexeme = grammar.Endmarker, ""
+		return token, nil
+	}
+
+	return token, err
+}
+
+// vertex is a vertex in the graph-structured stack.
+// Each vertex holds a parser state, and every vertex created while
+// reading the same input token belongs to the same level of the stack.
+type vertex struct {
+	state lr.State
+	level int
+	edges []*edge
+}
+
+// edge connects a vertex to the vertex below it in the graph-structured stack.
+// Each edge is labeled with the forest node for the grammar symbol between the two vertices.
+type edge struct {
+	to   *vertex
+	node *SymbolNode
+}
+
+// findEdge returns the edge from v to u, if any.
+func (v *vertex) findEdge(u *vertex) *edge {
+	for _, e := range v.edges {
+		if e.to == u {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// paths enumerates all paths of the given length starting at v.
+// If via is not nil, only the paths going through the edge via are enumerated.
+// For each path, the visit function is called with the last vertex on the path
+// and the labels of the edges on the path from the bottom to the top of the stack.
+func (v *vertex) paths(length int, via *edge, visit func(*vertex, []*SymbolNode)) {
+	nodes := make([]*SymbolNode, length)
+
+	var walk func(*vertex, int, bool)
+	walk = func(u *vertex, i int, seen bool) {
+		if i == 0 {
+			if seen {
+				visit(u, nodes)
+			}
+			return
+		}
+
+		for _, e := range u.edges {
+			nodes[i-1] = e.node
+			walk(e.to, i-1, seen || e == via)
+		}
+	}
+
+	walk(v, length, via == nil)
+}
+
+// reduction is a pending reduction in the graph-structured stack.
+type reduction struct {
+	v    *vertex
+	prod *grammar.Production
+	via  *edge
+}
+
+/*
+ * INPUT:  • A lexer for reading input string w = a₁a₂...aₙ.
+ *         • An LR parsing table with functions ACTION and GOTO for a grammar G, possibly with conflicts.
+ * OUTPUT: • If w ∈ L(G), a shared packed parse forest for all derivations of w; otherwise, an error indication.
+ *
+ * METHOD: Initially, the graph-structured stack has a single vertex for s₀ in level U₀.
+ *
+ *         for i = 0 to n {
+ *           let a be aᵢ₊₁ (or $ when i = n);
+ *           for every vertex v in Uᵢ and every action REDUCE A → β in ACTION[v,a] {
+ *             for every path of length |β| from v to some vertex u {
+ *               let the forest node for A span the input from u to v;
+ *               add a packed node for A → β with the labels of the path as children;
+ *               if Uᵢ has no vertex w for GOTO[u,A], create it;
+ *               if there is no edge from w to u, add it and redo all the reductions going through the new edge;
+ *             }
+ *           }
+ *           if a = $ and some vertex v in Uᵢ has ACTION[v,$] = accept, return the forest node for S;
+ *           for every vertex v in Uᵢ and every action SHIFT t in ACTION[v,a] {
+ *             add an edge labeled with a from the vertex for t in Uᵢ₊₁ to v;
+ *           }
+ *           if Uᵢ₊₁ is empty, report an error;
+ *         }
+ *
+ * The reductions going through a new edge must be redone (Farshi's correction to Tomita's algorithm),
+ * since the new edge creates new paths for reductions that have already been performed.
+ */
+
+// Parse implements the GLR parsing algorithm.
+// It analyzes a sequence of input tokens (terminal symbols) provided by a lexical analyzer.
+// It attempts to parse the input according to the production rules of a context-free grammar,
+// determining whether the input string belongs to the language defined by the grammar.
+//
+// If the input string is valid, a shared packed parse forest is returned,
+// representing all possible syntactic structures of the input string.
+// The forest can be enumerated into parse trees or disambiguated into a single parse tree.
+//
+// An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue.
+func (p *Parser) Parse() (*Forest, error) {
+	f := newForest()
+
+	// BuildStateMap ensures state 0 always includes the initial item "S′ → •S"
+	curr := map[lr.State]*vertex{
+		0: {state: 0, level: 0},
+	}
+	order := []*vertex{curr[0]}
+
+	// Read the first input token.
+	token, err := p.nextToken()
+	if err != nil {
+		return nil, &parser.ParseError{Cause: err}
+	}
+
+	for i := 0; ; i++ {
+		a := token.Terminal
+		f.positions = append(f.positions, token.Pos)
+
+		// Perform all reductions on the current level.
+		var queue []reduction
+
+		enqueue := func(v *vertex, via *edge) {
+			for _, action := range p.T.ACTIONS(v.state, a) {
+				if action.Type == lr.REDUCE && (via == nil || len(action.Production.Body) > 0) {
+					queue = append(queue, reduction{v, action.Production, via})
+				}
+			}
+		}
+
+		for _, v := range order {
+			enqueue(v, nil)
+		}
+
+		for len(queue) > 0 {
+			r := queue[0]
+			queue = queue[1:]
+
+			A, β := r.prod.Head, r.prod.Body
+
+			r.v.paths(len(β), r.via, func(u *vertex, children []*SymbolNode) {
+				next, err := p.T.GOTO(u.state, A)
+				if err != nil {
+					return
+				}
+
+				node := f.symbolNode(A, u.level, i)
+				node.addAlternative(r.prod, children)
+
+				w, ok := curr[next]
+				if !ok {
+					w = &vertex{state: next, level: i}
+					w.edges = append(w.edges, &edge{to: u, node: node})
+					curr[next] = w
+					order = append(order, w)
+					enqueue(w, nil)
+					return
+				}
+
+				if w.findEdge(u) == nil {
+					e := &edge{to: u, node: node}
+					w.edges = append(w.edges, e)
+
+					// The new edge creates new paths for the reductions of all vertices on the current level.
+					for _, v := range order {
+						enqueue(v, e)
+					}
+				}
+			})
+		}
+
+		// Check whether any stack accepts the input.
+		if a == grammar.Endmarker {
+			for _, v := range order {
+				for _, action := range p.T.ACTIONS(v.state, a) {
+					if action.Type != lr.ACCEPT {
+						continue
+					}
+
+					for _, e := range v.edges {
+						if e.to.level == 0 && e.to.state == 0 {
+							f.Root = e.node
+							return f, nil
+						}
+					}
+				}
+			}
+		}
+
+		// Shift the current token on all stacks that can shift it.
+		next := map[lr.State]*vertex{}
+		nextOrder := []*vertex{}
+
+		for _, v := range order {
+			for _, action := range p.T.ACTIONS(v.state, a) {
+				if action.Type != lr.SHIFT {
+					continue
+				}
+
+				w, ok := next[action.State]
+				if !ok {
+					w = &vertex{state: action.State, level: i + 1}
+					next[action.State] = w
+					nextOrder = append(nextOrder, w)
+				}
+
+				w.edges = append(w.edges, &edge{to: v, node: f.terminalNode(token, i)})
+			}
+		}
+
+		// All stacks have died.
+		if len(nextOrder) == 0 {
+			return nil, &parser.ParseError{
+				Description: fmt.Sprintf("unexpected string %q", token.Lexeme),
+				Cause:       fmt.Errorf("no action exists in the parsing table for %s", a),
+				Pos:         token.Pos,
+			}
+		}
+
+		curr, order = next, nextOrder
+
+		// Read the next input token.
+		if token, err = p.nextToken(); err != nil {
+			return nil, &parser.ParseError{Cause: err}
+		}
+	}
+}
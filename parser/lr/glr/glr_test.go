@@ -0,0 +1,271 @@
+package glr
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+	"github.com/moorara/algo/parser/lr"
+)
+
+// mockTokens creates a mock lexer that returns a token for each lexeme on a single line.
+func mockTokens(lexemes ...string) *parsertest.MockLexer {
+	L := new(parsertest.MockLexer)
+	col := 1
+
+	for _, lexeme := range lexemes {
+		terminal := grammar.Terminal(lexeme)
+		if lexeme >= "a" && lexeme <= "z" {
+			terminal = "id"
+		}
+
+		L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{
+			OutToken: lexer.Token{
+				Terminal: terminal,
+				Lexeme:   lexeme,
+				Pos:      lexer.Position{Filename: "test", Offset: col - 1, Line: 1, Column: col},
+			},
+		})
+
+		col += len(lexeme) + 1
+	}
+
+	L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{OutError: io.EOF})
+
+	return L
+}
+
+// bracket returns a compact bracketed representation of a parse tree.
+func bracket(n parser.Node) string {
+	switch n := n.(type) {
+	case *parser.LeafNode:
+		return n.Lexeme
+	case *parser.InternalNode:
+		s := make([]string, 0, len(n.Children)+1)
+		s = append(s, n.NonTerminal.Name())
+		for _, child := range n.Children {
+			s = append(s, bracket(child))
+		}
+		return "(" + strings.Join(s, " ") + ")"
+	default:
+		return ""
+	}
+}
+
+var (
+	// S → a S B | x
+	// B → b | ε
+	rightNullable = grammar.NewCFG(
+		[]grammar.Terminal{"a", "b", "x"},
+		[]grammar.NonTerminal{"S", "B"},
+		[]*grammar.Production{
+			{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("a"), grammar.NonTerminal("S"), grammar.NonTerminal("B")}},
+			{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("x")}},
+			{Head: "B", Body: grammar.String[grammar.Symbol]{grammar.Terminal("b")}},
+			{Head: "B", Body: grammar.E},
+		},
+		"S",
+	)
+
+	// S → S | id
+	cyclic = grammar.NewCFG(
+		[]grammar.Terminal{"id"},
+		[]grammar.NonTerminal{"S"},
+		[]*grammar.Production{
+			{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("S")}},
+			{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}},
+		},
+		"S",
+	)
+)
+
+// mockRightNullable creates a mock lexer for the right-nullable grammar.
+func mockRightNullable(lexemes ...string) *parsertest.MockLexer {
+	L := mockTokens(lexemes...)
+	for i := range L.NextTokenMocks {
+		if t := &L.NextTokenMocks[i].OutToken; t.Terminal == "id" {
+			t.Terminal = grammar.Terminal(t.Lexeme)
+		}
+	}
+
+	return L
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		G             *grammar.CFG
+		precedences   lr.PrecedenceLevels
+		expectedError string
+	}{
+		{
+			name:        "E→E+T",
+			G:           parsertest.Grammars[3],
+			precedences: lr.PrecedenceLevels{},
+		},
+		{
+			name:        "E→E+E",
+			G:           parsertest.Grammars[4],
+			precedences: lr.PrecedenceLevels{},
+		},
+		{
+			name: "InvalidPrecedences",
+			G:    parsertest.Grammars[4],
+			precedences: lr.PrecedenceLevels{
+				{
+					Associativity: lr.LEFT,
+					Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("+")),
+				},
+				{
+					Associativity: lr.LEFT,
+					Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("+")),
+				},
+			},
+			expectedError: `"+" appeared in more than one precedence level`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(nil, tc.G, tc.precedences)
+
+			if len(tc.expectedError) == 0 {
+				assert.NotNil(t, p)
+				assert.NoError(t, err)
+			} else {
+				assert.Nil(t, p)
+				assert.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestParser_Parse(t *testing.T) {
+	tests := []struct {
+		name              string
+		L                 lexer.Lexer
+		G                 *grammar.CFG
+		precedences       lr.PrecedenceLevels
+		expectedAmbiguous bool
+		expectedTrees     []string
+		expectedError     string
+	}{
+		{
+			name:              "Unambiguous",
+			L:                 mockTokens("a", "+", "b", "*", "c"),
+			G:                 parsertest.Grammars[3],
+			expectedAmbiguous: false,
+			expectedTrees: []string{
+				"(E (E (T (F a))) + (T (T (F b)) * (F c)))",
+			},
+		},
+		{
+			name:              "Ambiguous",
+			L:                 mockTokens("a", "+", "b", "*", "c"),
+			G:                 parsertest.Grammars[4],
+			expectedAmbiguous: true,
+			expectedTrees: []string{
+				"(E (E a) + (E (E b) * (E c)))",
+				"(E (E (E a) + (E b)) * (E c))",
+			},
+		},
+		{
+			name: "ResolvedByPrecedence",
+			L:    mockTokens("a", "+", "b", "*", "c"),
+			G:    parsertest.Grammars[4],
+			precedences: lr.PrecedenceLevels{
+				{
+					Associativity: lr.LEFT,
+					Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("*")),
+				},
+				{
+					Associativity: lr.LEFT,
+					Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("+")),
+				},
+			},
+			expectedAmbiguous: false,
+			expectedTrees: []string{
+				"(E (E a) + (E (E b) * (E c)))",
+			},
+		},
+		{
+			name:              "Exponential",
+			L:                 mockTokens("a", "+", "b", "+", "c", "+", "d"),
+			G:                 parsertest.Grammars[4],
+			expectedAmbiguous: true,
+			expectedTrees: []string{
+				"(E (E a) + (E (E b) + (E (E c) + (E d))))",
+				"(E (E a) + (E (E (E b) + (E c)) + (E d)))",
+				"(E (E (E a) + (E b)) + (E (E c) + (E d)))",
+				"(E (E (E a) + (E (E b) + (E c))) + (E d))",
+				"(E (E (E (E a) + (E b)) + (E c)) + (E d))",
+			},
+		},
+		{
+			name:              "RightNullable",
+			L:                 mockRightNullable("a", "a", "x", "b"),
+			G:                 rightNullable,
+			expectedAmbiguous: true,
+			expectedTrees: []string{
+				"(S a (S a (S x) (B)) (B b))",
+				"(S a (S a (S x) (B b)) (B))",
+			},
+		},
+		{
+			name:              "Cyclic",
+			L:                 mockTokens("a"),
+			G:                 cyclic,
+			expectedAmbiguous: true,
+			expectedTrees: []string{
+				"(S a)",
+			},
+		},
+		{
+			name: "LexerError",
+			L: &parsertest.MockLexer{
+				NextTokenMocks: []parsertest.NextTokenMock{
+					{OutError: errors.New("cannot read rune")},
+				},
+			},
+			G:             parsertest.Grammars[4],
+			expectedError: "cannot read rune",
+		},
+		{
+			name:          "SyntaxError",
+			L:             mockTokens("a", "+", ")"),
+			G:             parsertest.Grammars[4],
+			expectedError: `test:1:5: unexpected string ")": no action exists in the parsing table for ")"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.L, tc.G, tc.precedences)
+			assert.NoError(t, err)
+
+			f, err := p.Parse()
+
+			if len(tc.expectedError) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAmbiguous, f.Ambiguous())
+
+				trees := []string{}
+				for tree := range f.Trees() {
+					trees = append(trees, bracket(tree))
+				}
+
+				assert.ElementsMatch(t, tc.expectedTrees, trees)
+			} else {
+				assert.Nil(t, f)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
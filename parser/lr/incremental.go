@@ -0,0 +1,481 @@
+package lr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/list"
+	"github.com/moorara/algo/parser"
+)
+
+// LexerFunc creates a lexer for reading an input string.
+// The positions of the tokens must be relative to the beginning of the string,
+// and the lexemes must be the exact substrings of the input that the tokens are read from.
+type LexerFunc func(src string) (lexer.Lexer, error)
+
+// Edit represents a change to the input text of an incremental parser.
+// The characters in the range [Start, End) of the old text are replaced by Text.
+//
+// Start and End are measured in the same unit as lexer.Position.Offset (i.e., runes).
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// String returns a string representation of an edit.
+func (e Edit) String() string {
+	return fmt.Sprintf("[%d, %d) → %q", e.Start, e.End, e.Text)
+}
+
+// IncrementalParser is an LR parser for editing scenarios, where the same input is parsed repeatedly after small changes.
+//
+// Instead of parsing the whole input again after each change, an incremental parser only re-tokenizes the affected region
+// of the input and reuses the subtrees of the previous abstract syntax tree (AST) that are not affected by the change.
+// The resulting AST is always the same as the one built by a full parse of the new input.
+//
+// A subtree is reused if all of its tokens are unchanged, the parser is in the same state as when the subtree was built,
+// and the token following it is also unchanged. Under these conditions, the LR parsing algorithm is guaranteed
+// to build the same subtree again, so the parser pushes the subtree onto the stack in a single step.
+//
+// The lexer is assumed to be deterministic and to look at most one token ahead when matching a token.
+// Tokens are re-read starting from the token before the edit, until a token boundary in the unchanged text is reached.
+type IncrementalParser struct {
+	T *ParsingTable
+	L LexerFunc
+
+	src    []rune
+	root   parser.Node
+	leaves []*parser.LeafNode
+	states map[parser.Node]State // The state on top of the stack when each internal node was pushed.
+}
+
+// NewIncrementalParser creates a new incremental parser for a parsing table and a lexer constructor.
+func NewIncrementalParser(T *ParsingTable, L LexerFunc) *IncrementalParser {
+	return &IncrementalParser{
+		T:      T,
+		L:      L,
+		states: map[parser.Node]State{},
+	}
+}
+
+// Text returns the current input text of the parser.
+func (p *IncrementalParser) Text() string {
+	return string(p.src)
+}
+
+// Parse parses an input string from scratch and returns the root node of its AST.
+// The input and the AST are retained for subsequent calls to Reparse.
+//
+// An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue.
+// If the parser recovers from syntax errors, a partial AST is returned along with the errors.
+// A partial AST is never reused by Reparse.
+func (p *IncrementalParser) Parse(src string) (parser.Node, error) {
+	p.src = []rune(src)
+	return p.fullParse()
+}
+
+// Reparse applies an edit to the current input text and returns the root node of the AST for the new text.
+//
+// prev is the AST returned by the last call to Parse or Reparse.
+// The subtrees of prev that are not affected by the edit become part of the new AST,
+// and the positions of the tokens after the edit are updated in place.
+// Hence, prev must not be used after calling Reparse.
+// If prev is nil or not the latest AST built by the parser, the new text is parsed from scratch.
+//
+// An error is returned if the edit is out of the range of the current text,
+// or if the new text fails to conform to the grammar rules.
+func (p *IncrementalParser) Reparse(prev parser.Node, e Edit) (parser.Node, error) {
+	if e.Start < 0 || e.Start > e.End || e.End > len(p.src) {
+		return nil, fmt.Errorf("invalid edit %s for text of length %d", e, len(p.src))
+	}
+
+	old := p.src
+	text := []rune(e.Text)
+
+	p.src = make([]rune, 0, len(old)-(e.End-e.Start)+len(text))
+	p.src = append(p.src, old[:e.Start]...)
+	p.src = append(p.src, text...)
+	p.src = append(p.src, old[e.End:]...)
+
+	if prev == nil || prev != p.root || p.leaves == nil {
+		return p.fullParse()
+	}
+
+	input, err := p.relex(e, len(text))
+	if err != nil {
+		return p.fullParse()
+	}
+
+	root, err := p.parse(input)
+	if err != nil {
+		return p.fullParse()
+	}
+
+	return root, nil
+}
+
+// fullParse parses the current input text from scratch.
+func (p *IncrementalParser) fullParse() (parser.Node, error) {
+	p.root, p.leaves, p.states = nil, nil, map[parser.Node]State{}
+
+	tokens, err := p.tokenize(p.src, lexer.Position{})
+	if err == nil {
+		input := &reparseInput{
+			leaves: make([]*parser.LeafNode, len(tokens)),
+			lo:     len(tokens),
+			hi:     len(tokens),
+		}
+
+		for i, token := range tokens {
+			input.leaves[i] = &parser.LeafNode{
+				Terminal: token.Terminal,
+				Lexeme:   token.Lexeme,
+				Position: token.Pos,
+			}
+		}
+
+		if root, err := p.parse(input); err == nil {
+			return root, nil
+		}
+	}
+
+	// Let the general LR parser report the errors and recover from them.
+	L, err := p.L(string(p.src))
+	if err != nil {
+		return nil, &parser.ParseError{Cause: err}
+	}
+
+	full := &Parser{L: L, T: p.T}
+	return full.ParseAndBuildAST()
+}
+
+// tokenize reads all tokens from an input string.
+// The positions of the tokens are shifted as if the input string starts at base.
+func (p *IncrementalParser) tokenize(src []rune, base lexer.Position) ([]lexer.Token, error) {
+	tokens := []lexer.Token{}
+	err := p.scan(src, base, func(token lexer.Token) bool {
+		tokens = append(tokens, token)
+		return true
+	})
+
+	return tokens, err
+}
+
+// scan reads tokens from an input string and passes them to the yield function until it returns false.
+// The positions of the tokens are shifted as if the input string starts at base.
+func (p *IncrementalParser) scan(src []rune, base lexer.Position, yield func(lexer.Token) bool) error {
+	L, err := p.L(string(src))
+	if err != nil {
+		return err
+	}
+
+	for {
+		token, err := L.NextToken()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		token.Pos.Offset += base.Offset
+		if base.Line > 0 {
+			if token.Pos.Line == 1 {
+				token.Pos.Column += base.Column - 1
+			}
+			token.Pos.Line += base.Line - 1
+		}
+
+		if !yield(token) {
+			return nil
+		}
+	}
+}
+
+// reparseInput is the input of the parser after an edit.
+// It consists of the leaves of the previous AST before and after the edit, and the new leaves for the edited region.
+//
+// The leaves in [0, lo) are the unchanged leaves before the edit, the leaves in [lo, hi) are the new leaves,
+// and the leaves in [hi, len(leaves)) are the unchanged leaves after the edit.
+// An unchanged leaf at index i corresponds to the leaf at index oldIndex(i) of the previous AST.
+type reparseInput struct {
+	leaves []*parser.LeafNode
+	lo, hi int
+	shift  int // The difference between the indices of the unchanged leaves after the edit in the new and old inputs.
+
+	starts map[int][]*parser.InternalNode // Internal nodes of the previous AST by the index of their first leaf, outermost first.
+	ends   map[parser.Node]int            // The index of the leaf right after each internal node of the previous AST.
+}
+
+// oldIndex maps the index of a leaf in the new input to its index in the previous AST.
+// It returns -1 if the leaf is new.
+func (in *reparseInput) oldIndex(i int) int {
+	switch {
+	case i < in.lo:
+		return i
+	case i >= in.hi:
+		return i - in.shift
+	default:
+		return -1
+	}
+}
+
+// newIndex maps the index of a leaf in the previous AST to its index in the new input.
+// It returns -1 if the leaf is replaced by the edit.
+func (in *reparseInput) newIndex(k int) int {
+	switch {
+	case k <= in.lo:
+		return k
+	case k+in.shift >= in.hi:
+		return k + in.shift
+	default:
+		return -1
+	}
+}
+
+// terminal returns the terminal of the leaf at index i of the new input, or the endmarker if i is past the end.
+func (in *reparseInput) terminal(i int) grammar.Terminal {
+	if i < len(in.leaves) {
+		return in.leaves[i].Terminal
+	}
+
+	return grammar.Endmarker
+}
+
+// relex re-tokenizes the region of the current text affected by an edit and prepares the input for reparsing.
+func (p *IncrementalParser) relex(e Edit, n int) (*reparseInput, error) {
+	old := p.leaves
+
+	// Find the first token that ends at or after the start of the edit, then step back one more token,
+	// since the lexer may have looked ahead into the edited region when matching the preceding token.
+	lo := 0
+	for lo < len(old) && old[lo].Position.Offset+len([]rune(old[lo].Lexeme)) < e.Start {
+		lo++
+	}
+	if lo > 0 {
+		lo--
+	}
+
+	// If the edit starts at or before the end of the first token, the edited text may precede the first token.
+	// In this case, re-tokenizing starts from the beginning of the text, so the edited text is not skipped.
+	var base lexer.Position
+	if lo > 0 {
+		base = old[lo].Position
+	}
+
+	// The difference between the offsets of the unchanged characters after the edit in the new and old texts.
+	delta := n - (e.End - e.Start)
+
+	// Re-tokenize until reaching an old token that starts in the unchanged text after the edit.
+	// From that point on, the lexer reads the same characters and produces the same tokens as before.
+	leaves := []*parser.LeafNode{}
+	hi, k := len(old), lo
+	var sync lexer.Position
+
+	err := p.scan(p.src[base.Offset:], base, func(token lexer.Token) bool {
+		if off := token.Pos.Offset - delta; off >= e.End {
+			for k < len(old) && old[k].Position.Offset < off {
+				k++
+			}
+
+			if k < len(old) && old[k].Position.Offset == off {
+				hi, sync = k, token.Pos
+				return false
+			}
+		}
+
+		leaves = append(leaves, &parser.LeafNode{
+			Terminal: token.Terminal,
+			Lexeme:   token.Lexeme,
+			Position: token.Pos,
+		})
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	input := &reparseInput{
+		leaves: make([]*parser.LeafNode, 0, lo+len(leaves)+len(old)-hi),
+		lo:     lo,
+		hi:     lo + len(leaves),
+		shift:  lo + len(leaves) - hi,
+		starts: map[int][]*parser.InternalNode{},
+		ends:   map[parser.Node]int{},
+	}
+
+	input.leaves = append(input.leaves, old[:lo]...)
+	input.leaves = append(input.leaves, leaves...)
+	input.leaves = append(input.leaves, old[hi:]...)
+
+	// Update the positions of the unchanged tokens after the edit.
+	if hi < len(old) {
+		from := old[hi].Position
+		for _, leaf := range old[hi:] {
+			if leaf.Position.Line == from.Line {
+				leaf.Position.Column += sync.Column - from.Column
+			}
+			leaf.Position.Offset += sync.Offset - from.Offset
+			leaf.Position.Line += sync.Line - from.Line
+		}
+	}
+
+	// Index the internal nodes of the previous AST by the range of leaves they span.
+	i := 0
+	parser.Traverse(p.root, generic.VLR, func(n parser.Node) bool {
+		switch n := n.(type) {
+		case *parser.LeafNode:
+			i++
+		case *parser.InternalNode:
+			input.starts[i] = append(input.starts[i], n)
+		}
+		return true
+	})
+
+	i = 0
+	parser.Traverse(p.root, generic.LRV, func(n parser.Node) bool {
+		switch n := n.(type) {
+		case *parser.LeafNode:
+			i++
+		case *parser.InternalNode:
+			input.ends[n] = i
+		}
+		return true
+	})
+
+	return input, nil
+}
+
+// reusable returns a subtree of the previous AST that can be pushed onto the stack
+// when the parser is in state s and is about to shift the leaf at index i of the input.
+// It returns the outermost reusable subtree along with the number of leaves it spans,
+// or nil if no subtree can be reused at this point.
+func (p *IncrementalParser) reusable(in *reparseInput, i int, s State) (*parser.InternalNode, int) {
+	k := in.oldIndex(i)
+	if k == -1 {
+		return nil, 0
+	}
+
+	for _, n := range in.starts[k] {
+		end := in.ends[n]
+
+		// Empty subtrees are cheap to rebuild.
+		if end == k {
+			continue
+		}
+
+		// All leaves of the subtree must be unchanged.
+		if (k < in.lo && end > in.lo) || in.newIndex(end) == -1 {
+			continue
+		}
+
+		// The parser must be in the same state as when the subtree was built.
+		if st, ok := p.states[n]; !ok || st != s {
+			continue
+		}
+
+		// The lookahead for the last reductions within the subtree must be unchanged.
+		var a grammar.Terminal = grammar.Endmarker
+		if end < len(p.leaves) {
+			a = p.leaves[end].Terminal
+		}
+
+		if !in.terminal(in.newIndex(end)).Equal(a) {
+			continue
+		}
+
+		return n, end - k
+	}
+
+	return nil, 0
+}
+
+// parse implements the LR parsing algorithm over the leaves of an input,
+// pushing reusable subtrees of the previous AST onto the stack in a single step.
+// It does not recover from syntax errors.
+func (p *IncrementalParser) parse(in *reparseInput) (parser.Node, error) {
+	states := map[parser.Node]State{}
+
+	stack := list.NewStack(1024, EqState)
+	stack.Push(State(0))
+
+	nodes := list.NewStack(1024, parser.EqNode)
+
+	for i := 0; ; {
+		s, _ := stack.Peek()
+		a := in.terminal(i)
+
+		action, err := p.T.ACTION(s, a)
+		if err != nil {
+			var pos lexer.Position
+			if i < len(in.leaves) {
+				pos = in.leaves[i].Position
+			}
+
+			return nil, &parser.ParseError{
+				Description: fmt.Sprintf("unexpected terminal %s", a),
+				Cause:       err,
+				Pos:         pos,
+			}
+		}
+
+		switch action.Type {
+		case SHIFT:
+			if n, l := p.reusable(in, i, s); n != nil {
+				// Reusing a subtree is equivalent to a GOTO after all its reductions.
+				next, _ := p.T.GOTO(s, n.NonTerminal)
+				stack.Push(next)
+				nodes.Push(n)
+
+				parser.Traverse(n, generic.VLR, func(m parser.Node) bool {
+					if st, ok := p.states[m]; ok {
+						states[m] = st
+					}
+					return true
+				})
+
+				i += l
+				continue
+			}
+
+			stack.Push(action.State)
+			nodes.Push(in.leaves[i])
+			i++
+
+		case REDUCE:
+			A, β := action.Production.Head, action.Production.Body
+
+			n := &parser.InternalNode{
+				NonTerminal: A,
+				Production:  action.Production,
+			}
+
+			if len(β) > 0 {
+				n.Children = make([]parser.Node, len(β))
+			}
+
+			for j := len(β) - 1; j >= 0; j-- {
+				stack.Pop()
+				n.Children[j], _ = nodes.Pop()
+			}
+
+			t, _ := stack.Peek()
+			next, _ := p.T.GOTO(t, A)
+			stack.Push(next)
+			nodes.Push(n)
+
+			states[n] = t
+
+		case ACCEPT:
+			root, _ := nodes.Pop()
+			p.root, p.leaves, p.states = root, in.leaves, states
+			return root, nil
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package lr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/lexer/dfa"
+	"github.com/moorara/algo/lexer/input"
+	"github.com/moorara/algo/parser"
+)
+
+func getTestLexerFunc(t *testing.T) LexerFunc {
+	rules := []dfa.Rule{
+		{Terminal: "id", Regex: `[a-z][0-9a-z]*`},
+		{Terminal: "+", Regex: `\+`},
+		{Terminal: "*", Regex: `\*`},
+		{Terminal: "(", Regex: `\(`},
+		{Terminal: ")", Regex: `\)`},
+	}
+
+	T, err := dfa.BuildTable(rules, []string{`\s+`})
+	assert.NoError(t, err)
+
+	return func(src string) (lexer.Lexer, error) {
+		in, err := input.New("test", strings.NewReader(src), 4096)
+		if err != nil {
+			return nil, err
+		}
+
+		return &dfa.Lexer{In: in, T: T}, nil
+	}
+}
+
+func TestEdit_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		e              Edit
+		expectedString string
+	}{
+		{
+			name:           "Insert",
+			e:              Edit{Start: 2, End: 2, Text: "+ b"},
+			expectedString: `[2, 2) → "+ b"`,
+		},
+		{
+			name:           "Replace",
+			e:              Edit{Start: 0, End: 4, Text: "(a)"},
+			expectedString: `[0, 4) → "(a)"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.e.String())
+		})
+	}
+}
+
+func TestIncrementalParser_Parse(t *testing.T) {
+	pt := getTestParsingTables()
+	L := getTestLexerFunc(t)
+
+	tests := []struct {
+		name                 string
+		src                  string
+		expectedErrorStrings []string
+	}{
+		{
+			name: "Success",
+			src:  "a + b * (c + d)",
+		},
+		{
+			name: "InvalidCharacter",
+			src:  "a + b $ c",
+			expectedErrorStrings: []string{
+				`test:1:7: unexpected character "$"`,
+			},
+		},
+		{
+			name: "InvalidInput",
+			src:  "a + * b",
+			expectedErrorStrings: []string{
+				`test:1:5: unexpected string "*": no action exists in the parsing table for ACTION[6, "*"]`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := L(tc.src)
+			assert.NoError(t, err)
+
+			full := &Parser{L: l, T: pt[0]}
+			expectedAST, expectedErr := full.ParseAndBuildAST()
+
+			p := NewIncrementalParser(pt[0], L)
+			ast, err := p.Parse(tc.src)
+
+			assert.Equal(t, tc.src, p.Text())
+
+			if len(tc.expectedErrorStrings) == 0 {
+				assert.NoError(t, err)
+				assert.True(t, ast.Equal(expectedAST))
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, expectedErr.Error(), err.Error())
+				for _, s := range tc.expectedErrorStrings {
+					assert.Contains(t, err.Error(), s)
+				}
+			}
+		})
+	}
+}
+
+func TestIncrementalParser_Reparse(t *testing.T) {
+	pt := getTestParsingTables()
+	L := getTestLexerFunc(t)
+
+	tests := []struct {
+		name          string
+		src           string
+		edits         []Edit
+		expectedTexts []string
+	}{
+		{
+			name: "AppendToken",
+			src:  "a + b * c",
+			edits: []Edit{
+				{Start: 9, End: 9, Text: " + d"},
+				{Start: 13, End: 13, Text: "e"},
+			},
+			expectedTexts: []string{
+				"a + b * c + d",
+				"a + b * c + de",
+			},
+		},
+		{
+			name: "ReplaceToken",
+			src:  "a + b * c + d",
+			edits: []Edit{
+				{Start: 4, End: 5, Text: "bb"},
+				{Start: 0, End: 1, Text: "(x + y)"},
+			},
+			expectedTexts: []string{
+				"a + bb * c + d",
+				"(x + y) + bb * c + d",
+			},
+		},
+		{
+			name: "MultipleLines",
+			src:  "a +\nb *\nc +\nd",
+			edits: []Edit{
+				{Start: 4, End: 5, Text: "(e\n+ f)"},
+				{Start: 0, End: 4, Text: ""},
+			},
+			expectedTexts: []string{
+				"a +\n(e\n+ f) *\nc +\nd",
+				"(e\n+ f) *\nc +\nd",
+			},
+		},
+		{
+			name: "MergeTokens",
+			src:  "ab + c",
+			edits: []Edit{
+				{Start: 2, End: 5, Text: ""},
+				{Start: 1, End: 1, Text: " * "},
+			},
+			expectedTexts: []string{
+				"abc",
+				"a * bc",
+			},
+		},
+		{
+			name: "EditBeforeFirstToken",
+			src:  " a + b",
+			edits: []Edit{
+				{Start: 0, End: 1, Text: "x"},
+				{Start: 0, End: 0, Text: "(y) * "},
+			},
+			expectedTexts: []string{
+				"xa + b",
+				"(y) * xa + b",
+			},
+		},
+		{
+			name: "EditInWhitespace",
+			src:  "  a + b  ",
+			edits: []Edit{
+				{Start: 1, End: 2, Text: ""},
+				{Start: 7, End: 8, Text: " * c "},
+				{Start: 2, End: 3, Text: "\n\n"},
+			},
+			expectedTexts: []string{
+				" a + b  ",
+				" a + b  * c ",
+				" a\n\n+ b  * c ",
+			},
+		},
+		{
+			name: "SyntaxError",
+			src:  "a + b * c",
+			edits: []Edit{
+				{Start: 4, End: 5, Text: "+"},
+				{Start: 4, End: 5, Text: "d"},
+			},
+			expectedTexts: []string{
+				"a + + * c",
+				"a + d * c",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewIncrementalParser(pt[0], L)
+			ast, _ := p.Parse(tc.src)
+
+			for i, e := range tc.edits {
+				l, err := L(tc.expectedTexts[i])
+				assert.NoError(t, err)
+
+				full := &Parser{L: l, T: pt[0]}
+				expectedAST, expectedErr := full.ParseAndBuildAST()
+
+				ast, err = p.Reparse(ast, e)
+				assert.Equal(t, tc.expectedTexts[i], p.Text())
+
+				if expectedErr == nil {
+					assert.NoError(t, err)
+					assert.True(t, ast.Equal(expectedAST), "expected %s, got %s", expectedAST, ast)
+				} else {
+					assert.Error(t, err)
+					assert.Equal(t, expectedErr.Error(), err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestIncrementalParser_Reparse_ReusesSubtrees(t *testing.T) {
+	pt := getTestParsingTables()
+	L := getTestLexerFunc(t)
+
+	p := NewIncrementalParser(pt[0], L)
+	prev, err := p.Parse("(a * b) + (c * d)")
+	assert.NoError(t, err)
+
+	// E → E + T
+	left := prev.(*parser.InternalNode).Children[0]
+
+	t.Run("EditAfterSubtree", func(t *testing.T) {
+		ast, err := p.Reparse(prev, Edit{Start: 16, End: 16, Text: " * e"})
+		assert.NoError(t, err)
+		assert.Equal(t, "(a * b) + (c * d * e)", p.Text())
+
+		// The subtree before the edit is reused as is.
+		assert.Same(t, left, ast.(*parser.InternalNode).Children[0])
+		prev = ast
+	})
+
+	t.Run("EditBeforeSubtree", func(t *testing.T) {
+		right := prev.(*parser.InternalNode).Children[2]
+
+		ast, err := p.Reparse(prev, Edit{Start: 1, End: 2, Text: "xyz"})
+		assert.NoError(t, err)
+		assert.Equal(t, "(xyz * b) + (c * d * e)", p.Text())
+
+		// The subtree after the edit is reused with updated positions.
+		assert.Same(t, right, ast.(*parser.InternalNode).Children[2])
+		assert.Equal(t, lexer.Position{Filename: "test", Offset: 12, Line: 1, Column: 13}, right.Pos())
+	})
+
+	t.Run("InvalidEdit", func(t *testing.T) {
+		ast, err := p.Reparse(prev, Edit{Start: 10, End: 100, Text: ""})
+		assert.Nil(t, ast)
+		assert.EqualError(t, err, `invalid edit [10, 100) → "" for text of length 23`)
+	})
+
+	t.Run("NilPrev", func(t *testing.T) {
+		ast, err := p.Reparse(nil, Edit{Start: 0, End: 0, Text: "a + "})
+		assert.NoError(t, err)
+		assert.Equal(t, "a + (xyz * b) + (c * d * e)", p.Text())
+		assert.Equal(t, lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1}, ast.Pos())
+	})
+}
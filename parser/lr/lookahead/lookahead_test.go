@@ -1,13 +1,17 @@
 package lookahead
 
 import (
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/moorara/algo/errors"
+	"github.com/moorara/algo/generic"
 	"github.com/moorara/algo/grammar"
 	"github.com/moorara/algo/internal/parsertest"
 	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
 	"github.com/moorara/algo/parser/lr"
 )
 
@@ -44,6 +48,12 @@ Cause:      Multiple conflicts in the parsing table:
               2. Shift/Reduce conflict in ACTION[2, "+"]
               3. Shift/Reduce conflict in ACTION[3, "*"]
               4. Shift/Reduce conflict in ACTION[3, "+"]
+Examples:   The parser reaches each conflict with these inputs:
+              1. "id" "*" "id"•"*" "id"
+              2. "id" "*" "id"•"+" "id"
+              3. "id" "+" "id"•"*" "id"
+              4. "id" "+" "id"•"+" "id"
+            A single input means it reaches the conflict with every action, so the grammar is likely ambiguous.
 Resolution: Specify associativity and precedence for these Terminals/Productions:
               • "*" vs. "*", "+"
               • "+" vs. "*", "+"
@@ -68,3 +78,130 @@ Resolution: Specify associativity and precedence for these Terminals/Productions
 		})
 	}
 }
+
+// mockTokens creates a mock lexer that returns a token for each lexeme on a single line.
+func mockTokens(lexemes ...string) *parsertest.MockLexer {
+	L := new(parsertest.MockLexer)
+	col := 1
+
+	for _, lexeme := range lexemes {
+		terminal := grammar.Terminal(lexeme)
+		if lexeme >= "a" && lexeme <= "z" {
+			terminal = "id"
+		}
+
+		L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{
+			OutToken: lexer.Token{
+				Terminal: terminal,
+				Lexeme:   lexeme,
+				Pos:      lexer.Position{Filename: "test", Offset: col - 1, Line: 1, Column: col},
+			},
+		})
+
+		col += len(lexeme) + 1
+	}
+
+	L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{OutError: io.EOF})
+
+	return L
+}
+
+func TestParser_ErrorRecovery(t *testing.T) {
+	G := grammar.NewCFG(
+		[]grammar.Terminal{"=", "+", ";", "id", lr.ErrorTerminal},
+		[]grammar.NonTerminal{"stmts", "stmt", "expr"},
+		[]*grammar.Production{
+			{Head: "stmts", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("stmts"), grammar.NonTerminal("stmt")}},                                        // stmts → stmts stmt
+			{Head: "stmts", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("stmt")}},                                                                      // stmts → stmt
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id"), grammar.Terminal("="), grammar.NonTerminal("expr"), grammar.Terminal(";")}}, // stmt → id = expr ;
+			{Head: "stmt", Body: grammar.String[grammar.Symbol]{lr.ErrorTerminal, grammar.Terminal(";")}},                                                           // stmt → error ;
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.Terminal("id")}},                        // expr → expr + id
+			{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}},                                                                            // expr → id
+		},
+		"stmts",
+	)
+
+	tests := []struct {
+		name           string
+		G              *grammar.CFG
+		L              lexer.Lexer
+		expectedLeaves []string
+		expectedError  string
+		expectedType   error
+	}{
+		{
+			name:           "NoError",
+			G:              G,
+			L:              mockTokens("a", "=", "b", "+", "c", ";"),
+			expectedLeaves: []string{"a", "=", "b", "+", "c", ";"},
+			expectedError:  "",
+		},
+		{
+			name:           "OneError",
+			G:              G,
+			L:              mockTokens("a", "=", "b", ";", "c", "=", "+", ";", "d", "=", "e", ";"),
+			expectedLeaves: []string{"a", "=", "b", ";", "<error>", ";", "d", "=", "e", ";"},
+			expectedError:  "test:1:13: unexpected string \"+\": no action exists in the parsing table for ACTION[6, \"+\"]\n",
+			expectedType:   &errors.MultiError{},
+		},
+		{
+			name:           "MultipleErrors",
+			G:              G,
+			L:              mockTokens("a", "=", ";", "b", "=", "c", ";", "d", "+", "e", ";"),
+			expectedLeaves: []string{"<error>", ";", "b", "=", "c", ";", "<error>", ";"},
+			expectedError: "test:1:5: unexpected string \";\": no action exists in the parsing table for ACTION[6, \";\"]\n" +
+				"test:1:17: unexpected string \"+\": no action exists in the parsing table for ACTION[10, \"+\"]\n",
+			expectedType: &errors.MultiError{},
+		},
+		{
+			name:           "Unrecoverable",
+			G:              G,
+			L:              mockTokens("a", "=", "b"),
+			expectedLeaves: nil,
+			expectedError:  "unexpected string \"\": no action exists in the parsing table for ACTION[9, $]",
+			expectedType:   &parser.ParseError{},
+		},
+		{
+			name:           "NoErrorProduction",
+			G:              parsertest.Grammars[3],
+			L:              mockTokens("a", "+", "+", "b"),
+			expectedLeaves: nil,
+			expectedError:  "test:1:5: unexpected string \"+\": no action exists in the parsing table for ACTION[5, \"+\"]",
+			expectedType:   &parser.ParseError{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.L, tc.G, lr.PrecedenceLevels{})
+			assert.NoError(t, err)
+
+			root, err := p.ParseAndBuildAST()
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.IsType(t, tc.expectedType, err)
+			}
+
+			if tc.expectedLeaves == nil {
+				assert.Nil(t, root)
+			} else {
+				var leaves []string
+				parser.Traverse(root, generic.VLR, func(n parser.Node) bool {
+					if leaf, ok := n.(*parser.LeafNode); ok {
+						if leaf.Terminal == lr.ErrorTerminal {
+							leaves = append(leaves, "<error>")
+						} else {
+							leaves = append(leaves, leaf.Lexeme)
+						}
+					}
+					return true
+				})
+
+				assert.Equal(t, tc.expectedLeaves, leaves)
+			}
+		})
+	}
+}
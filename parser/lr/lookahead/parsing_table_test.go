@@ -43,6 +43,12 @@ Cause:      Multiple conflicts in the parsing table:
               2. Shift/Reduce conflict in ACTION[2, "+"]
               3. Shift/Reduce conflict in ACTION[3, "*"]
               4. Shift/Reduce conflict in ACTION[3, "+"]
+Examples:   The parser reaches each conflict with these inputs:
+              1. "id" "*" "id"•"*" "id"
+              2. "id" "*" "id"•"+" "id"
+              3. "id" "+" "id"•"*" "id"
+              4. "id" "+" "id"•"+" "id"
+            A single input means it reaches the conflict with every action, so the grammar is likely ambiguous.
 Resolution: Specify associativity and precedence for these Terminals/Productions:
               • "*" vs. "*", "+"
               • "+" vs. "*", "+"
@@ -60,12 +60,23 @@ import (
 	"fmt"
 	"io"
 
+	errs "github.com/moorara/algo/errors"
 	"github.com/moorara/algo/grammar"
 	"github.com/moorara/algo/lexer"
 	"github.com/moorara/algo/list"
 	"github.com/moorara/algo/parser"
 )
 
+// ErrorTerminal is a special terminal reserved for error recovery.
+// It can be used in the body of production rules to specify where the parser may resume
+// after a syntax error, similar to the error token in yacc (e.g., "stmt → error ;").
+// A grammar using it must declare it in its set of terminals, just like any other terminal.
+const ErrorTerminal = grammar.Terminal("error")
+
+// recoveryShifts is the number of tokens that must be shifted after recovering from
+// a syntax error before a new syntax error is reported. This prevents cascading errors.
+const recoveryShifts = 3
+
 // Parser is a general LR parser for LR(1) grammars.
 // It implements the parser.Parser interface.
 type Parser struct {
@@ -110,6 +121,15 @@ func (p *Parser) nextToken() (lexer.Token, error) {
  *             call error-recovery routine;
  *           }
  *         }
+ *
+ * The error-recovery routine follows the yacc approach:
+ *
+ *         pop states off the stack until a state s with ACTION[s,error] = shift t is found;
+ *         push t onto the stack;
+ *         discard input symbols until one is found that has a non-error action in state t;
+ *
+ * If no state on the stack can shift the error terminal, or the end of input is reached
+ * while discarding input symbols, the parser gives up.
  */
 
 // Parse implements the LR parsing algorithm.
@@ -123,16 +143,65 @@ func (p *Parser) nextToken() (lexer.Token, error) {
 // The Parse method invokes the provided functions each time a token or a production rule is matched.
 // This allows the caller to process or react to each step of the parsing process.
 //
+// If the grammar has production rules with the ErrorTerminal, the parser recovers from syntax errors
+// and continues parsing. In this case, a token with the ErrorTerminal is passed to the TokenFunc
+// in place of the erroneous input. Grammar symbols discarded from the stack during recovery are not reported.
+//
 // An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue,
 // or if any of the provided functions return an error, indicating a semantic issue.
+// If the parser never recovers from a syntax error, the error is returned as a *parser.ParseError.
+// Otherwise, all syntax errors encountered are collected and returned in an *errors.MultiError.
 func (p *Parser) Parse(tokenF parser.TokenFunc, prodF parser.ProductionFunc) error {
+	_, err := p.parse(tokenF, prodF, nil)
+	return err
+}
+
+// parse implements the LR parsing algorithm with error recovery.
+// The discardF function, if provided, is invoked for every grammar symbol popped off the stack during error recovery.
+// It returns true if the input is accepted, possibly after recovering from syntax errors.
+func (p *Parser) parse(tokenF parser.TokenFunc, prodF parser.ProductionFunc, discardF func()) (bool, error) {
+	// The syntax errors the parser has resumed parsing after.
+	var syntaxErr error
+
+	// The syntax error the parser is recovering from, until it resumes parsing.
+	var pending *parser.ParseError
+
+	// resume records the pending syntax error once the parser resumes parsing after it.
+	resume := func() {
+		if pending != nil {
+			syntaxErr, pending = errs.Append(syntaxErr, pending), nil
+		}
+	}
+
+	// stop returns the error for giving up on parsing.
+	// If the parser has never resumed parsing after a syntax error, a single error is returned as is.
+	stop := func(err *parser.ParseError) error {
+		if err == nil {
+			err, pending = pending, nil
+		} else {
+			resume()
+		}
+
+		switch {
+		case err == nil:
+			return syntaxErr
+		case syntaxErr == nil:
+			return err
+		default:
+			return errs.Append(syntaxErr, err)
+		}
+	}
+
+	// The number of tokens yet to be shifted before reporting new syntax errors.
+	errStatus := 0
+
 	stack := list.NewStack(1024, EqState)
 	stack.Push(State(0)) // BuildStateMap ensures state 0 always includes the initial item "S′ → •S"
 
 	// Read the first input token.
 	token, err := p.nextToken()
 	if err != nil {
-		return &parser.ParseError{Cause: err}
+		return false, &parser.ParseError{Cause: err}
 	}
 
 	for {
@@ -141,31 +210,102 @@ func (p *Parser) Parse(tokenF parser.TokenFunc, prodF parser.ProductionFunc) err
 
 		action, err := p.T.ACTION(s, a)
 		if err != nil {
-			return &parser.ParseError{
+			perr := &parser.ParseError{
 				Description: fmt.Sprintf("unexpected string %q", token.Lexeme),
 				Cause:       err,
 				Pos:         token.Pos,
 			}
+
+			// Conflicts in the parsing table cannot be recovered from.
+			if cerr := new(ConflictError); errors.As(err, &cerr) {
+				return false, stop(perr)
+			}
+
+			// Without any action on the error terminal, the parser cannot recover from syntax errors.
+			if !p.recoverable() {
+				return false, stop(perr)
+			}
+
+			// A new syntax error is not reported until enough tokens are shifted after the last recovery.
+			if errStatus > 0 {
+				perr = nil
+			}
+
+			// No token has been shifted since the last recovery, so the current token is discarded.
+			if errStatus == recoveryShifts {
+				if a == grammar.Endmarker {
+					return false, stop(nil)
+				}
+
+				if token, err = p.nextToken(); err != nil {
+					return false, stop(&parser.ParseError{Cause: err})
+				}
+
+				continue
+			}
+
+			errStatus = recoveryShifts
+
+			// Pop states off the stack until a state that can shift the error terminal is found.
+			var shift *Action
+			for shift == nil {
+				s, _ := stack.Peek()
+				if action, err := p.T.ACTION(s, ErrorTerminal); err == nil && action.Type == SHIFT {
+					shift = action
+				} else if stack.Size() == 1 {
+					return false, stop(perr)
+				} else {
+					stack.Pop()
+					if discardF != nil {
+						discardF()
+					}
+				}
+			}
+
+			stack.Push(shift.State)
+
+			if perr != nil {
+				pending = perr
+			}
+
+			// Yield the error token.
+			if tokenF != nil {
+				errToken := lexer.Token{Terminal: ErrorTerminal, Pos: token.Pos}
+				if err := tokenF(&errToken); err != nil {
+					return false, stop(&parser.ParseError{
+						Cause: err,
+						Pos:   token.Pos,
+					})
+				}
+			}
+
+			continue
 		}
 
 		switch action.Type {
 		case SHIFT:
 			stack.Push(action.State)
 
+			if errStatus > 0 {
+				errStatus--
+			}
+
+			resume()
+
 			// Yield the token.
 			if tokenF != nil {
 				if err := tokenF(&token); err != nil {
-					return &parser.ParseError{
+					return false, stop(&parser.ParseError{
 						Cause: err,
 						Pos:   token.Pos,
-					}
+					})
 				}
 			}
 
 			// Read the next input token.
 			token, err = p.nextToken()
 			if err != nil {
-				return &parser.ParseError{Cause: err}
+				return false, stop(&parser.ParseError{Cause: err})
 			}
 
 		case REDUCE:
@@ -186,18 +326,28 @@ func (p *Parser) Parse(tokenF parser.TokenFunc, prodF parser.ProductionFunc) err
 			// Yield the production.
 			if prodF != nil {
 				if err := prodF(action.Production); err != nil {
-					return &parser.ParseError{Cause: err}
+					return false, stop(&parser.ParseError{Cause: err})
 				}
 			}
 
 		case ACCEPT:
 			// Accept the input string.
-			return nil
+			resume()
+			return true, syntaxErr
+		}
+	}
+}
 
-		case ERROR:
-			// TODO: This is unreachable currently, since T.ACTION handles the error.
+// recoverable determines whether or not the parser can recover from syntax errors.
+// This is the case if any state in the parsing table has an action on the ErrorTerminal.
+func (p *Parser) recoverable() bool {
+	for _, s := range p.T.States {
+		if _, ok := p.T.getActions(s, ErrorTerminal); ok {
+			return true
 		}
 	}
+
+	return false
 }
 
 // ParseAndBuildAST implements the LR parsing algorithm.
@@ -209,11 +359,14 @@ func (p *Parser) Parse(tokenF parser.TokenFunc, prodF parser.ProductionFunc) err
 // representing the syntactic structure of the input string.
 //
 // An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue.
+//
+// If the parser recovers from syntax errors, a partial AST is returned along with the errors.
+// The erroneous parts of the input are represented by leaf nodes with the ErrorTerminal.
 func (p *Parser) ParseAndBuildAST() (parser.Node, error) {
 	// Stack for constructing the abstract syntax tree.
 	nodes := list.NewStack(1024, parser.EqNode)
 
-	err := p.Parse(
+	accepted, err := p.parse(
 		func(token *lexer.Token) error {
 			nodes.Push(&parser.LeafNode{
 				Terminal: token.Terminal,
@@ -238,16 +391,19 @@ func (p *Parser) ParseAndBuildAST() (parser.Node, error) {
 
 			return nil
 		},
+		func() {
+			nodes.Pop()
+		},
 	)
 
-	if err != nil {
+	if !accepted {
 		return nil, err
 	}
 
 	// The nodes stack only contains the root of AST at this point.
 	root, _ := nodes.Pop()
 
-	return root, nil
+	return root, err
 }
 
 // ParseAndEvaluate implements the LR parsing algorithm.
@@ -261,11 +417,14 @@ func (p *Parser) ParseAndBuildAST() (parser.Node, error) {
 //
 // An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue,
 // or if the evaluation function returns an error, indicating a semantic issue.
+//
+// If the parser recovers from syntax errors, the value of the ErrorTerminal is its empty lexeme,
+// and the evaluated value is returned along with the errors.
 func (p *Parser) ParseAndEvaluate(eval EvaluateFunc) (*Value, error) {
 	// Stack for constructing the abstract syntax tree.
 	nodes := list.NewStack[*Value](1024, nil)
 
-	err := p.Parse(
+	accepted, err := p.parse(
 		func(token *lexer.Token) error {
 			copy := token.Pos
 			nodes.Push(&Value{
@@ -299,16 +458,19 @@ func (p *Parser) ParseAndEvaluate(eval EvaluateFunc) (*Value, error) {
 
 			return nil
 		},
+		func() {
+			nodes.Pop()
+		},
 	)
 
-	if err != nil {
+	if !accepted {
 		return nil, err
 	}
 
 	// The nodes stack only contains the root of AST at this point.
 	root, _ := nodes.Pop()
 
-	return root, nil
+	return root, err
 }
 
 // EvaluateFunc is a function invoked every time a production rule
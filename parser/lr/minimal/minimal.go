@@ -0,0 +1,40 @@
+// Package minimal provides data structures and algorithms for building minimal LR(1) parsers.
+// A minimal LR(1) parser is a bottom-up parser for the class of LR(1) grammars.
+//
+// A canonical LR(1) parser accepts all LR(1) grammars, but its parsing table is often too large for practical use.
+// An LALR(1) parser merges all states with identical cores, which keeps the table as small as an SLR table,
+// but the merging may introduce reduce/reduce conflicts that do not exist in the canonical LR(1) table.
+//
+// A minimal LR(1) parser, similar to canonical LR(1), uses the LR(1) items to construct the state machine (DFA).
+// Following Pager's practical general method, a new state is merged with an existing state with the same core
+// only if the two states are weakly compatible, meaning the merge cannot introduce any new reduce/reduce conflict.
+// The resulting parsing table accepts exactly the same class of grammars as canonical LR(1),
+// while its number of states is the same as LALR(1) for all LALR(1) grammars and close to it for others.
+//
+// For more details on parsing theory, refer to
+// "A Practical General Method for Constructing LR(k) Parsers" by David Pager and
+// "Parsing Techniques: A Practical Guide (2nd Edition)" by Dick Grune and Ceriel J.H. Jacobs.
+package minimal
+
+import (
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+	"github.com/moorara/algo/parser/lr"
+)
+
+// New creates a new minimal LR(1) parser for a given context-free grammar (CFG).
+// It requires a lexer for lexical analysis, which reads the input tokens (terminal symbols).
+func New(L lexer.Lexer, G *grammar.CFG, precedences lr.PrecedenceLevels) (*lr.Parser, error) {
+	T, err := BuildParsingTable(G, precedences)
+	if err != nil {
+		return nil, &parser.ParseError{
+			Cause: err,
+		}
+	}
+
+	return &lr.Parser{
+		L: L,
+		T: T,
+	}, nil
+}
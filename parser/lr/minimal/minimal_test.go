@@ -0,0 +1,138 @@
+package minimal
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser/lr"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name                 string
+		L                    lexer.Lexer
+		G                    *grammar.CFG
+		precedences          lr.PrecedenceLevels
+		expectedErrorStrings []string
+	}{
+		{
+			name:        "S→CC",
+			L:           nil,
+			G:           parsertest.Grammars[1],
+			precedences: lr.PrecedenceLevels{},
+		},
+		{
+			name:        "NotLALR",
+			L:           nil,
+			G:           notLALR,
+			precedences: lr.PrecedenceLevels{},
+		},
+		{
+			name:        "E→E+E",
+			L:           nil,
+			G:           parsertest.Grammars[4],
+			precedences: lr.PrecedenceLevels{},
+			expectedErrorStrings: []string{
+				`Error:      Ambiguous Grammar`,
+				`Shift/Reduce conflict in ACTION`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, tc.G.Verify())
+			p, err := New(tc.L, tc.G, tc.precedences)
+
+			if len(tc.expectedErrorStrings) == 0 {
+				assert.NotNil(t, p)
+				assert.NoError(t, err)
+			} else {
+				assert.Nil(t, p)
+				assert.Error(t, err)
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_Parse(t *testing.T) {
+	// mockTokens creates a mock lexer that returns a token for each terminal on a single line.
+	mockTokens := func(terminals ...grammar.Terminal) *parsertest.MockLexer {
+		L := new(parsertest.MockLexer)
+		for i, a := range terminals {
+			L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{
+				OutToken: lexer.Token{
+					Terminal: a,
+					Lexeme:   string(a),
+					Pos:      lexer.Position{Filename: "test", Offset: 2 * i, Line: 1, Column: 2*i + 1},
+				},
+			})
+		}
+
+		L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{OutError: io.EOF})
+
+		return L
+	}
+
+	tests := []struct {
+		name                string
+		L                   lexer.Lexer
+		expectedProductions []string
+		expectedError       string
+	}{
+		{
+			name:                "a_c_d",
+			L:                   mockTokens("a", "c", "d"),
+			expectedProductions: []string{`A → "c"`, `S → "a" A "d"`},
+		},
+		{
+			name:                "a_c_e",
+			L:                   mockTokens("a", "c", "e"),
+			expectedProductions: []string{`B → "c"`, `S → "a" B "e"`},
+		},
+		{
+			name:                "b_c_d",
+			L:                   mockTokens("b", "c", "d"),
+			expectedProductions: []string{`B → "c"`, `S → "b" B "d"`},
+		},
+		{
+			name:                "b_c_e",
+			L:                   mockTokens("b", "c", "e"),
+			expectedProductions: []string{`A → "c"`, `S → "b" A "e"`},
+		},
+		{
+			name:          "Invalid_Input",
+			L:             mockTokens("a", "d"),
+			expectedError: `test:1:3: unexpected string "d"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.L, notLALR, lr.PrecedenceLevels{})
+			assert.NoError(t, err)
+
+			var prods []string
+			err = p.Parse(nil, func(prod *grammar.Production) error {
+				prods = append(prods, prod.String())
+				return nil
+			})
+
+			if len(tc.expectedError) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedProductions, prods)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
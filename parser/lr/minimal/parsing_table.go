@@ -0,0 +1,274 @@
+package minimal
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/parser/lr"
+)
+
+// BuildParsingTable constructs a parsing table for a minimal LR(1) parser.
+func BuildParsingTable(G *grammar.CFG, precedences lr.PrecedenceLevels) (*lr.ParsingTable, error) {
+	/*
+	 * INPUT:  An augmented grammar G′.
+	 * OUTPUT: The minimal LR(1) parsing table functions ACTION and GOTO for G′.
+	 */
+
+	G1 := lr.NewGrammarWithLR1Kernel(G)
+
+	M := buildAutomaton(G1)    // 1. Construct the kernels of the minimal LR(1) collection of sets of items for G′.
+	S := lr.BuildStateMap(M.C) // Map sets of LR(1) items to state numbers.
+
+	// Map the kernels to their state numbers.
+	states := make([]lr.State, len(M.kernels))
+	for i, K := range M.kernels {
+		states[i] = S.FindItemSet(K)
+	}
+
+	terminals := G1.OrderTerminals()
+	_, _, nonTerminals := G1.OrderNonTerminals()
+	table := lr.NewParsingTable(S.States(), terminals, nonTerminals, precedences)
+
+	// 2. State i is constructed from I.
+	for k, K := range M.kernels {
+		i := states[k]
+
+		// The parsing action for state i is determined as follows:
+
+		for item := range G1.CLOSURE(K).All() {
+			item := item.(*lr.Item1)
+
+			// If "A → α•aβ, b" is in Iᵢ and GOTO(Iᵢ,a) = Iⱼ (a must be a terminal)
+			if X, ok := item.DotSymbol(); ok {
+				if a, ok := X.(grammar.Terminal); ok {
+					// Set ACTION[i,a] to SHIFT j
+					table.AddACTION(i, a, &lr.Action{
+						Type:  lr.SHIFT,
+						State: states[M.trans[k][a]],
+					})
+				}
+			}
+
+			// If "A → α•, a" is in Iᵢ (A ≠ S′)
+			if item.IsComplete() && !item.IsFinal() {
+				a := item.Lookahead
+
+				// Set ACTION[i,a] to REDUCE A → α
+				table.AddACTION(i, a, &lr.Action{
+					Type:       lr.REDUCE,
+					Production: item.Production,
+				})
+			}
+
+			// If "S′ → S•, $" is in Iᵢ
+			if item.IsFinal() {
+				// Set ACTION[i,$] to ACCEPT
+				table.AddACTION(i, grammar.Endmarker, &lr.Action{
+					Type: lr.ACCEPT,
+				})
+			}
+
+			// If any conflicting actions result from the above rules, the grammar is not LR(1).
+			// The table.Error() method will list all conflicts, if any exist.
+		}
+
+		// 3. The goto transitions for state i are constructed for all non-terminals A using the rule:
+		// If GOTO(Iᵢ,A) = Iⱼ
+		for _, A := range nonTerminals {
+			if j, ok := M.trans[k][A]; ok {
+				// Set GOTO[i,A] = j
+				table.SetGOTO(i, A, states[j])
+			}
+		}
+
+		// 4. All entries not defined by rules (2) and (3) are made ERROR.
+	}
+
+	// 5. The initial state of the parser is the one constructed from the set of items containing "S′ → •S, $".
+
+	// Try resolving any conflicts in the ACTION parsing table.
+	if err := table.ResolveConflicts(); err != nil {
+		return table, lr.AddCounterexamples(G1.CFG, table, err)
+	}
+
+	return table, nil
+}
+
+// automaton is the minimal LR(1) automaton for an augmented grammar.
+// Each state is identified by its kernel, and the states are numbered in the order they are created.
+type automaton struct {
+	C       lr.ItemSetCollection
+	kernels []lr.ItemSet
+	trans   []map[grammar.Symbol]int
+}
+
+// buildAutomaton constructs the kernels of the minimal LR(1) collection of sets of items for an augmented grammar.
+//
+// The construction follows the canonical LR(1) construction with one difference.
+// When a new kernel has the same core as an existing one and the two are weakly compatible,
+// the new kernel is merged into the existing one instead of creating a new state.
+// If merging adds new lookaheads to the existing kernel, its successors are computed again,
+// so the new lookaheads are propagated through the automaton.
+func buildAutomaton(G1 *lr.Grammar) *automaton {
+	terminals := G1.OrderTerminals()
+	_, _, nonTerminals := G1.OrderNonTerminals()
+
+	symbols := make([]grammar.Symbol, 0, len(terminals)+len(nonTerminals))
+	for _, a := range terminals {
+		symbols = append(symbols, a)
+	}
+	for _, A := range nonTerminals {
+		symbols = append(symbols, A)
+	}
+
+	M := &automaton{
+		kernels: []lr.ItemSet{lr.NewItemSet(G1.Initial())},
+		trans:   []map[grammar.Symbol]int{{}},
+	}
+
+	// Kernels indexed by their cores.
+	cores := map[string][]int{
+		core(M.kernels[0]): {0},
+	}
+
+	queue := []int{0}
+	queued := map[int]bool{0: true}
+
+	for len(queue) > 0 {
+		i := queue[0]
+		queue, queued[i] = queue[1:], false
+
+		for _, X := range symbols {
+			J := G1.GOTO(M.kernels[i], X)
+			if J.IsEmpty() {
+				continue
+			}
+
+			key := core(J)
+			j := -1
+
+			// Find an existing kernel that already includes all items of J.
+			for _, k := range cores[key] {
+				if M.kernels[k].IsSuperset(J) {
+					j = k
+					break
+				}
+			}
+
+			// Find an existing kernel that J can be merged into.
+			if j == -1 {
+				for _, k := range cores[key] {
+					if weaklyCompatible(M.kernels[k], J) {
+						j = k
+						M.kernels[k].Add(generic.Collect1(J.All())...)
+
+						if !queued[k] {
+							queue, queued[k] = append(queue, k), true
+						}
+
+						break
+					}
+				}
+			}
+
+			// Create a new state for J.
+			if j == -1 {
+				j = len(M.kernels)
+				M.kernels = append(M.kernels, J)
+				M.trans = append(M.trans, map[grammar.Symbol]int{})
+				cores[key] = append(cores[key], j)
+				queue, queued[j] = append(queue, j), true
+			}
+
+			M.trans[i][X] = j
+		}
+	}
+
+	M.C = lr.NewItemSetCollection(M.kernels...)
+
+	return M
+}
+
+// core returns a string representation of the core of a set of LR(1) items,
+// which consists of the productions and the dot positions, excluding the lookaheads.
+func core(I lr.ItemSet) string {
+	items := []string{}
+	seen := map[string]bool{}
+
+	for item := range I.All() {
+		if s := item.(*lr.Item1).Item0().String(); !seen[s] {
+			seen[s] = true
+			items = append(items, s)
+		}
+	}
+
+	sort.Strings(items)
+
+	return strings.Join(items, "\n")
+}
+
+// lookaheads groups the lookaheads of a set of LR(1) items by the core of the items.
+func lookaheads(I lr.ItemSet) map[string]map[grammar.Terminal]bool {
+	L := map[string]map[grammar.Terminal]bool{}
+
+	for item := range I.All() {
+		item := item.(*lr.Item1)
+		s := item.Item0().String()
+
+		if L[s] == nil {
+			L[s] = map[grammar.Terminal]bool{}
+		}
+
+		L[s][item.Lookahead] = true
+	}
+
+	return L
+}
+
+// intersect determines whether or not two sets of lookaheads have any lookahead in common.
+func intersect(lhs, rhs map[grammar.Terminal]bool) bool {
+	for a := range lhs {
+		if rhs[a] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// weaklyCompatible determines whether or not two sets of LR(1) items with the same core are weakly compatible.
+//
+// Let I₁, ..., Iₙ be the items of the common core, and Lᵢ and L′ᵢ the lookaheads of Iᵢ in the two sets.
+// The two sets are weakly compatible if for every pair of items Iᵢ and Iⱼ (i ≠ j) at least one of the following holds:
+//
+//   - Lᵢ ∩ L′ⱼ = ∅ and L′ᵢ ∩ Lⱼ = ∅
+//   - Lᵢ ∩ Lⱼ ≠ ∅
+//   - L′ᵢ ∩ L′ⱼ ≠ ∅
+//
+// Merging weakly compatible sets never introduces a reduce/reduce conflict that does not already exist in one of them.
+func weaklyCompatible(lhs, rhs lr.ItemSet) bool {
+	L1, L2 := lookaheads(lhs), lookaheads(rhs)
+
+	items := make([]string, 0, len(L1))
+	for s := range L1 {
+		items = append(items, s)
+	}
+
+	for i := 0; i < len(items); i++ {
+		for j := i + 1; j < len(items); j++ {
+			a, b := items[i], items[j]
+
+			if !intersect(L1[a], L2[b]) && !intersect(L2[a], L1[b]) ||
+				intersect(L1[a], L1[b]) ||
+				intersect(L2[a], L2[b]) {
+				continue
+			}
+
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,160 @@
+package minimal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/parser/lr"
+	"github.com/moorara/algo/parser/lr/canonical"
+	"github.com/moorara/algo/parser/lr/lookahead"
+)
+
+// S → a A d | b B d | a B e | b A e
+// A → c
+// B → c
+//
+// This grammar is LR(1) but not LALR(1).
+var notLALR = grammar.NewCFG(
+	[]grammar.Terminal{"a", "b", "c", "d", "e"},
+	[]grammar.NonTerminal{"S", "A", "B"},
+	[]*grammar.Production{
+		{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("a"), grammar.NonTerminal("A"), grammar.Terminal("d")}},
+		{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("b"), grammar.NonTerminal("B"), grammar.Terminal("d")}},
+		{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("a"), grammar.NonTerminal("B"), grammar.Terminal("e")}},
+		{Head: "S", Body: grammar.String[grammar.Symbol]{grammar.Terminal("b"), grammar.NonTerminal("A"), grammar.Terminal("e")}},
+		{Head: "A", Body: grammar.String[grammar.Symbol]{grammar.Terminal("c")}},
+		{Head: "B", Body: grammar.String[grammar.Symbol]{grammar.Terminal("c")}},
+	},
+	"S",
+)
+
+func TestBuildParsingTable(t *testing.T) {
+	tests := []struct {
+		name                 string
+		G                    *grammar.CFG
+		precedences          lr.PrecedenceLevels
+		expectedLALR         bool
+		expectedStates       int
+		expectedErrorStrings []string
+	}{
+		{
+			name:           "S→CC",
+			G:              parsertest.Grammars[1],
+			precedences:    lr.PrecedenceLevels{},
+			expectedLALR:   true,
+			expectedStates: 7,
+		},
+		{
+			name:           "S→L=R",
+			G:              parsertest.Grammars[2],
+			precedences:    lr.PrecedenceLevels{},
+			expectedLALR:   true,
+			expectedStates: 10,
+		},
+		{
+			name:           "E→E+T",
+			G:              parsertest.Grammars[3],
+			precedences:    lr.PrecedenceLevels{},
+			expectedLALR:   true,
+			expectedStates: 12,
+		},
+		{
+			name:           "NotLALR",
+			G:              notLALR,
+			precedences:    lr.PrecedenceLevels{},
+			expectedLALR:   false,
+			expectedStates: 14,
+		},
+		{
+			name:        "E→E+E",
+			G:           parsertest.Grammars[4],
+			precedences: lr.PrecedenceLevels{},
+			expectedErrorStrings: []string{
+				`Error:      Ambiguous Grammar`,
+				`Cause:      Multiple conflicts in the parsing table:`,
+				`Examples:   The parser reaches each conflict with these inputs:`,
+				`"id" "+" "id"•"*" "id"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, tc.G.Verify())
+			table, err := BuildParsingTable(tc.G, tc.precedences)
+
+			if len(tc.expectedErrorStrings) > 0 {
+				assert.Error(t, err)
+				s := err.Error()
+				for _, expectedErrorString := range tc.expectedErrorStrings {
+					assert.Contains(t, s, expectedErrorString)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, table.States, tc.expectedStates)
+
+			// A minimal LR(1) table has no more states than the canonical LR(1) table.
+			canonicalTable, err := canonical.BuildParsingTable(tc.G, tc.precedences)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(table.States), len(canonicalTable.States))
+
+			// A minimal LR(1) table is identical to the LALR(1) table for an LALR(1) grammar.
+			lalrTable, err := lookahead.BuildParsingTable(tc.G, tc.precedences)
+			if tc.expectedLALR {
+				assert.NoError(t, err)
+				assert.True(t, table.Equal(lalrTable), "Expected:\n%s\nActual:\n%s", lalrTable, table)
+			} else {
+				assert.Error(t, err)
+				assert.Greater(t, len(table.States), len(lalrTable.States))
+			}
+		})
+	}
+}
+
+func TestWeaklyCompatible(t *testing.T) {
+	G1 := lr.NewGrammarWithLR1Kernel(notLALR)
+
+	item := func(prod *grammar.Production, lookahead grammar.Terminal) lr.Item {
+		return &lr.Item1{Production: prod, Start: G1.Start, Dot: len(prod.Body), Lookahead: lookahead}
+	}
+
+	prodA := &grammar.Production{Head: "A", Body: grammar.String[grammar.Symbol]{grammar.Terminal("c")}}
+	prodB := &grammar.Production{Head: "B", Body: grammar.String[grammar.Symbol]{grammar.Terminal("c")}}
+
+	tests := []struct {
+		name               string
+		lhs                lr.ItemSet
+		rhs                lr.ItemSet
+		expectedCompatible bool
+	}{
+		{
+			name:               "Disjoint",
+			lhs:                lr.NewItemSet(item(prodA, "d"), item(prodB, "e")),
+			rhs:                lr.NewItemSet(item(prodA, "d"), item(prodB, "e")),
+			expectedCompatible: true,
+		},
+		{
+			name:               "ExistingConflict",
+			lhs:                lr.NewItemSet(item(prodA, "d"), item(prodB, "d")),
+			rhs:                lr.NewItemSet(item(prodA, "e"), item(prodB, "d")),
+			expectedCompatible: true,
+		},
+		{
+			name:               "NewConflict",
+			lhs:                lr.NewItemSet(item(prodA, "d"), item(prodB, "e")),
+			rhs:                lr.NewItemSet(item(prodA, "e"), item(prodB, "d")),
+			expectedCompatible: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCompatible, weaklyCompatible(tc.lhs, tc.rhs))
+		})
+	}
+}
@@ -222,6 +222,9 @@ func (t *ParsingTable) resolveConflict(a grammar.Terminal, actions set.Set[*Acti
 			handle = PrecedenceHandleForTerminal(a)
 		case REDUCE:
 			handle = PrecedenceHandleForProduction(action.Production)
+		default:
+			// A conflict involving an ACCEPT action (e.g., in a cyclic grammar) cannot be resolved by precedence.
+			return nil, fmt.Errorf("cannot determine precedence: %s", action)
 		}
 
 		pairs = append(pairs, &ActionHandlePair{
@@ -270,6 +273,24 @@ func (t *ParsingTable) ACTION(s State, a grammar.Terminal) (*Action, error) {
 	return action, nil
 }
 
+// ACTIONS looks up and returns all actions for state s and terminal a, including conflicting ones.
+// The actions are returned in a deterministic order (shifts first, then reductions).
+// If no action exists for ACTION[s,a], it returns nil.
+//
+// Unlike ACTION, this method does not treat conflicts as errors.
+// It is intended for generalized parsers that pursue all conflicting actions simultaneously.
+func (t *ParsingTable) ACTIONS(s State, a grammar.Terminal) []*Action {
+	actions, ok := t.getActions(s, a)
+	if !ok || actions.Size() == 0 {
+		return nil
+	}
+
+	all := generic.Collect1(actions.All())
+	sort.Quick(all, cmpAction)
+
+	return all
+}
+
 // GOTO looks up and returns the next state for state s and non-terminal A.
 // If the GOTO[s,A] contains more than one state, it returns an error.
 func (t *ParsingTable) GOTO(s State, A grammar.NonTerminal) (State, error) {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
 	"github.com/moorara/algo/set"
 )
 
@@ -279,6 +280,16 @@ func TestParsingTable_resolveConflict(t *testing.T) {
 			),
 			expectedErrorSubstring: `cannot determine precedence: no associativity and precedence specified:`,
 		},
+		{
+			name: "Accept",
+			pt:   pt[1],
+			term: grammar.Endmarker,
+			actions: set.New(eqAction,
+				&Action{Type: ACCEPT},
+				actions[0][4], // REDUCE E → E + E
+			),
+			expectedErrorSubstring: `cannot determine precedence: ACCEPT`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -366,6 +377,56 @@ func TestParsingTable_ACTION(t *testing.T) {
 	}
 }
 
+func TestParsingTable_ACTIONS(t *testing.T) {
+	pt := getTestParsingTables()
+
+	tests := []struct {
+		name            string
+		pt              *ParsingTable
+		s               State
+		a               grammar.Terminal
+		expectedActions []*Action
+	}{
+		{
+			name:            "NoAction",
+			pt:              pt[0],
+			s:               State(4),
+			a:               grammar.Terminal("+"),
+			expectedActions: nil,
+		},
+		{
+			name: "Conflict",
+			pt:   pt[1],
+			s:    State(2),
+			a:    grammar.Terminal("+"),
+			expectedActions: []*Action{
+				{Type: SHIFT, State: 6},
+				{Type: REDUCE, Production: parsertest.Prods[4][2]},
+			},
+		},
+		{
+			name: "Success",
+			pt:   pt[0],
+			s:    State(4),
+			a:    grammar.Terminal("id"),
+			expectedActions: []*Action{
+				{Type: SHIFT, State: 5},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actions := tc.pt.ACTIONS(tc.s, tc.a)
+
+			assert.Len(t, actions, len(tc.expectedActions))
+			for i, action := range actions {
+				assert.True(t, action.Equal(tc.expectedActions[i]))
+			}
+		})
+	}
+}
+
 func TestParsingTable_GOTO(t *testing.T) {
 	pt := getTestParsingTables()
 
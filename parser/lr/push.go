@@ -0,0 +1,236 @@
+package lr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser"
+)
+
+// ErrAccepted is returned when a token is fed to a push parser that has already accepted its input.
+var ErrAccepted = errors.New("input already accepted")
+
+// PushParser is an LR parser driven by its caller rather than by a lexer.
+//
+// Unlike Parser, which pulls tokens from a lexer.Lexer, a push parser receives tokens one at a time through Feed.
+// This makes it suitable for event-driven environments, such as network protocols, where tokens arrive in chunks.
+// The parser stack is kept between calls, and the provided functions are invoked as tokens are shifted
+// and productions are reduced, exactly as they would be by Parser.Parse for the same sequence of tokens.
+//
+// The state of a push parser can be captured by Checkpoint and restored by Restore at any time.
+// Since a syntax error may occur after some productions have been reduced, restoring a checkpoint
+// is the way to continue after an error or to try an alternative sequence of tokens.
+// Push parsers do not recover from syntax errors.
+type PushParser struct {
+	T      *ParsingTable
+	TokenF parser.TokenFunc
+	ProdF  parser.ProductionFunc
+
+	stack    []State
+	accepted bool
+}
+
+// NewPushParser creates a new push parser for a parsing table.
+// The provided functions are invoked each time a token is shifted or a production rule is reduced.
+// Either function can be nil.
+func NewPushParser(T *ParsingTable, tokenF parser.TokenFunc, prodF parser.ProductionFunc) *PushParser {
+	return &PushParser{
+		T:      T,
+		TokenF: tokenF,
+		ProdF:  prodF,
+		stack:  []State{0}, // BuildStateMap ensures state 0 always includes the initial item "S′ → •S"
+	}
+}
+
+// Feed passes the next input token to the parser.
+// All reductions enabled by the token are performed before the token is shifted onto the stack.
+//
+// Feeding a token with the Endmarker is equivalent to calling Finish.
+//
+// An error is returned if the token does not conform to the grammar rules, indicating a syntax issue,
+// or if any of the provided functions return an error, indicating a semantic issue.
+func (p *PushParser) Feed(token lexer.Token) error {
+	if token.Terminal.Equal(grammar.Endmarker) {
+		return p.finish(token)
+	}
+
+	if p.accepted {
+		return &parser.ParseError{
+			Description: fmt.Sprintf("unexpected string %q", token.Lexeme),
+			Cause:       ErrAccepted,
+			Pos:         token.Pos,
+		}
+	}
+
+	for {
+		action, err := p.action(token)
+		if err != nil {
+			return err
+		}
+
+		switch action.Type {
+		case SHIFT:
+			p.stack = append(p.stack, action.State)
+
+			// Yield the token.
+			if p.TokenF != nil {
+				if err := p.TokenF(&token); err != nil {
+					return &parser.ParseError{
+						Cause: err,
+						Pos:   token.Pos,
+					}
+				}
+			}
+
+			return nil
+
+		case REDUCE:
+			if err := p.reduce(action.Production); err != nil {
+				return err
+			}
+
+		case ACCEPT:
+			// ACCEPT only appears in ACTION table entries for the Endmarker.
+			return nil
+		}
+	}
+}
+
+// Finish signals the end of input to the parser.
+// All remaining reductions are performed, and the input is accepted if it belongs to the language.
+//
+// An error is returned if the input fed so far is not a complete sentence of the grammar,
+// or if any of the provided functions return an error.
+func (p *PushParser) Finish() error {
+	return p.finish(lexer.Token{Terminal: grammar.Endmarker})
+}
+
+// finish processes the Endmarker token until the input is accepted.
+func (p *PushParser) finish(token lexer.Token) error {
+	if p.accepted {
+		return nil
+	}
+
+	token.Lexeme = ""
+
+	for {
+		action, err := p.action(token)
+		if err != nil {
+			return err
+		}
+
+		switch action.Type {
+		case REDUCE:
+			if err := p.reduce(action.Production); err != nil {
+				return err
+			}
+
+		case ACCEPT:
+			p.accepted = true
+			return nil
+
+		default:
+			// The Endmarker is never shifted.
+			return &parser.ParseError{
+				Description: "unexpected end of input",
+				Pos:         token.Pos,
+			}
+		}
+	}
+}
+
+// action returns the action for the state on top of the stack and a token.
+func (p *PushParser) action(token lexer.Token) (*Action, error) {
+	s := p.stack[len(p.stack)-1]
+
+	action, err := p.T.ACTION(s, token.Terminal)
+	if err != nil {
+		return nil, &parser.ParseError{
+			Description: fmt.Sprintf("unexpected string %q", token.Lexeme),
+			Cause:       err,
+			Pos:         token.Pos,
+		}
+	}
+
+	return action, nil
+}
+
+// reduce pops the body of a production off the stack and pushes the GOTO state for its head.
+func (p *PushParser) reduce(prod *grammar.Production) error {
+	p.stack = p.stack[:len(p.stack)-len(prod.Body)]
+
+	// An LR parser detects an error when it consults the ACTION table.
+	// Errors are never identified by consulting the GOTO table.
+	// If ACTION(s, a) is not an error entry, GOTO(t, A) will also not be an error entry.
+
+	t := p.stack[len(p.stack)-1]
+	next, _ := p.T.GOTO(t, prod.Head)
+	p.stack = append(p.stack, next)
+
+	// Yield the production.
+	if p.ProdF != nil {
+		if err := p.ProdF(prod); err != nil {
+			return &parser.ParseError{Cause: err}
+		}
+	}
+
+	return nil
+}
+
+// Accepted returns true if the parser has accepted its input.
+func (p *PushParser) Accepted() bool {
+	return p.accepted
+}
+
+// Checkpoint captures the current state of the parser.
+// The returned checkpoint is independent of the parser and remains valid as the parser proceeds.
+func (p *PushParser) Checkpoint() *Checkpoint {
+	stack := make([]State, len(p.stack))
+	copy(stack, p.stack)
+
+	return &Checkpoint{
+		Stack:    stack,
+		Accepted: p.accepted,
+	}
+}
+
+// Restore resets the parser to the state captured by a checkpoint.
+// The checkpoint can be restored multiple times, and it can be created by another push parser for the same parsing table.
+//
+// An error is returned if the checkpoint is not valid for the parsing table of the parser.
+func (p *PushParser) Restore(c *Checkpoint) error {
+	if len(c.Stack) == 0 || c.Stack[0] != 0 {
+		return errors.New("invalid checkpoint: stack must start with the initial state")
+	}
+
+	for _, s := range c.Stack {
+		if s < 0 || int(s) >= len(p.T.States) {
+			return fmt.Errorf("invalid checkpoint: state %d does not exist in the parsing table", s)
+		}
+	}
+
+	p.stack = make([]State, len(c.Stack))
+	copy(p.stack, c.Stack)
+	p.accepted = c.Accepted
+
+	return nil
+}
+
+// Checkpoint is a snapshot of the state of a push parser.
+// It consists of the states on the parser stack from bottom to top,
+// so it can be stored or transmitted and used later to resume parsing.
+type Checkpoint struct {
+	Stack    []State
+	Accepted bool
+}
+
+// String returns a string representation of a checkpoint.
+func (c *Checkpoint) String() string {
+	if c.Accepted {
+		return fmt.Sprintf("%v (accepted)", c.Stack)
+	}
+
+	return fmt.Sprintf("%v", c.Stack)
+}
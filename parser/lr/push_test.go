@@ -0,0 +1,284 @@
+package lr
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/internal/parsertest"
+	"github.com/moorara/algo/lexer"
+)
+
+// idPlusIdTimesId is the sequence of tokens for the input string "a + b * c".
+var idPlusIdTimesId = []lexer.Token{
+	{Terminal: "id", Lexeme: "a", Pos: lexer.Position{Filename: "test", Offset: 0, Line: 1, Column: 1}},
+	{Terminal: "+", Lexeme: "+", Pos: lexer.Position{Filename: "test", Offset: 2, Line: 1, Column: 3}},
+	{Terminal: "id", Lexeme: "b", Pos: lexer.Position{Filename: "test", Offset: 4, Line: 1, Column: 5}},
+	{Terminal: "*", Lexeme: "*", Pos: lexer.Position{Filename: "test", Offset: 6, Line: 1, Column: 7}},
+	{Terminal: "id", Lexeme: "c", Pos: lexer.Position{Filename: "test", Offset: 8, Line: 1, Column: 9}},
+}
+
+func TestNewPushParser(t *testing.T) {
+	pt := getTestParsingTables()
+	p := NewPushParser(pt[0], nil, nil)
+
+	assert.NotNil(t, p)
+	assert.Equal(t, pt[0], p.T)
+	assert.False(t, p.Accepted())
+	assert.Equal(t, &Checkpoint{Stack: []State{0}}, p.Checkpoint())
+}
+
+func TestPushParser_Feed(t *testing.T) {
+	pt := getTestParsingTables()
+
+	tests := []struct {
+		name                 string
+		tokens               []lexer.Token
+		finish               bool
+		tokenF               func(*lexer.Token) error
+		prodF                func(*grammar.Production) error
+		expectedErrorStrings []string
+	}{
+		{
+			name:   "Success",
+			tokens: idPlusIdTimesId,
+			finish: true,
+		},
+		{
+			name:   "EndmarkerToken",
+			tokens: append(idPlusIdTimesId, lexer.Token{Terminal: grammar.Endmarker}),
+		},
+		{
+			name:   "Invalid_Input",
+			tokens: idPlusIdTimesId[1:],
+			expectedErrorStrings: []string{
+				`test:1:3: unexpected string "+": no action exists in the parsing table for ACTION[0, "+"]`,
+			},
+		},
+		{
+			name:   "Incomplete_Input",
+			tokens: idPlusIdTimesId[:4],
+			finish: true,
+			expectedErrorStrings: []string{
+				`unexpected string "": no action exists in the parsing table for ACTION[7, $]`,
+			},
+		},
+		{
+			name:   "TokenFuncError",
+			tokens: idPlusIdTimesId,
+			tokenF: func(*lexer.Token) error { return errors.New("invalid semantic") },
+			expectedErrorStrings: []string{
+				`test:1:1: invalid semantic`,
+			},
+		},
+		{
+			name:   "ProductionFuncError",
+			tokens: idPlusIdTimesId,
+			prodF:  func(*grammar.Production) error { return errors.New("invalid semantic") },
+			expectedErrorStrings: []string{
+				`invalid semantic`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var tokens []grammar.Terminal
+			var prods []*grammar.Production
+
+			tokenF := func(token *lexer.Token) error {
+				tokens = append(tokens, token.Terminal)
+				if tc.tokenF != nil {
+					return tc.tokenF(token)
+				}
+				return nil
+			}
+
+			prodF := func(prod *grammar.Production) error {
+				prods = append(prods, prod)
+				if tc.prodF != nil {
+					return tc.prodF(prod)
+				}
+				return nil
+			}
+
+			p := NewPushParser(pt[0], tokenF, prodF)
+
+			var err error
+			for _, token := range tc.tokens {
+				if err = p.Feed(token); err != nil {
+					break
+				}
+			}
+
+			if err == nil && tc.finish {
+				err = p.Finish()
+			}
+
+			if len(tc.expectedErrorStrings) == 0 {
+				assert.NoError(t, err)
+				assert.True(t, p.Accepted())
+
+				assert.Equal(t, []grammar.Terminal{"id", "+", "id", "*", "id"}, tokens)
+				assert.Equal(t, []*grammar.Production{
+					parsertest.Prods[3][6], // F → id
+					parsertest.Prods[3][4], // T → F
+					parsertest.Prods[3][2], // E → T
+					parsertest.Prods[3][6], // F → id
+					parsertest.Prods[3][4], // T → F
+					parsertest.Prods[3][6], // F → id
+					parsertest.Prods[3][3], // T → T * F
+					parsertest.Prods[3][1], // E → E + T
+				}, prods)
+			} else {
+				assert.Error(t, err)
+				assert.False(t, p.Accepted())
+				for _, s := range tc.expectedErrorStrings {
+					assert.Contains(t, err.Error(), s)
+				}
+			}
+		})
+	}
+}
+
+func TestPushParser_Feed_Accepted(t *testing.T) {
+	pt := getTestParsingTables()
+	p := NewPushParser(pt[0], nil, nil)
+
+	assert.NoError(t, p.Feed(idPlusIdTimesId[0]))
+	assert.NoError(t, p.Finish())
+	assert.True(t, p.Accepted())
+
+	// Finishing an accepted input is a no-op.
+	assert.NoError(t, p.Finish())
+
+	err := p.Feed(idPlusIdTimesId[1])
+	assert.ErrorIs(t, err, ErrAccepted)
+	assert.EqualError(t, err, `test:1:3: unexpected string "+": input already accepted`)
+}
+
+func TestPushParser_Checkpoint(t *testing.T) {
+	pt := getTestParsingTables()
+
+	var prods []*grammar.Production
+	p := NewPushParser(pt[0], nil, func(prod *grammar.Production) error {
+		prods = append(prods, prod)
+		return nil
+	})
+
+	// a +
+	assert.NoError(t, p.Feed(idPlusIdTimesId[0]))
+	assert.NoError(t, p.Feed(idPlusIdTimesId[1]))
+
+	c := p.Checkpoint()
+	assert.Equal(t, &Checkpoint{Stack: []State{0, 1, 6}}, c)
+	assert.Equal(t, "[0 1 6]", c.String())
+
+	// a + + fails after the checkpoint.
+	assert.Error(t, p.Feed(idPlusIdTimesId[1]))
+
+	// Resume from the checkpoint with b * c in a new parser.
+	q := NewPushParser(pt[0], nil, func(prod *grammar.Production) error {
+		prods = append(prods, prod)
+		return nil
+	})
+
+	assert.NoError(t, q.Restore(c))
+	for _, token := range idPlusIdTimesId[2:] {
+		assert.NoError(t, q.Feed(token))
+	}
+	assert.NoError(t, q.Finish())
+	assert.True(t, q.Accepted())
+
+	assert.Equal(t, []*grammar.Production{
+		parsertest.Prods[3][6], // F → id
+		parsertest.Prods[3][4], // T → F
+		parsertest.Prods[3][2], // E → T
+		parsertest.Prods[3][6], // F → id
+		parsertest.Prods[3][4], // T → F
+		parsertest.Prods[3][6], // F → id
+		parsertest.Prods[3][3], // T → T * F
+		parsertest.Prods[3][1], // E → E + T
+	}, prods)
+
+	accepted := q.Checkpoint()
+	assert.Equal(t, "[0 1] (accepted)", accepted.String())
+
+	// Restoring a checkpoint does not share the stack with the parser.
+	assert.NoError(t, p.Restore(c))
+	assert.NoError(t, p.Feed(idPlusIdTimesId[2]))
+	assert.Equal(t, []State{0, 1, 6}, c.Stack)
+}
+
+func TestPushParser_Restore(t *testing.T) {
+	pt := getTestParsingTables()
+
+	tests := []struct {
+		name          string
+		c             *Checkpoint
+		expectedError string
+	}{
+		{
+			name:          "EmptyStack",
+			c:             &Checkpoint{},
+			expectedError: "invalid checkpoint: stack must start with the initial state",
+		},
+		{
+			name:          "InvalidState",
+			c:             &Checkpoint{Stack: []State{0, 1, 42}},
+			expectedError: "invalid checkpoint: state 42 does not exist in the parsing table",
+		},
+		{
+			name: "Success",
+			c:    &Checkpoint{Stack: []State{0, 1, 6}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPushParser(pt[0], nil, nil)
+			err := p.Restore(tc.c)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.c, p.Checkpoint())
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestPushParser_MatchesParser(t *testing.T) {
+	pt := getTestParsingTables()
+
+	mocks := []parsertest.NextTokenMock{}
+	for _, token := range idPlusIdTimesId {
+		mocks = append(mocks, parsertest.NextTokenMock{OutToken: token})
+	}
+	mocks = append(mocks, parsertest.NextTokenMock{OutError: io.EOF})
+
+	var expected, actual []string
+
+	pull := &Parser{L: &parsertest.MockLexer{NextTokenMocks: mocks}, T: pt[0]}
+	err := pull.Parse(
+		func(token *lexer.Token) error { expected = append(expected, token.String()); return nil },
+		func(prod *grammar.Production) error { expected = append(expected, prod.String()); return nil },
+	)
+	assert.NoError(t, err)
+
+	push := NewPushParser(pt[0],
+		func(token *lexer.Token) error { actual = append(actual, token.String()); return nil },
+		func(prod *grammar.Production) error { actual = append(actual, prod.String()); return nil },
+	)
+
+	for _, token := range idPlusIdTimesId {
+		assert.NoError(t, push.Feed(token))
+	}
+	assert.NoError(t, push.Finish())
+
+	assert.Equal(t, expected, actual)
+}
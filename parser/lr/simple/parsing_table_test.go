@@ -20,6 +20,27 @@ func TestBuildParsingTable(t *testing.T) {
 		expectedTable *lr.ParsingTable
 		expectedError string
 	}{
+		{
+			name:        "S→L=R",
+			G:           parsertest.Grammars[2],
+			precedences: lr.PrecedenceLevels{},
+			expectedError: `Error:      Ambiguous Grammar
+Cause:      Shift/Reduce conflict in ACTION[7, "="]
+Context:    The parser cannot decide whether to
+              1. Shift the terminal "=", or
+              2. Reduce by production R → L
+Examples:   The parser reaches the conflict with these derivations:
+              1. "id"•"=" "id"
+                 S → L•"=" R
+              2. "id"•
+                 S → [R → L•]
+            The examples differ, so the conflict may be due to the limited lookahead of the parser.
+Resolution: Specify associativity and precedence for these Terminals/Productions:
+              • R = L vs. "="
+            Terminals/Productions listed earlier will have higher precedence.
+            Terminals/Productions in the same line will have the same precedence.
+`,
+		},
 		{
 			name:          "E→E+T",
 			G:             parsertest.Grammars[3],
@@ -36,6 +57,12 @@ Cause:      Multiple conflicts in the parsing table:
               2. Shift/Reduce conflict in ACTION[2, "+"]
               3. Shift/Reduce conflict in ACTION[3, "*"]
               4. Shift/Reduce conflict in ACTION[3, "+"]
+Examples:   The parser reaches each conflict with these inputs:
+              1. "id" "*" "id"•"*" "id"
+              2. "id" "*" "id"•"+" "id"
+              3. "id" "+" "id"•"*" "id"
+              4. "id" "+" "id"•"+" "id"
+            A single input means it reaches the conflict with every action, so the grammar is likely ambiguous.
 Resolution: Specify associativity and precedence for these Terminals/Productions:
               • "*" vs. "*", "+"
               • "+" vs. "*", "+"
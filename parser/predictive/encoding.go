@@ -0,0 +1,229 @@
+package predictive
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moorara/algo/grammar"
+)
+
+// syncEntry marks an entry in the serialized table as a synchronization symbol.
+const syncEntry = -1
+
+// tableData is the serializable form of a predictive parsing table.
+//
+// Terminals, non-terminals, and productions are stored once and referenced by their indices.
+// A symbol in a production body is referenced by its index in the list of terminals,
+// or by the number of terminals plus its index in the list of non-terminals.
+type tableData struct {
+	Terminals    []string         `json:"terminals"`
+	NonTerminals []string         `json:"nonTerminals"`
+	Productions  []productionData `json:"productions"`
+	Entries      [][3]int         `json:"entries"` // {non-terminal, terminal, production or -1 for sync}
+}
+
+// productionData is the serializable form of a production rule.
+type productionData struct {
+	Head int   `json:"head"`
+	Body []int `json:"body"`
+}
+
+// data converts the parsing table into its serializable form.
+func (t *ParsingTable) data() (*tableData, error) {
+	d := &tableData{
+		Terminals:    make([]string, len(t.terminals)),
+		NonTerminals: make([]string, len(t.nonTerminals)),
+		Productions:  []productionData{},
+		Entries:      [][3]int{},
+	}
+
+	symbols := make(map[grammar.Symbol]int)
+
+	for i, a := range t.terminals {
+		d.Terminals[i] = string(a)
+		symbols[a] = i
+	}
+
+	for i, A := range t.nonTerminals {
+		d.NonTerminals[i] = string(A)
+		symbols[A] = len(t.terminals) + i
+	}
+
+	// Ensure no entry is left out.
+	for A, row := range t.table.All() {
+		for a, e := range row.All() {
+			_, okA := symbols[A]
+			_, oka := symbols[a]
+			if (!okA || !oka) && (e.Sync || e.Productions.Size() > 0) {
+				return nil, fmt.Errorf("entry M[%s, %s] is not in the parsing table", A, a)
+			}
+		}
+	}
+
+	prods := make(map[string]int)
+
+	production := func(p *grammar.Production) (int, error) {
+		key := p.String()
+		if i, ok := prods[key]; ok {
+			return i, nil
+		}
+
+		head, ok := symbols[p.Head]
+		if !ok {
+			return 0, fmt.Errorf("non-terminal %s is not in the parsing table", p.Head)
+		}
+
+		pd := productionData{
+			Head: head - len(t.terminals),
+			Body: make([]int, len(p.Body)),
+		}
+
+		for i, X := range p.Body {
+			if pd.Body[i], ok = symbols[X]; !ok {
+				return 0, fmt.Errorf("symbol %s is not in the parsing table", X)
+			}
+		}
+
+		i := len(d.Productions)
+		d.Productions = append(d.Productions, pd)
+		prods[key] = i
+
+		return i, nil
+	}
+
+	for i, A := range t.nonTerminals {
+		for j, a := range t.terminals {
+			e, ok := t.getEntry(A, a)
+			if !ok {
+				continue
+			}
+
+			if e.Sync {
+				d.Entries = append(d.Entries, [3]int{i, j, syncEntry})
+				continue
+			}
+
+			for _, p := range grammar.OrderProductionSet(e.Productions) {
+				k, err := production(p)
+				if err != nil {
+					return nil, err
+				}
+
+				d.Entries = append(d.Entries, [3]int{i, j, k})
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// load populates the parsing table from its serializable form.
+func (t *ParsingTable) load(d *tableData) error {
+	terminals := make([]grammar.Terminal, len(d.Terminals))
+	for i, a := range d.Terminals {
+		terminals[i] = grammar.Terminal(a)
+	}
+
+	nonTerminals := make([]grammar.NonTerminal, len(d.NonTerminals))
+	for i, A := range d.NonTerminals {
+		nonTerminals[i] = grammar.NonTerminal(A)
+	}
+
+	prods := make([]*grammar.Production, len(d.Productions))
+	for i, pd := range d.Productions {
+		if pd.Head < 0 || pd.Head >= len(nonTerminals) {
+			return fmt.Errorf("invalid non-terminal %d in production %d", pd.Head, i)
+		}
+
+		body := make(grammar.String[grammar.Symbol], len(pd.Body))
+		for j, k := range pd.Body {
+			switch {
+			case 0 <= k && k < len(terminals):
+				body[j] = terminals[k]
+			case len(terminals) <= k && k < len(terminals)+len(nonTerminals):
+				body[j] = nonTerminals[k-len(terminals)]
+			default:
+				return fmt.Errorf("invalid symbol %d in production %d", k, i)
+			}
+		}
+
+		prods[i] = &grammar.Production{
+			Head: nonTerminals[pd.Head],
+			Body: body,
+		}
+	}
+
+	table := NewParsingTable(terminals, nonTerminals)
+
+	for _, e := range d.Entries {
+		if e[0] < 0 || e[0] >= len(nonTerminals) || e[1] < 0 || e[1] >= len(terminals) {
+			return fmt.Errorf("invalid entry M[%d, %d]", e[0], e[1])
+		}
+
+		A, a := nonTerminals[e[0]], terminals[e[1]]
+
+		switch {
+		case e[2] == syncEntry:
+			table.setSync(A, a, true)
+		case 0 <= e[2] && e[2] < len(prods):
+			table.addProduction(A, a, prods[e[2]])
+		default:
+			return fmt.Errorf("invalid production %d in M[%s, %s]", e[2], A, a)
+		}
+	}
+
+	*t = *table
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It encodes the terminals, non-terminals, and all entries of the parsing table, including synchronization symbols.
+func (t *ParsingTable) MarshalJSON() ([]byte, error) {
+	d, err := t.data()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(d)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It decodes a parsing table previously encoded by MarshalJSON.
+func (t *ParsingTable) UnmarshalJSON(b []byte) error {
+	d := new(tableData)
+	if err := json.Unmarshal(b, d); err != nil {
+		return err
+	}
+
+	return t.load(d)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It encodes the terminals, non-terminals, and all entries of the parsing table in a compact binary form.
+func (t *ParsingTable) MarshalBinary() ([]byte, error) {
+	d, err := t.data()
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(d); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It decodes a parsing table previously encoded by MarshalBinary.
+func (t *ParsingTable) UnmarshalBinary(b []byte) error {
+	d := new(tableData)
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(d); err != nil {
+		return err
+	}
+
+	return t.load(d)
+}
@@ -0,0 +1,128 @@
+package predictive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+)
+
+func TestParsingTable_JSON(t *testing.T) {
+	pt := getTestParsingTables()
+
+	for i, tc := range []*ParsingTable{pt[0], pt[2]} {
+		b, err := tc.MarshalJSON()
+		assert.NoError(t, err)
+
+		T := new(ParsingTable)
+		err = T.UnmarshalJSON(b)
+		assert.NoError(t, err)
+
+		assert.True(t, T.Equal(tc), "parsing table %d", i)
+		assert.Equal(t, tc.terminals, T.terminals)
+		assert.Equal(t, tc.nonTerminals, T.nonTerminals)
+	}
+}
+
+func TestParsingTable_Binary(t *testing.T) {
+	pt := getTestParsingTables()
+
+	for i, tc := range []*ParsingTable{pt[0], pt[2]} {
+		b, err := tc.MarshalBinary()
+		assert.NoError(t, err)
+
+		T := new(ParsingTable)
+		err = T.UnmarshalBinary(b)
+		assert.NoError(t, err)
+
+		assert.True(t, T.Equal(tc), "parsing table %d", i)
+		assert.Equal(t, tc.terminals, T.terminals)
+		assert.Equal(t, tc.nonTerminals, T.nonTerminals)
+	}
+}
+
+func TestParsingTable_Marshal_Error(t *testing.T) {
+	pt := getTestParsingTables()
+
+	T := NewParsingTable([]grammar.Terminal{"a"}, []grammar.NonTerminal{"A"})
+	T.addProduction("A", "a", &grammar.Production{
+		Head: "A",
+		Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("B")},
+	})
+
+	tests := []struct {
+		name          string
+		T             *ParsingTable
+		expectedError string
+	}{
+		{
+			name:          "MissingEntry",
+			T:             pt[1],
+			expectedError: `entry M[S′, $] is not in the parsing table`,
+		},
+		{
+			name:          "MissingSymbol",
+			T:             T,
+			expectedError: `symbol B is not in the parsing table`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.T.MarshalJSON()
+			assert.EqualError(t, err, tc.expectedError)
+
+			_, err = tc.T.MarshalBinary()
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestParsingTable_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedError string
+	}{
+		{
+			name:          "InvalidJSON",
+			data:          `[`,
+			expectedError: `unexpected end of JSON input`,
+		},
+		{
+			name:          "InvalidHead",
+			data:          `{"terminals":["a"],"nonTerminals":["A"],"productions":[{"head":-1,"body":[]}]}`,
+			expectedError: `invalid non-terminal -1 in production 0`,
+		},
+		{
+			name:          "InvalidSymbol",
+			data:          `{"terminals":["a"],"nonTerminals":["A"],"productions":[{"head":0,"body":[0,5]}]}`,
+			expectedError: `invalid symbol 5 in production 0`,
+		},
+		{
+			name:          "InvalidEntry",
+			data:          `{"terminals":["a"],"nonTerminals":["A"],"entries":[[0,1,-1]]}`,
+			expectedError: `invalid entry M[0, 1]`,
+		},
+		{
+			name:          "InvalidProduction",
+			data:          `{"terminals":["a"],"nonTerminals":["A"],"entries":[[0,0,0]]}`,
+			expectedError: `invalid production 0 in M[A, "a"]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			T := new(ParsingTable)
+			err := T.UnmarshalJSON([]byte(tc.data))
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestParsingTable_UnmarshalBinary(t *testing.T) {
+	T := new(ParsingTable)
+	err := T.UnmarshalBinary([]byte{0x00})
+	assert.Error(t, err)
+}
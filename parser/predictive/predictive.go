@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 
+	errs "github.com/moorara/algo/errors"
 	"github.com/moorara/algo/grammar"
 	"github.com/moorara/algo/lexer"
 	"github.com/moorara/algo/list"
@@ -66,9 +67,22 @@ func (p *predictiveParser) nextToken() (lexer.Token, error) {
 // The Parse method invokes the provided functions each time a token or a production rule is matched.
 // This allows the caller to process or react to each step of the parsing process.
 //
+// The parser recovers from syntax errors using panic-mode error recovery and continues parsing.
+// Input tokens are skipped until a synchronizing token appears, and symbols that cannot be matched
+// are popped off the stack without being reported to the provided functions.
+//
 // An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue,
 // or if any of the provided functions return an error, indicating a semantic issue.
+// All syntax errors encountered are collected and returned in an *errors.MultiError.
 func (p *predictiveParser) Parse(tokenF parser.TokenFunc, prodF parser.ProductionFunc) error {
+	_, err := p.parse(tokenF, prodF, nil)
+	return err
+}
+
+// parse implements the predictive parsing algorithm with panic-mode error recovery.
+// The discardF function, if provided, is invoked for every grammar symbol popped off the stack during error recovery.
+// It returns true if the parser reaches the end of input, possibly after recovering from syntax errors.
+func (p *predictiveParser) parse(tokenF parser.TokenFunc, prodF parser.ProductionFunc, discardF func()) (bool, error) {
 	/*
 	 * INPUT:  • A lexer for reading input string w.
 	 *         • A parsing table M for grammar G.
@@ -94,11 +108,34 @@ func (p *predictiveParser) Parse(tokenF parser.TokenFunc, prodF parser.Productio
 	 *           }
 	 *           let X be the top stack symbol
 	 *         }
+	 *
+	 * The error() routine implements panic-mode error recovery:
+	 *
+	 *         if X is a terminal, pop X (as if X was inserted into the input);
+	 *         if M[X,a] is a synchronizing entry, pop X (as if X was derived);
+	 *         if M[X,a] is blank, skip a (unless a is $, in which case pop X);
 	 */
 
+	var syntaxErr error
+
+	// Only the first error is reported until the next input token is matched.
+	// This prevents cascading errors while recovering from an error.
+	recovering := false
+
+	errorf := func(pos lexer.Position, format string, a ...any) {
+		if !recovering {
+			syntaxErr = errs.Append(syntaxErr, &parser.ParseError{
+				Description: fmt.Sprintf(format, a...),
+				Pos:         pos,
+			})
+		}
+
+		recovering = true
+	}
+
 	M, err := BuildParsingTable(p.G)
 	if err != nil {
-		return &parser.ParseError{
+		return false, &parser.ParseError{
 			Description: "failed to construct the predictive parsing table",
 			Cause:       err,
 		}
@@ -111,18 +148,28 @@ func (p *predictiveParser) Parse(tokenF parser.TokenFunc, prodF parser.Productio
 	// Read the first input token.
 	token, err := p.nextToken()
 	if err != nil {
-		return &parser.ParseError{Cause: err}
+		return false, &parser.ParseError{Cause: err}
+	}
+
+	// discard pops a grammar symbol off the stack without matching it.
+	discard := func() {
+		stack.Pop()
+		if discardF != nil {
+			discardF()
+		}
 	}
 
 	for X, _ := stack.Peek(); !X.Equal(grammar.Endmarker); X, _ = stack.Peek() {
 		if X.Equal(token.Terminal) {
+			recovering = false
+
 			// Yield the token.
 			if tokenF != nil {
 				if err := tokenF(&token); err != nil {
-					return &parser.ParseError{
+					return false, errs.Append(syntaxErr, &parser.ParseError{
 						Cause: err,
 						Pos:   token.Pos,
-					}
+					})
 				}
 			}
 
@@ -132,20 +179,29 @@ func (p *predictiveParser) Parse(tokenF parser.TokenFunc, prodF parser.Productio
 			// Read the next input token.
 			token, err = p.nextToken()
 			if err != nil {
-				return &parser.ParseError{Cause: err}
+				return false, errs.Append(syntaxErr, &parser.ParseError{Cause: err})
 			}
 		} else if X.IsTerminal() {
-			return &parser.ParseError{
-				Description: fmt.Sprintf("unexpected terminal %s on stack", X),
-			}
+			// Pop the terminal as if it was inserted into the input.
+			errorf(token.Pos, "missing terminal %s before <%s, %s>", X, token.Terminal, token.Lexeme)
+			discard()
 		} else {
 			A := X.(grammar.NonTerminal)
 
 			if M.IsEmpty(A, token.Terminal) {
-				return &parser.ParseError{
-					Description: fmt.Sprintf("unacceptable input <%s, %s> for non-terminal %s", token.Terminal, token.Lexeme, A),
-					Pos:         token.Pos,
+				errorf(token.Pos, "unacceptable input <%s, %s> for non-terminal %s", token.Terminal, token.Lexeme, A)
+
+				if M.IsSync(A, token.Terminal) || token.Terminal.Equal(grammar.Endmarker) {
+					// Pop the non-terminal as if it was derived.
+					discard()
+				} else {
+					// Skip the input token.
+					if token, err = p.nextToken(); err != nil {
+						return false, errs.Append(syntaxErr, &parser.ParseError{Cause: err})
+					}
 				}
+
+				continue
 			}
 
 			// At this point, it is guaranteed that M[A,a] contains exactly one production.
@@ -154,7 +210,7 @@ func (p *predictiveParser) Parse(tokenF parser.TokenFunc, prodF parser.Productio
 			// Yield the production.
 			if prodF != nil {
 				if err := prodF(prod); err != nil {
-					return &parser.ParseError{Cause: err}
+					return false, errs.Append(syntaxErr, &parser.ParseError{Cause: err})
 				}
 			}
 
@@ -168,8 +224,14 @@ func (p *predictiveParser) Parse(tokenF parser.TokenFunc, prodF parser.Productio
 		}
 	}
 
+	// The remaining input cannot be derived from the start symbol.
+	if !token.Terminal.Equal(grammar.Endmarker) {
+		recovering = false
+		errorf(token.Pos, "expected end of input, found <%s, %s>", token.Terminal, token.Lexeme)
+	}
+
 	// Accept the input string.
-	return nil
+	return true, syntaxErr
 }
 
 // ParseAndBuildAST analyzes a sequence of input tokens (terminal symbols) provided by a lexical analyzer.
@@ -180,6 +242,10 @@ func (p *predictiveParser) Parse(tokenF parser.TokenFunc, prodF parser.Productio
 // representing the syntactic structure of the input string.
 //
 // An error is returned if the input fails to conform to the grammar rules, indicating a syntax issue.
+//
+// If the parser recovers from syntax errors, a best-effort AST is returned along with the errors.
+// The nodes for the symbols that could not be matched are left incomplete:
+// internal nodes have no production and children, and leaf nodes have no lexeme and position.
 func (p *predictiveParser) ParseAndBuildAST() (parser.Node, error) {
 	// Root of the abstract syntax tree.
 	root := &parser.InternalNode{
@@ -190,7 +256,7 @@ func (p *predictiveParser) ParseAndBuildAST() (parser.Node, error) {
 	nodes := list.NewStack[parser.Node](1024, parser.EqNode)
 	nodes.Push(root)
 
-	err := p.Parse(
+	ok, err := p.parse(
 		func(token *lexer.Token) error {
 			// Complete the leaf node.
 			n, _ := nodes.Pop()
@@ -224,11 +290,14 @@ func (p *predictiveParser) ParseAndBuildAST() (parser.Node, error) {
 
 			return nil
 		},
+		func() {
+			nodes.Pop()
+		},
 	)
 
-	if err != nil {
+	if !ok {
 		return nil, err
 	}
 
-	return root, nil
+	return root, err
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/moorara/algo/generic"
 	"github.com/moorara/algo/grammar"
 	"github.com/moorara/algo/internal/parsertest"
 	"github.com/moorara/algo/lexer"
@@ -144,6 +145,8 @@ func TestPredictiveParser_Parse(t *testing.T) {
 								},
 							},
 						},
+						// EOF
+						{OutError: io.EOF},
 					},
 				},
 			},
@@ -375,7 +378,9 @@ func TestPredictiveParser_ParseAndBuildAST(t *testing.T) {
 					},
 				},
 			},
-			expectedAST: nil,
+			expectedAST: &parser.InternalNode{
+				NonTerminal: "E",
+			},
 			expectedErrorStrings: []string{
 				`unacceptable input <$, > for non-terminal E`,
 			},
@@ -443,10 +448,14 @@ func TestPredictiveParser_ParseAndBuildAST(t *testing.T) {
 								},
 							},
 						},
+						// EOF
+						{OutError: io.EOF},
 					},
 				},
 			},
-			expectedAST: nil,
+			expectedAST: &parser.InternalNode{
+				NonTerminal: "E",
+			},
 			expectedErrorStrings: []string{
 				`unacceptable input <"+", +> for non-terminal E`,
 			},
@@ -619,7 +628,11 @@ func TestPredictiveParser_ParseAndBuildAST(t *testing.T) {
 				assert.True(t, ast.Equal(tc.expectedAST))
 				assert.NoError(t, err)
 			} else {
-				assert.Nil(t, ast)
+				if tc.expectedAST == nil {
+					assert.Nil(t, ast)
+				} else {
+					assert.True(t, ast.Equal(tc.expectedAST))
+				}
 				assert.Error(t, err)
 				s := err.Error()
 				for _, expectedErrorString := range tc.expectedErrorStrings {
@@ -629,3 +642,86 @@ func TestPredictiveParser_ParseAndBuildAST(t *testing.T) {
 		})
 	}
 }
+
+// mockTokens creates a mock lexer that returns a token for each lexeme on a single line.
+func mockTokens(lexemes ...string) *parsertest.MockLexer {
+	L := new(parsertest.MockLexer)
+	col := 1
+
+	for _, lexeme := range lexemes {
+		terminal := grammar.Terminal(lexeme)
+		if lexeme >= "a" && lexeme <= "z" {
+			terminal = "id"
+		}
+
+		L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{
+			OutToken: lexer.Token{
+				Terminal: terminal,
+				Lexeme:   lexeme,
+				Pos:      lexer.Position{Filename: "test", Offset: col - 1, Line: 1, Column: col},
+			},
+		})
+
+		col += len(lexeme) + 1
+	}
+
+	L.NextTokenMocks = append(L.NextTokenMocks, parsertest.NextTokenMock{OutError: io.EOF})
+
+	return L
+}
+
+func TestPredictiveParser_ErrorRecovery(t *testing.T) {
+	tests := []struct {
+		name           string
+		p              *predictiveParser
+		expectedLeaves []string
+		expectedError  string
+	}{
+		{
+			name: "SyncEntry",
+			p: &predictiveParser{
+				G:     parsertest.Grammars[0],
+				lexer: mockTokens("(", "a", "+", ")", "*", "b"),
+			},
+			expectedLeaves: []string{"(", "a", "+", ")", "*", "b"},
+			expectedError:  "test:1:7: unacceptable input <\")\", )> for non-terminal T\n",
+		},
+		{
+			name: "SkipInput",
+			p: &predictiveParser{
+				G:     parsertest.Grammars[0],
+				lexer: mockTokens("a", "(", "b", "*", ")"),
+			},
+			expectedLeaves: []string{"a", "*"},
+			expectedError: "test:1:3: unacceptable input <\"(\", (> for non-terminal T′\n" +
+				"test:1:9: unacceptable input <\")\", )> for non-terminal F\n" +
+				"test:1:9: expected end of input, found <\")\", )>\n",
+		},
+		{
+			name: "MissingTerminal",
+			p: &predictiveParser{
+				G:     parsertest.Grammars[0],
+				lexer: mockTokens("(", "a", "*", "b"),
+			},
+			expectedLeaves: []string{"(", "a", "*", "b", ""},
+			expectedError:  "missing terminal \")\" before <$, >\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ast, err := tc.p.ParseAndBuildAST()
+			assert.EqualError(t, err, tc.expectedError)
+
+			var leaves []string
+			parser.Traverse(ast, generic.VLR, func(n parser.Node) bool {
+				if leaf, ok := n.(*parser.LeafNode); ok {
+					leaves = append(leaves, leaf.Lexeme)
+				}
+				return true
+			})
+
+			assert.Equal(t, tc.expectedLeaves, leaves)
+		})
+	}
+}
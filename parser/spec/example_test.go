@@ -0,0 +1,45 @@
+package spec_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moorara/algo/parser/spec"
+)
+
+func Example() {
+	src := strings.NewReader(`
+		%left "+" "-"
+		%left "*" "/"
+
+		expr = expr "+" expr | expr "-" expr
+		     | expr "*" expr | expr "/" expr
+		     | "(" expr ")"
+		     | call
+		     | num
+		     ;
+
+		call = id "(" ( expr ( "," expr )* )? ")" ;
+	`)
+
+	G, precedences, err := spec.Load("expr.grammar", src)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(G)
+	fmt.Println(precedences)
+
+	// Output:
+	// Terminal Symbols: "(" ")" "*" "+" "," "-" "/" "id" "num"
+	// Non-Terminal Symbols: expr call call′ call″
+	// Start Symbol: expr
+	// Production Rules:
+	//   expr → expr "*" expr | expr "+" expr | expr "-" expr | expr "/" expr | "(" expr ")" | call | "num"
+	//   call → "id" "(" call′ ")"
+	//   call′ → expr call″ | ε
+	//   call″ → call″ "," expr | ε
+	//
+	// LEFT "*", "/"
+	// LEFT "+", "-"
+}
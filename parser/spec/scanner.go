@@ -0,0 +1,318 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/moorara/algo/errors"
+	"github.com/moorara/algo/lexer"
+)
+
+// tokenKind is the type of a token in a grammar specification.
+type tokenKind int
+
+const (
+	tokenEOF       tokenKind = iota // end of input
+	tokenIdent                      // identifier
+	tokenLiteral                    // quoted literal terminal
+	tokenDirective                  // %left, %right, %nonassoc, %token, %start
+	tokenDefine                     // =, :, or ::=
+	tokenBar                        // |
+	tokenSemicolon                  // ;
+	tokenLParen                     // (
+	tokenRParen                     // )
+	tokenQuestion                   // ?
+	tokenStar                       // *
+	tokenPlus                       // +
+	tokenEpsilon                    // ε
+)
+
+// token is a lexical unit of a grammar specification.
+type token struct {
+	kind  tokenKind
+	value string
+	pos   lexer.Position
+}
+
+// String returns a string representation of the token for error messages.
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenIdent:
+		return fmt.Sprintf("identifier %s", t.value)
+	case tokenLiteral:
+		return fmt.Sprintf("literal %q", t.value)
+	case tokenDirective:
+		return fmt.Sprintf("directive %%%s", t.value)
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+// scanner breaks a grammar specification into tokens.
+// Lexical errors are accumulated and the offending characters are skipped.
+type scanner struct {
+	filename string
+	src      string
+	offset   int // byte offset of the next rune
+	line     int // line number of the next rune
+	column   int // column number of the next rune
+	err      error
+}
+
+func newScanner(filename, src string) *scanner {
+	return &scanner{
+		filename: filename,
+		src:      src,
+		offset:   0,
+		line:     1,
+		column:   1,
+	}
+}
+
+func (s *scanner) pos() lexer.Position {
+	return lexer.Position{
+		Filename: s.filename,
+		Offset:   s.offset,
+		Line:     s.line,
+		Column:   s.column,
+	}
+}
+
+func (s *scanner) errorf(pos lexer.Position, format string, a ...any) {
+	s.err = errors.Append(s.err, &SpecError{
+		Description: fmt.Sprintf(format, a...),
+		Pos:         pos,
+	})
+}
+
+// peek returns the next rune without consuming it.
+// At the end of input, it returns utf8.RuneError and false.
+func (s *scanner) peek() (rune, bool) {
+	if s.offset >= len(s.src) {
+		return utf8.RuneError, false
+	}
+
+	r, _ := utf8.DecodeRuneInString(s.src[s.offset:])
+	return r, true
+}
+
+// advance consumes the next rune and returns it.
+func (s *scanner) advance() rune {
+	r, size := utf8.DecodeRuneInString(s.src[s.offset:])
+	s.offset += size
+
+	if r == '\n' {
+		s.line++
+		s.column = 1
+	} else {
+		s.column++
+	}
+
+	return r
+}
+
+func (s *scanner) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(s.src[s.offset:], prefix)
+}
+
+// skip consumes whitespaces and comments.
+func (s *scanner) skip() {
+	for {
+		r, ok := s.peek()
+		switch {
+		case !ok:
+			return
+
+		case unicode.IsSpace(r):
+			s.advance()
+
+		case s.hasPrefix("//"):
+			for r, ok := s.peek(); ok && r != '\n'; r, ok = s.peek() {
+				s.advance()
+			}
+
+		case s.hasPrefix("/*"):
+			pos := s.pos()
+			s.advance()
+			s.advance()
+			for !s.hasPrefix("*/") {
+				if _, ok := s.peek(); !ok {
+					s.errorf(pos, "unterminated comment")
+					return
+				}
+				s.advance()
+			}
+			s.advance()
+			s.advance()
+
+		default:
+			return
+		}
+	}
+}
+
+// Next returns the next token in the input.
+func (s *scanner) Next() token {
+	for {
+		s.skip()
+
+		pos := s.pos()
+		r, ok := s.peek()
+
+		switch {
+		case !ok:
+			return token{kind: tokenEOF, pos: pos}
+
+		case s.hasPrefix("::="):
+			s.advance()
+			s.advance()
+			s.advance()
+			return token{kind: tokenDefine, value: "::=", pos: pos}
+
+		case r == '=' || r == ':':
+			s.advance()
+			return token{kind: tokenDefine, value: string(r), pos: pos}
+
+		case r == '|':
+			s.advance()
+			return token{kind: tokenBar, value: "|", pos: pos}
+
+		case r == ';':
+			s.advance()
+			return token{kind: tokenSemicolon, value: ";", pos: pos}
+
+		case r == '(':
+			s.advance()
+			return token{kind: tokenLParen, value: "(", pos: pos}
+
+		case r == ')':
+			s.advance()
+			return token{kind: tokenRParen, value: ")", pos: pos}
+
+		case r == '?':
+			s.advance()
+			return token{kind: tokenQuestion, value: "?", pos: pos}
+
+		case r == '*':
+			s.advance()
+			return token{kind: tokenStar, value: "*", pos: pos}
+
+		case r == '+':
+			s.advance()
+			return token{kind: tokenPlus, value: "+", pos: pos}
+
+		case r == '"' || r == '\'':
+			if t, ok := s.literal(pos); ok {
+				return t
+			}
+
+		case r == '%':
+			if t, ok := s.directive(pos); ok {
+				return t
+			}
+
+		case isIdentStart(r):
+			ident := s.ident()
+			if ident == "ε" {
+				return token{kind: tokenEpsilon, value: ident, pos: pos}
+			}
+			return token{kind: tokenIdent, value: ident, pos: pos}
+
+		default:
+			s.advance()
+			s.errorf(pos, "unexpected character %q", r)
+		}
+	}
+}
+
+func (s *scanner) ident() string {
+	begin := s.offset
+	for r, ok := s.peek(); ok && isIdentPart(r); r, ok = s.peek() {
+		s.advance()
+	}
+
+	return s.src[begin:s.offset]
+}
+
+// directive scans a directive starting with %.
+// If the directive is unknown, an error is recorded and the second return value will be false.
+func (s *scanner) directive(pos lexer.Position) (token, bool) {
+	s.advance()
+	name := s.ident()
+
+	switch name {
+	case "left", "right", "nonassoc", "token", "start":
+		return token{kind: tokenDirective, value: name, pos: pos}, true
+	default:
+		s.errorf(pos, "unknown directive %%%s", name)
+		return token{}, false
+	}
+}
+
+// literal scans a single- or double-quoted literal terminal.
+// If the literal is malformed, an error is recorded and the second return value will be false.
+func (s *scanner) literal(pos lexer.Position) (token, bool) {
+	quote := s.advance()
+
+	var b bytes.Buffer
+	for {
+		r, ok := s.peek()
+		if !ok || r == '\n' {
+			s.errorf(pos, "unterminated literal")
+			return token{}, false
+		}
+
+		rpos := s.pos()
+		s.advance()
+
+		if r == quote {
+			break
+		}
+
+		if r == '\\' {
+			r, ok = s.peek()
+			if !ok || r == '\n' {
+				s.errorf(pos, "unterminated literal")
+				return token{}, false
+			}
+
+			s.advance()
+
+			switch r {
+			case '\\', '"', '\'':
+			case 'n':
+				r = '\n'
+			case 'r':
+				r = '\r'
+			case 't':
+				r = '\t'
+			default:
+				s.errorf(rpos, "invalid escape sequence \\%c", r)
+				continue
+			}
+		}
+
+		b.WriteRune(r)
+	}
+
+	if b.Len() == 0 {
+		s.errorf(pos, "empty literal")
+		return token{}, false
+	}
+
+	return token{kind: tokenLiteral, value: b.String(), pos: pos}, true
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' ||
+		r == '′' || r == '″' || r == '‴'
+}
@@ -0,0 +1,121 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/lexer"
+)
+
+func pos(offset, line, column int) lexer.Position {
+	return lexer.Position{Filename: "test", Offset: offset, Line: line, Column: column}
+}
+
+func TestToken_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		t              token
+		expectedString string
+	}{
+		{"EOF", token{kind: tokenEOF}, "end of input"},
+		{"Ident", token{kind: tokenIdent, value: "expr"}, "identifier expr"},
+		{"Literal", token{kind: tokenLiteral, value: "+"}, `literal "+"`},
+		{"Directive", token{kind: tokenDirective, value: "left"}, "directive %left"},
+		{"Define", token{kind: tokenDefine, value: "::="}, `"::="`},
+		{"Bar", token{kind: tokenBar, value: "|"}, `"|"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.t.String())
+		})
+	}
+}
+
+func TestScanner_Next(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		expectedTokens []token
+		expectedError  string
+	}{
+		{
+			name: "OK",
+			src:  "%left '+' \"-\"\n/* block\ncomment */ expr ::= ( a | b )? c* d+ ; // comment\nx : ε = y",
+			expectedTokens: []token{
+				{kind: tokenDirective, value: "left", pos: pos(0, 1, 1)},
+				{kind: tokenLiteral, value: "+", pos: pos(6, 1, 7)},
+				{kind: tokenLiteral, value: "-", pos: pos(10, 1, 11)},
+				{kind: tokenIdent, value: "expr", pos: pos(34, 3, 12)},
+				{kind: tokenDefine, value: "::=", pos: pos(39, 3, 17)},
+				{kind: tokenLParen, value: "(", pos: pos(43, 3, 21)},
+				{kind: tokenIdent, value: "a", pos: pos(45, 3, 23)},
+				{kind: tokenBar, value: "|", pos: pos(47, 3, 25)},
+				{kind: tokenIdent, value: "b", pos: pos(49, 3, 27)},
+				{kind: tokenRParen, value: ")", pos: pos(51, 3, 29)},
+				{kind: tokenQuestion, value: "?", pos: pos(52, 3, 30)},
+				{kind: tokenIdent, value: "c", pos: pos(54, 3, 32)},
+				{kind: tokenStar, value: "*", pos: pos(55, 3, 33)},
+				{kind: tokenIdent, value: "d", pos: pos(57, 3, 35)},
+				{kind: tokenPlus, value: "+", pos: pos(58, 3, 36)},
+				{kind: tokenSemicolon, value: ";", pos: pos(60, 3, 38)},
+				{kind: tokenIdent, value: "x", pos: pos(73, 4, 1)},
+				{kind: tokenDefine, value: ":", pos: pos(75, 4, 3)},
+				{kind: tokenEpsilon, value: "ε", pos: pos(77, 4, 5)},
+				{kind: tokenDefine, value: "=", pos: pos(80, 4, 7)},
+				{kind: tokenIdent, value: "y", pos: pos(82, 4, 9)},
+				{kind: tokenEOF, pos: pos(83, 4, 10)},
+			},
+		},
+		{
+			name: "Escapes",
+			src:  `"\"\\\n\t" 'it\'s' E′`,
+			expectedTokens: []token{
+				{kind: tokenLiteral, value: "\"\\\n\t", pos: pos(0, 1, 1)},
+				{kind: tokenLiteral, value: "it's", pos: pos(11, 1, 12)},
+				{kind: tokenIdent, value: "E′", pos: pos(19, 1, 20)},
+				{kind: tokenEOF, pos: pos(23, 1, 22)},
+			},
+		},
+		{
+			name: "Errors",
+			src:  "a @ %foo \"\" \"x\\q\" 'open\nb /* open",
+			expectedTokens: []token{
+				{kind: tokenIdent, value: "a", pos: pos(0, 1, 1)},
+				{kind: tokenLiteral, value: "x", pos: pos(12, 1, 13)},
+				{kind: tokenIdent, value: "b", pos: pos(24, 2, 1)},
+				{kind: tokenEOF, pos: pos(33, 2, 10)},
+			},
+			expectedError: "test:1:3: unexpected character '@'\n" +
+				"test:1:5: unknown directive %foo\n" +
+				"test:1:10: empty literal\n" +
+				"test:1:15: invalid escape sequence \\q\n" +
+				"test:1:19: unterminated literal\n" +
+				"test:2:3: unterminated comment\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newScanner("test", tc.src)
+
+			tokens := make([]token, 0)
+			for {
+				tok := s.Next()
+				tokens = append(tokens, tok)
+				if tok.kind == tokenEOF {
+					break
+				}
+			}
+
+			assert.Equal(t, tc.expectedTokens, tokens)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, s.err)
+			} else {
+				assert.EqualError(t, s.err, tc.expectedError)
+			}
+		})
+	}
+}
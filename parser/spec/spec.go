@@ -0,0 +1,659 @@
+// Package spec implements a textual format for defining context-free grammars.
+//
+// A grammar specification is a sequence of directives and production rules.
+// Production rules are written in BNF/EBNF notation:
+//
+//	%token id num
+//
+//	%left "+" "-"
+//	%left "*" "/"
+//	%right "^"
+//
+//	expr = expr "+" expr
+//	     | expr "-" expr
+//	     | expr "*" expr
+//	     | expr "/" expr
+//	     | expr "^" expr
+//	     | "(" expr ")"
+//	     | id
+//	     | num
+//	     ;
+//
+// A rule consists of a non-terminal head, one of =, :, or ::=, a list of alternatives separated by |, and a semicolon.
+// An alternative is a sequence of symbols, which can be empty or written explicitly as ε.
+// Rules with the same head are merged.
+//
+// An identifier is a non-terminal if it is the head of at least one rule; otherwise, it is a terminal.
+// A single- or double-quoted literal is always a terminal.
+// Comments are written as // line comments and /* block comments */.
+//
+// The following EBNF operators are supported and translated into new non-terminals and production rules:
+//
+//	X?       optional X
+//	X*       zero or more X
+//	X+       one or more X
+//	( ... )  grouping with alternatives
+//
+// The following directives are supported:
+//
+//	%token     declares terminals, including the ones not used in any rule.
+//	%start     sets the start symbol (the head of the first rule by default).
+//	%left      declares a precedence level of left-associative terminals.
+//	%right     declares a precedence level of right-associative terminals.
+//	%nonassoc  declares a precedence level of non-associative terminals.
+//
+// As in yacc, precedence levels declared later have higher precedence.
+package spec
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/moorara/algo/errors"
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/lexer"
+	"github.com/moorara/algo/parser/lr"
+)
+
+// SpecError represents an error encountered when loading a grammar specification.
+type SpecError struct {
+	Description string
+	Pos         lexer.Position
+}
+
+// Error implements the error interface.
+// It returns a formatted string describing the error in detail.
+func (e *SpecError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Description)
+}
+
+// Load reads a grammar specification and creates a context-free grammar and precedence levels.
+// The filename is only used for reporting positions in errors.
+//
+// If the specification is malformed, all errors found are returned in an *errors.MultiError.
+func Load(filename string, src io.Reader) (*grammar.CFG, lr.PrecedenceLevels, error) {
+	b, err := io.ReadAll(src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := newParser(filename, string(b))
+	p.parse()
+
+	// Semantic analysis is meaningless if there are syntax errors.
+	if err := errors.Append(p.s.err, p.err).ErrorOrNil(); err != nil {
+		return nil, nil, err
+	}
+
+	return newBuilder(p).build()
+}
+
+// nodeKind is the type of a node in a production body.
+type nodeKind int
+
+const (
+	nodeIdent    nodeKind = iota // identifier
+	nodeLiteral                  // literal terminal
+	nodeGroup                    // ( ... )
+	nodeOptional                 // X?
+	nodeStar                     // X*
+	nodePlus                     // X+
+)
+
+// node is a symbol or an EBNF construct in a production body.
+type node struct {
+	kind  nodeKind
+	value string // identifier or literal
+	pos   lexer.Position
+	alts  [][]*node // alternatives of a group
+	child *node     // operand of ?, *, and +
+}
+
+// String returns the canonical form of a node.
+// Nodes with the same canonical form are translated into the same non-terminal.
+func (n *node) String() string {
+	switch n.kind {
+	case nodeIdent:
+		return n.value
+	case nodeLiteral:
+		return strconv.Quote(n.value)
+	case nodeGroup:
+		alts := make([]string, len(n.alts))
+		for i, seq := range n.alts {
+			syms := make([]string, len(seq))
+			for j, m := range seq {
+				syms[j] = m.String()
+			}
+			alts[i] = strings.Join(syms, " ")
+		}
+		return "(" + strings.Join(alts, " | ") + ")"
+	case nodeOptional:
+		return n.child.String() + "?"
+	case nodeStar:
+		return n.child.String() + "*"
+	case nodePlus:
+		return n.child.String() + "+"
+	default:
+		return ""
+	}
+}
+
+// rule is a production rule with alternatives.
+type rule struct {
+	head token
+	alts [][]*node
+}
+
+// precedence is a precedence declaration.
+type precedence struct {
+	assoc   lr.Associativity
+	pos     lexer.Position
+	symbols []*node
+}
+
+// parser is a recursive-descent parser for grammar specifications.
+//
+//	spec        → { directive | rule }
+//	directive   → ( "%left" | "%right" | "%nonassoc" ) symbol+ [ ";" ]
+//	            | "%token" IDENT+ [ ";" ]
+//	            | "%start" IDENT [ ";" ]
+//	rule        → IDENT define alternatives ";"
+//	alternatives → sequence { "|" sequence }
+//	sequence    → { term | "ε" }
+//	term        → factor { "?" | "*" | "+" }
+//	factor      → IDENT | LITERAL | "(" alternatives ")"
+type parser struct {
+	s     *scanner
+	tok   token // current token
+	ahead token // next token
+	err   error
+
+	rules       []*rule
+	precedences []*precedence
+	tokens      []token
+	start       *token
+}
+
+func newParser(filename, src string) *parser {
+	p := &parser{
+		s: newScanner(filename, src),
+	}
+
+	p.tok = p.s.Next()
+	p.ahead = p.s.Next()
+
+	return p
+}
+
+func (p *parser) next() {
+	p.tok = p.ahead
+	p.ahead = p.s.Next()
+}
+
+func (p *parser) errorf(pos lexer.Position, format string, a ...any) {
+	p.err = errors.Append(p.err, &SpecError{
+		Description: fmt.Sprintf(format, a...),
+		Pos:         pos,
+	})
+}
+
+// atRule returns true if the current token begins a new rule.
+func (p *parser) atRule() bool {
+	return p.tok.kind == tokenIdent && p.ahead.kind == tokenDefine
+}
+
+// sync skips tokens until the end of the current rule or the beginning of a new rule or directive.
+func (p *parser) sync() {
+	for p.tok.kind != tokenEOF && p.tok.kind != tokenDirective && !p.atRule() {
+		kind := p.tok.kind
+		p.next()
+		if kind == tokenSemicolon {
+			return
+		}
+	}
+}
+
+func (p *parser) parse() {
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.tok.kind == tokenDirective:
+			p.parseDirective()
+		case p.atRule():
+			p.parseRule()
+		default:
+			p.errorf(p.tok.pos, "unexpected %s, expecting a directive or a rule", p.tok)
+			p.next()
+			p.sync()
+		}
+	}
+}
+
+func (p *parser) parseDirective() {
+	d := p.tok
+	p.next()
+
+	switch d.value {
+	case "left", "right", "nonassoc":
+		prec := &precedence{
+			assoc: map[string]lr.Associativity{"left": lr.LEFT, "right": lr.RIGHT, "nonassoc": lr.NONE}[d.value],
+			pos:   d.pos,
+		}
+
+		for (p.tok.kind == tokenIdent && !p.atRule()) || p.tok.kind == tokenLiteral {
+			kind := nodeIdent
+			if p.tok.kind == tokenLiteral {
+				kind = nodeLiteral
+			}
+
+			prec.symbols = append(prec.symbols, &node{kind: kind, value: p.tok.value, pos: p.tok.pos})
+			p.next()
+		}
+
+		if len(prec.symbols) == 0 {
+			p.errorf(d.pos, "%%%s requires at least one terminal", d.value)
+		} else {
+			p.precedences = append(p.precedences, prec)
+		}
+
+	case "token":
+		count := 0
+		for ; p.tok.kind == tokenIdent && !p.atRule(); count++ {
+			p.tokens = append(p.tokens, p.tok)
+			p.next()
+		}
+
+		if count == 0 {
+			p.errorf(d.pos, "%%token requires at least one identifier")
+		}
+
+	case "start":
+		if p.tok.kind != tokenIdent || p.atRule() {
+			p.errorf(d.pos, "%%start requires an identifier")
+		} else if p.start != nil {
+			p.errorf(d.pos, "start symbol already declared at %s", p.start.pos)
+			p.next()
+		} else {
+			start := p.tok
+			p.start = &start
+			p.next()
+		}
+	}
+
+	if p.tok.kind == tokenSemicolon {
+		p.next()
+	}
+}
+
+func (p *parser) parseRule() {
+	head := p.tok
+	p.next() // identifier
+	p.next() // define
+
+	alts, ok := p.parseAlternatives()
+	if !ok {
+		p.sync()
+		return
+	}
+
+	switch {
+	case p.tok.kind == tokenSemicolon:
+		p.next()
+	case p.tok.kind == tokenEOF || p.tok.kind == tokenDirective || p.atRule():
+		p.errorf(p.tok.pos, "missing ; at the end of rule %s", head.value)
+	default:
+		p.errorf(p.tok.pos, "unexpected %s in rule %s", p.tok, head.value)
+		p.sync()
+		return
+	}
+
+	p.rules = append(p.rules, &rule{
+		head: head,
+		alts: alts,
+	})
+}
+
+func (p *parser) parseAlternatives() ([][]*node, bool) {
+	alts := make([][]*node, 0)
+
+	for {
+		seq, ok := p.parseSequence()
+		if !ok {
+			return nil, false
+		}
+
+		alts = append(alts, seq)
+
+		if p.tok.kind != tokenBar {
+			return alts, true
+		}
+
+		p.next()
+	}
+}
+
+func (p *parser) parseSequence() ([]*node, bool) {
+	seq := make([]*node, 0)
+
+	for {
+		switch {
+		case p.tok.kind == tokenEpsilon:
+			p.next()
+
+		case (p.tok.kind == tokenIdent && !p.atRule()) || p.tok.kind == tokenLiteral || p.tok.kind == tokenLParen:
+			n, ok := p.parseTerm()
+			if !ok {
+				return nil, false
+			}
+			seq = append(seq, n)
+
+		case p.tok.kind == tokenQuestion || p.tok.kind == tokenStar || p.tok.kind == tokenPlus:
+			p.errorf(p.tok.pos, "unexpected %s, missing operand", p.tok)
+			return nil, false
+
+		default:
+			return seq, true
+		}
+	}
+}
+
+func (p *parser) parseTerm() (*node, bool) {
+	n, ok := p.parseFactor()
+	if !ok {
+		return nil, false
+	}
+
+	for {
+		switch p.tok.kind {
+		case tokenQuestion:
+			n = &node{kind: nodeOptional, pos: p.tok.pos, child: n}
+		case tokenStar:
+			n = &node{kind: nodeStar, pos: p.tok.pos, child: n}
+		case tokenPlus:
+			n = &node{kind: nodePlus, pos: p.tok.pos, child: n}
+		default:
+			return n, true
+		}
+
+		p.next()
+	}
+}
+
+func (p *parser) parseFactor() (*node, bool) {
+	t := p.tok
+
+	switch t.kind {
+	case tokenIdent:
+		p.next()
+		return &node{kind: nodeIdent, value: t.value, pos: t.pos}, true
+
+	case tokenLiteral:
+		p.next()
+		return &node{kind: nodeLiteral, value: t.value, pos: t.pos}, true
+
+	default: // tokenLParen
+		p.next()
+
+		alts, ok := p.parseAlternatives()
+		if !ok {
+			return nil, false
+		}
+
+		if p.tok.kind != tokenRParen {
+			p.errorf(p.tok.pos, "unexpected %s, missing ) for ( at %s", p.tok, t.pos)
+			return nil, false
+		}
+
+		p.next()
+
+		return &node{kind: nodeGroup, pos: t.pos, alts: alts}, true
+	}
+}
+
+// primes are the suffixes for naming the non-terminals created for EBNF constructs.
+var primes = []string{
+	"′", // Prime (U+2032)
+	"″", // Double Prime (U+2033)
+	"‴", // Triple Prime (U+2034)
+	"⁗", // Quadruple Prime (U+2057)
+}
+
+// builder translates the parsed rules and directives into a context-free grammar and precedence levels.
+type builder struct {
+	p   *parser
+	err error
+
+	heads    map[string]bool                // heads of rules
+	names    map[string]bool                // all non-terminal names in use
+	memo     map[string]grammar.NonTerminal // canonical form of EBNF constructs --> new non-terminal
+	terms    []grammar.Terminal
+	nonTerms []grammar.NonTerminal
+	prods    []*grammar.Production
+}
+
+func newBuilder(p *parser) *builder {
+	return &builder{
+		p:     p,
+		heads: make(map[string]bool),
+		names: make(map[string]bool),
+		memo:  make(map[string]grammar.NonTerminal),
+	}
+}
+
+func (b *builder) errorf(pos lexer.Position, format string, a ...any) {
+	b.err = errors.Append(b.err, &SpecError{
+		Description: fmt.Sprintf(format, a...),
+		Pos:         pos,
+	})
+}
+
+func (b *builder) build() (*grammar.CFG, lr.PrecedenceLevels, error) {
+	if len(b.p.rules) == 0 {
+		b.errorf(b.p.tok.pos, "no production rules")
+		return nil, nil, b.err
+	}
+
+	for _, r := range b.p.rules {
+		if !b.heads[r.head.value] {
+			b.heads[r.head.value] = true
+			b.names[r.head.value] = true
+			b.nonTerms = append(b.nonTerms, grammar.NonTerminal(r.head.value))
+		}
+	}
+
+	for _, t := range b.p.tokens {
+		if b.heads[t.value] {
+			b.errorf(t.pos, "token %s cannot be the head of a rule", t.value)
+		} else {
+			b.terms = append(b.terms, grammar.Terminal(t.value))
+		}
+	}
+
+	start := grammar.NonTerminal(b.p.rules[0].head.value)
+	if s := b.p.start; s != nil {
+		if !b.heads[s.value] {
+			b.errorf(s.pos, "start symbol %s has no production rule", s.value)
+		}
+		start = grammar.NonTerminal(s.value)
+	}
+
+	for _, r := range b.p.rules {
+		head := grammar.NonTerminal(r.head.value)
+		for _, seq := range r.alts {
+			b.prods = append(b.prods, &grammar.Production{
+				Head: head,
+				Body: b.sequence(head, seq),
+			})
+		}
+	}
+
+	precedences := b.precedences()
+
+	if b.err != nil {
+		return nil, nil, b.err
+	}
+
+	g := grammar.NewCFG(b.terms, b.nonTerms, b.prods, start)
+	if err := g.Verify(); err != nil {
+		return nil, nil, err
+	}
+
+	return g, precedences, nil
+}
+
+// precedences creates the precedence levels in the reverse order of declarations,
+// so the levels declared later have higher precedence.
+func (b *builder) precedences() lr.PrecedenceLevels {
+	levels := make(lr.PrecedenceLevels, 0, len(b.p.precedences))
+	declared := make(map[grammar.Terminal]lexer.Position)
+
+	for i := len(b.p.precedences) - 1; i >= 0; i-- {
+		prec := b.p.precedences[i]
+		handles := lr.NewPrecedenceHandles()
+
+		for _, n := range prec.symbols {
+			if n.kind == nodeIdent && b.heads[n.value] {
+				b.errorf(n.pos, "non-terminal %s cannot have a precedence", n.value)
+				continue
+			}
+
+			t := b.terminal(n.value)
+			if pos, ok := declared[t]; ok {
+				b.errorf(n.pos, "precedence for %s already declared at %s", t, pos)
+				continue
+			}
+
+			declared[t] = n.pos
+			handles.Add(lr.PrecedenceHandleForTerminal(t))
+		}
+
+		levels = append(levels, &lr.PrecedenceLevel{
+			Associativity: prec.assoc,
+			Handles:       handles,
+		})
+	}
+
+	return levels
+}
+
+// terminal creates a terminal and adds it to the list of terminals.
+func (b *builder) terminal(name string) grammar.Terminal {
+	t := grammar.Terminal(name)
+	for _, u := range b.terms {
+		if u == t {
+			return t
+		}
+	}
+
+	b.terms = append(b.terms, t)
+
+	return t
+}
+
+// nonTerminal creates a new non-terminal for an EBNF construct in a rule.
+// The name of the new non-terminal is the head of the rule followed by primes (′, ″, ‴, ⁗),
+// following the convention of the grammar package for derived non-terminals.
+func (b *builder) nonTerminal(head grammar.NonTerminal) grammar.NonTerminal {
+	for i := 1; ; i++ {
+		var name strings.Builder
+		name.WriteString(string(head))
+		name.WriteString(strings.Repeat(primes[len(primes)-1], (i-1)/len(primes)))
+		name.WriteString(primes[(i-1)%len(primes)])
+
+		if n := name.String(); !b.names[n] {
+			b.names[n] = true
+			nonTerm := grammar.NonTerminal(n)
+			b.nonTerms = append(b.nonTerms, nonTerm)
+			return nonTerm
+		}
+	}
+}
+
+func (b *builder) sequence(head grammar.NonTerminal, seq []*node) grammar.String[grammar.Symbol] {
+	body := make(grammar.String[grammar.Symbol], 0, len(seq))
+	for _, n := range seq {
+		body = append(body, b.symbol(head, n))
+	}
+
+	return body
+}
+
+// bodies returns the alternatives of a node as production bodies.
+// A group is flattened into its alternatives, and any other node is a body with a single symbol.
+func (b *builder) bodies(head grammar.NonTerminal, n *node) []grammar.String[grammar.Symbol] {
+	if n.kind == nodeGroup {
+		bodies := make([]grammar.String[grammar.Symbol], len(n.alts))
+		for i, seq := range n.alts {
+			bodies[i] = b.sequence(head, seq)
+		}
+		return bodies
+	}
+
+	return []grammar.String[grammar.Symbol]{
+		{b.symbol(head, n)},
+	}
+}
+
+// symbol translates a node into a grammar symbol.
+// EBNF constructs are translated into new non-terminals with the following production rules:
+//
+//	( α | β )  →  N → α | β
+//	X?         →  N → X | ε
+//	X*         →  N → N X | ε
+//	X+         →  N → N X | X
+func (b *builder) symbol(head grammar.NonTerminal, n *node) grammar.Symbol {
+	switch n.kind {
+	case nodeIdent:
+		if b.heads[n.value] {
+			return grammar.NonTerminal(n.value)
+		}
+		return b.terminal(n.value)
+
+	case nodeLiteral:
+		return b.terminal(n.value)
+
+	case nodeGroup:
+		// A group with a single symbol is the symbol itself.
+		if len(n.alts) == 1 && len(n.alts[0]) == 1 {
+			return b.symbol(head, n.alts[0][0])
+		}
+	}
+
+	key := n.String()
+	if nonTerm, ok := b.memo[key]; ok {
+		return nonTerm
+	}
+
+	nonTerm := b.nonTerminal(head)
+	b.memo[key] = nonTerm
+
+	var bodies []grammar.String[grammar.Symbol]
+	switch n.kind {
+	case nodeGroup:
+		bodies = b.bodies(head, n)
+
+	case nodeOptional:
+		bodies = append(b.bodies(head, n.child), grammar.E)
+
+	case nodeStar:
+		for _, body := range b.bodies(head, n.child) {
+			bodies = append(bodies, append(grammar.String[grammar.Symbol]{nonTerm}, body...))
+		}
+		bodies = append(bodies, grammar.E)
+
+	case nodePlus:
+		for _, body := range b.bodies(head, n.child) {
+			bodies = append(bodies, append(grammar.String[grammar.Symbol]{nonTerm}, body...), body)
+		}
+	}
+
+	for _, body := range bodies {
+		b.prods = append(b.prods, &grammar.Production{
+			Head: nonTerm,
+			Body: body,
+		})
+	}
+
+	return nonTerm
+}
@@ -0,0 +1,209 @@
+package spec
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/grammar"
+	"github.com/moorara/algo/parser/lr"
+)
+
+func TestSpecError(t *testing.T) {
+	tests := []struct {
+		name          string
+		e             *SpecError
+		expectedError string
+	}{
+		{
+			name: "OK",
+			e: &SpecError{
+				Description: "unexpected character '@'",
+				Pos:         pos(10, 2, 4),
+			},
+			expectedError: "test:2:4: unexpected character '@'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, tc.e, tc.expectedError)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name                string
+		src                 io.Reader
+		expectedCFG         *grammar.CFG
+		expectedPrecedences lr.PrecedenceLevels
+		expectedError       string
+	}{
+		{
+			name: "BNF",
+			src: strings.NewReader(`
+				%token unused
+
+				%left "+" "-"
+				%left "*" "/"
+				%right "^"
+
+				expr ::= expr "+" expr | expr "-" expr
+				       | expr "*" expr | expr "/" expr
+				       | expr "^" expr
+				       | "(" expr ")"
+				       | id
+				       ;
+			`),
+			expectedCFG: grammar.NewCFG(
+				[]grammar.Terminal{"unused", "+", "-", "*", "/", "^", "(", ")", "id"},
+				[]grammar.NonTerminal{"expr"},
+				[]*grammar.Production{
+					{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("+"), grammar.NonTerminal("expr")}},
+					{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("-"), grammar.NonTerminal("expr")}},
+					{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("*"), grammar.NonTerminal("expr")}},
+					{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("/"), grammar.NonTerminal("expr")}},
+					{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("expr"), grammar.Terminal("^"), grammar.NonTerminal("expr")}},
+					{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("("), grammar.NonTerminal("expr"), grammar.Terminal(")")}},
+					{Head: "expr", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}},
+				},
+				"expr",
+			),
+			expectedPrecedences: lr.PrecedenceLevels{
+				{
+					Associativity: lr.RIGHT,
+					Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("^")),
+				},
+				{
+					Associativity: lr.LEFT,
+					Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("*"), lr.PrecedenceHandleForTerminal("/")),
+				},
+				{
+					Associativity: lr.LEFT,
+					Handles:       lr.NewPrecedenceHandles(lr.PrecedenceHandleForTerminal("+"), lr.PrecedenceHandleForTerminal("-")),
+				},
+			},
+		},
+		{
+			name: "EBNF",
+			src: strings.NewReader(`
+				%start list
+
+				item : "x" | ε ;
+				list = "[" ( item ( "," item )* )? "]" ;
+				ids  = ( id )+ ;
+			`),
+			expectedCFG: grammar.NewCFG(
+				[]grammar.Terminal{"x", "[", ",", "]", "id"},
+				[]grammar.NonTerminal{"item", "list", "list′", "list″", "ids", "ids′"},
+				[]*grammar.Production{
+					{Head: "item", Body: grammar.String[grammar.Symbol]{grammar.Terminal("x")}},
+					{Head: "item", Body: grammar.E},
+					{Head: "list", Body: grammar.String[grammar.Symbol]{grammar.Terminal("["), grammar.NonTerminal("list′"), grammar.Terminal("]")}},
+					{Head: "list′", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("item"), grammar.NonTerminal("list″")}},
+					{Head: "list′", Body: grammar.E},
+					{Head: "list″", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("list″"), grammar.Terminal(","), grammar.NonTerminal("item")}},
+					{Head: "list″", Body: grammar.E},
+					{Head: "ids", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("ids′")}},
+					{Head: "ids′", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("ids′"), grammar.Terminal("id")}},
+					{Head: "ids′", Body: grammar.String[grammar.Symbol]{grammar.Terminal("id")}},
+				},
+				"list",
+			),
+			expectedPrecedences: lr.PrecedenceLevels{},
+		},
+		{
+			name: "ManyEBNFConstructs",
+			src:  strings.NewReader(`s = "a"? "b"? "c"? "d"? "e"? ;`),
+			expectedCFG: grammar.NewCFG(
+				[]grammar.Terminal{"a", "b", "c", "d", "e"},
+				[]grammar.NonTerminal{"s", "s′", "s″", "s‴", "s⁗", "s⁗′"},
+				[]*grammar.Production{
+					{Head: "s", Body: grammar.String[grammar.Symbol]{grammar.NonTerminal("s′"), grammar.NonTerminal("s″"), grammar.NonTerminal("s‴"), grammar.NonTerminal("s⁗"), grammar.NonTerminal("s⁗′")}},
+					{Head: "s′", Body: grammar.String[grammar.Symbol]{grammar.Terminal("a")}},
+					{Head: "s′", Body: grammar.E},
+					{Head: "s″", Body: grammar.String[grammar.Symbol]{grammar.Terminal("b")}},
+					{Head: "s″", Body: grammar.E},
+					{Head: "s‴", Body: grammar.String[grammar.Symbol]{grammar.Terminal("c")}},
+					{Head: "s‴", Body: grammar.E},
+					{Head: "s⁗", Body: grammar.String[grammar.Symbol]{grammar.Terminal("d")}},
+					{Head: "s⁗", Body: grammar.E},
+					{Head: "s⁗′", Body: grammar.String[grammar.Symbol]{grammar.Terminal("e")}},
+					{Head: "s⁗′", Body: grammar.E},
+				},
+				"s",
+			),
+			expectedPrecedences: lr.PrecedenceLevels{},
+		},
+		{
+			name:          "ReaderError",
+			src:           iotest.ErrReader(errors.New("io error")),
+			expectedError: "io error",
+		},
+		{
+			name:          "ScannerErrors",
+			src:           strings.NewReader("expr = id @ ;\n%foo"),
+			expectedError: "test:1:11: unexpected character '@'\ntest:2:1: unknown directive %foo\n",
+		},
+		{
+			name: "SyntaxErrors",
+			src: strings.NewReader(`%left
+%token
+%start
+%start a %start b
+x ) a = b c? ;
+a = * b ;
+a = ( b ;
+a = b ) ;
+a = b
+b = c ;`),
+			expectedError: "test:1:1: %left requires at least one terminal\n" +
+				"test:2:1: %token requires at least one identifier\n" +
+				"test:3:1: %start requires an identifier\n" +
+				"test:4:10: start symbol already declared at test:4:8\n" +
+				"test:5:1: unexpected identifier x, expecting a directive or a rule\n" +
+				"test:6:5: unexpected \"*\", missing operand\n" +
+				"test:7:9: unexpected \";\", missing ) for ( at test:7:5\n" +
+				"test:8:7: unexpected \")\" in rule a\n" +
+				"test:10:1: missing ; at the end of rule a\n",
+		},
+		{
+			name:          "NoRules",
+			src:           strings.NewReader("%token a\n"),
+			expectedError: "test:2:1: no production rules\n",
+		},
+		{
+			name: "SemanticErrors",
+			src: strings.NewReader(`%token expr
+%start stmt
+%left expr "+"
+%right "+"
+expr = expr "+" expr | id ;`),
+			expectedError: "test:1:8: token expr cannot be the head of a rule\n" +
+				"test:2:8: start symbol stmt has no production rule\n" +
+				"test:3:7: non-terminal expr cannot have a precedence\n" +
+				"test:3:12: precedence for \"+\" already declared at test:4:8\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g, precedences, err := Load("test", tc.src)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.True(t, g.Equal(tc.expectedCFG), "Expected:\n%s\nActual:\n%s", tc.expectedCFG, g)
+				assert.True(t, precedences.Equal(tc.expectedPrecedences), "Expected:\n%s\nActual:\n%s", tc.expectedPrecedences, precedences)
+			} else {
+				assert.Nil(t, g)
+				assert.Nil(t, precedences)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
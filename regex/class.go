@@ -0,0 +1,113 @@
+package regex
+
+import (
+	"unicode"
+
+	"github.com/moorara/algo/automata"
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/range/disc"
+)
+
+// anyRange is the range of all valid characters (Unicode code points).
+var anyRange = disc.Range[automata.Symbol]{Lo: 0, Hi: unicode.MaxRune}
+
+// perlClasses are the character classes available through escape sequences (\d, \s, \w).
+var perlClasses = map[rune]charSet{
+	'd': newCharSet(r('0', '9')),
+	's': newCharSet(r('\t', '\n'), r('\f', '\r'), r(' ', ' ')),
+	'w': newCharSet(r('0', '9'), r('A', 'Z'), r('_', '_'), r('a', 'z')),
+}
+
+// asciiClasses are the POSIX character classes available inside bracket expressions ([[:alpha:]]).
+var asciiClasses = map[string]charSet{
+	"alnum":  newCharSet(r('0', '9'), r('A', 'Z'), r('a', 'z')),
+	"alpha":  newCharSet(r('A', 'Z'), r('a', 'z')),
+	"ascii":  newCharSet(r(0x00, 0x7F)),
+	"blank":  newCharSet(r('\t', '\t'), r(' ', ' ')),
+	"cntrl":  newCharSet(r(0x00, 0x1F), r(0x7F, 0x7F)),
+	"digit":  newCharSet(r('0', '9')),
+	"graph":  newCharSet(r('!', '~')),
+	"lower":  newCharSet(r('a', 'z')),
+	"print":  newCharSet(r(' ', '~')),
+	"punct":  newCharSet(r('!', '/'), r(':', '@'), r('[', '`'), r('{', '~')),
+	"space":  newCharSet(r('\t', '\r'), r(' ', ' ')),
+	"upper":  newCharSet(r('A', 'Z')),
+	"word":   newCharSet(r('0', '9'), r('A', 'Z'), r('_', '_'), r('a', 'z')),
+	"xdigit": newCharSet(r('0', '9'), r('A', 'F'), r('a', 'f')),
+}
+
+// r is a shorthand for creating a range of characters.
+func r(lo, hi rune) disc.Range[automata.Symbol] {
+	return disc.Range[automata.Symbol]{Lo: automata.Symbol(lo), Hi: automata.Symbol(hi)}
+}
+
+// charSet represents a set of characters as a sorted list of non-overlapping ranges.
+type charSet []disc.Range[automata.Symbol]
+
+// newCharSet creates a new set of characters from the given ranges.
+// Overlapping and adjacent ranges are merged.
+func newCharSet(rs ...disc.Range[automata.Symbol]) charSet {
+	l := disc.NewRangeList(nil, rs...)
+	return generic.Collect1(l.All())
+}
+
+// Union returns a new set of characters including the characters from both sets.
+func (s charSet) Union(t charSet) charSet {
+	all := make([]disc.Range[automata.Symbol], 0, len(s)+len(t))
+	all = append(all, s...)
+	all = append(all, t...)
+
+	return newCharSet(all...)
+}
+
+// Negate returns a new set of characters including all valid characters not in the set.
+func (s charSet) Negate() charSet {
+	l := disc.NewRangeList(nil, anyRange)
+	l.Remove(s...)
+
+	return generic.Collect1(l.All())
+}
+
+// unicodeClass returns the set of characters for a Unicode category or script.
+func unicodeClass(name string) (charSet, bool) {
+	if name == "Any" {
+		return charSet{anyRange}, true
+	}
+
+	if t, ok := unicode.Categories[name]; ok {
+		return rangeTableToCharSet(t), true
+	}
+
+	if t, ok := unicode.Scripts[name]; ok {
+		return rangeTableToCharSet(t), true
+	}
+
+	return nil, false
+}
+
+// rangeTableToCharSet converts a Unicode range table into a set of characters.
+func rangeTableToCharSet(t *unicode.RangeTable) charSet {
+	rs := make([]disc.Range[automata.Symbol], 0, len(t.R16)+len(t.R32))
+
+	for _, rr := range t.R16 {
+		if rr.Stride == 1 {
+			rs = append(rs, r(rune(rr.Lo), rune(rr.Hi)))
+		} else {
+			for c := rune(rr.Lo); c <= rune(rr.Hi); c += rune(rr.Stride) {
+				rs = append(rs, r(c, c))
+			}
+		}
+	}
+
+	for _, rr := range t.R32 {
+		if rr.Stride == 1 {
+			rs = append(rs, r(rune(rr.Lo), rune(rr.Hi)))
+		} else {
+			for c := rune(rr.Lo); c <= rune(rr.Hi); c += rune(rr.Stride) {
+				rs = append(rs, r(c, c))
+			}
+		}
+	}
+
+	return newCharSet(rs...)
+}
@@ -0,0 +1,160 @@
+package regex
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCharSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		set         charSet
+		expectedSet charSet
+	}{
+		{
+			name:        "Empty",
+			set:         newCharSet(),
+			expectedSet: charSet{},
+		},
+		{
+			name:        "Merged",
+			set:         newCharSet(r('a', 'f'), r('0', '9'), r('d', 'z'), r('A', 'Z'), r('[', '[')),
+			expectedSet: charSet{r('0', '9'), r('A', '['), r('a', 'z')},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSet, tc.set)
+		})
+	}
+}
+
+func TestCharSet_Union(t *testing.T) {
+	tests := []struct {
+		name        string
+		s, t        charSet
+		expectedSet charSet
+	}{
+		{
+			name:        "Nil",
+			s:           nil,
+			t:           newCharSet(r('a', 'z')),
+			expectedSet: charSet{r('a', 'z')},
+		},
+		{
+			name:        "Overlapping",
+			s:           newCharSet(r('0', '9'), r('a', 'f')),
+			t:           newCharSet(r('A', 'F'), r('c', 'z')),
+			expectedSet: charSet{r('0', '9'), r('A', 'F'), r('a', 'z')},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSet, tc.s.Union(tc.t))
+		})
+	}
+}
+
+func TestCharSet_Negate(t *testing.T) {
+	tests := []struct {
+		name        string
+		set         charSet
+		expectedSet charSet
+	}{
+		{
+			name:        "Empty",
+			set:         charSet{},
+			expectedSet: charSet{r(0, unicode.MaxRune)},
+		},
+		{
+			name:        "All",
+			set:         charSet{r(0, unicode.MaxRune)},
+			expectedSet: charSet{},
+		},
+		{
+			name:        "Digits",
+			set:         newCharSet(r('0', '9')),
+			expectedSet: charSet{r(0, '/'), r(':', unicode.MaxRune)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSet, tc.set.Negate())
+		})
+	}
+}
+
+func TestUnicodeClass(t *testing.T) {
+	tests := []struct {
+		name        string
+		class       string
+		expectedOK  bool
+		expectedSet charSet
+	}{
+		{
+			name:        "Any",
+			class:       "Any",
+			expectedOK:  true,
+			expectedSet: charSet{r(0, unicode.MaxRune)},
+		},
+		{
+			name:        "Category",
+			class:       "Zl",
+			expectedOK:  true,
+			expectedSet: charSet{r(0x2028, 0x2028)},
+		},
+		{
+			name:        "Script",
+			class:       "Ogham",
+			expectedOK:  true,
+			expectedSet: charSet{r(0x1680, 0x169C)},
+		},
+		{
+			name:       "Invalid",
+			class:      "Foo",
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			set, ok := unicodeClass(tc.class)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedSet, set)
+		})
+	}
+}
+
+func TestRangeTableToCharSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		table       *unicode.RangeTable
+		expectedSet charSet
+	}{
+		{
+			name: "OK",
+			table: &unicode.RangeTable{
+				R16: []unicode.Range16{
+					{Lo: 'a', Hi: 'c', Stride: 1},
+					{Lo: 'x', Hi: 'z', Stride: 2},
+				},
+				R32: []unicode.Range32{
+					{Lo: 0x1F600, Hi: 0x1F64F, Stride: 1},
+				},
+			},
+			expectedSet: charSet{r('a', 'c'), r('x', 'x'), r('z', 'z'), r(0x1F600, 0x1F64F)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSet, rangeTableToCharSet(tc.table))
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package regex
+
+import "github.com/moorara/algo/automata"
+
+// fragment is a partial NFA with a single start state and a single final state.
+type fragment struct {
+	start, end automata.State
+}
+
+// compiler translates an abstract syntax tree into an NFA.
+// It implements the McNaughton-Yamada-Thompson algorithm.
+//
+// All fragments are added to a single NFA builder,
+// so the construction is linear in the size of the regular expression.
+type compiler struct {
+	b    *automata.NFABuilder
+	last automata.State
+}
+
+func newCompiler() *compiler {
+	return &compiler{
+		b:    automata.NewNFABuilder(),
+		last: -1,
+	}
+}
+
+// newState creates a new state.
+func (c *compiler) newState() automata.State {
+	c.last++
+	return c.last
+}
+
+// ε adds an ε-transition from state s to state t.
+func (c *compiler) ε(s, t automata.State) {
+	c.b.AddTransition(s, automata.E, automata.E, []automata.State{t})
+}
+
+// compile constructs a fragment recognizing the language of the given node.
+func (c *compiler) compile(n node) fragment {
+	switch n := n.(type) {
+	case *emptyNode:
+		// i --ε--> f
+		f := fragment{c.newState(), c.newState()}
+		c.ε(f.start, f.end)
+		return f
+
+	case *charNode:
+		// i --a--> f
+		f := fragment{c.newState(), c.newState()}
+		for _, r := range n.set {
+			c.b.AddTransition(f.start, r.Lo, r.Hi, []automata.State{f.end})
+		}
+		return f
+
+	case *concatNode:
+		// The final state of each fragment is connected to the start state of the next one.
+		f := c.compile(n.nodes[0])
+		for _, m := range n.nodes[1:] {
+			g := c.compile(m)
+			c.ε(f.end, g.start)
+			f.end = g.end
+		}
+		return f
+
+	case *altNode:
+		// A new start state branches into all fragments, and all fragments join into a new final state.
+		f := fragment{c.newState(), c.newState()}
+		for _, m := range n.nodes {
+			g := c.compile(m)
+			c.ε(f.start, g.start)
+			c.ε(g.end, f.end)
+		}
+		return f
+
+	case *repeatNode:
+		return c.compileRepeat(n)
+	}
+
+	panic("regex: unknown node type")
+}
+
+// compileRepeat constructs a fragment for x{min,max} by expanding it to
+// min mandatory copies of x followed by either x* (unbounded) or max-min optional copies of x.
+func (c *compiler) compileRepeat(n *repeatNode) fragment {
+	f := fragment{c.newState(), -1}
+	f.end = f.start
+
+	for range n.min {
+		g := c.compile(n.node)
+		c.ε(f.end, g.start)
+		f.end = g.end
+	}
+
+	if n.max < 0 {
+		// Kleene star: the fragment can be skipped or repeated.
+		g := c.compile(n.node)
+		end := c.newState()
+		c.ε(f.end, g.start)
+		c.ε(f.end, end)
+		c.ε(g.end, g.start)
+		c.ε(g.end, end)
+		f.end = end
+
+		return f
+	}
+
+	// Nested optionals: x{0,2} ≡ (x(x)?)?
+	end := c.newState()
+	for range n.max - n.min {
+		g := c.compile(n.node)
+		c.ε(f.end, g.start)
+		c.ε(f.end, end)
+		f.end = g.end
+	}
+	c.ε(f.end, end)
+	f.end = end
+
+	return f
+}
@@ -0,0 +1,113 @@
+package regex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/automata"
+)
+
+func TestCompiler_compile(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           node
+		expectedNFA *automata.NFA
+	}{
+		{
+			name: "Empty",
+			n:    &emptyNode{},
+			expectedNFA: automata.NewNFABuilder().
+				SetStart(0).
+				SetFinal([]automata.State{1}).
+				AddTransition(0, automata.E, automata.E, []automata.State{1}).
+				Build(),
+		},
+		{
+			name: "Char",
+			n:    &charNode{set: newCharSet(r('0', '9'), r('a', 'f'))},
+			expectedNFA: automata.NewNFABuilder().
+				SetStart(0).
+				SetFinal([]automata.State{1}).
+				AddTransition(0, '0', '9', []automata.State{1}).
+				AddTransition(0, 'a', 'f', []automata.State{1}).
+				Build(),
+		},
+		{
+			name: "Concat",
+			n: &concatNode{
+				nodes: []node{
+					&charNode{set: newCharSet(r('a', 'a'))},
+					&charNode{set: newCharSet(r('b', 'b'))},
+				},
+			},
+			expectedNFA: automata.NewNFABuilder().
+				SetStart(0).
+				SetFinal([]automata.State{3}).
+				AddTransition(0, 'a', 'a', []automata.State{1}).
+				AddTransition(1, automata.E, automata.E, []automata.State{2}).
+				AddTransition(2, 'b', 'b', []automata.State{3}).
+				Build(),
+		},
+		{
+			name: "Alternation",
+			n: &altNode{
+				nodes: []node{
+					&charNode{set: newCharSet(r('a', 'a'))},
+					&charNode{set: newCharSet(r('b', 'b'))},
+				},
+			},
+			expectedNFA: automata.NewNFABuilder().
+				SetStart(0).
+				SetFinal([]automata.State{1}).
+				AddTransition(0, automata.E, automata.E, []automata.State{2, 4}).
+				AddTransition(2, 'a', 'a', []automata.State{3}).
+				AddTransition(3, automata.E, automata.E, []automata.State{1}).
+				AddTransition(4, 'b', 'b', []automata.State{5}).
+				AddTransition(5, automata.E, automata.E, []automata.State{1}).
+				Build(),
+		},
+		{
+			name: "Star",
+			n: &repeatNode{
+				node: &charNode{set: newCharSet(r('a', 'a'))},
+				min:  0,
+				max:  -1,
+			},
+			expectedNFA: automata.NewNFABuilder().
+				SetStart(0).
+				SetFinal([]automata.State{3}).
+				AddTransition(0, automata.E, automata.E, []automata.State{1, 3}).
+				AddTransition(1, 'a', 'a', []automata.State{2}).
+				AddTransition(2, automata.E, automata.E, []automata.State{1, 3}).
+				Build(),
+		},
+		{
+			name: "Repeat",
+			n: &repeatNode{
+				node: &charNode{set: newCharSet(r('a', 'a'))},
+				min:  1,
+				max:  2,
+			},
+			expectedNFA: automata.NewNFABuilder().
+				SetStart(0).
+				SetFinal([]automata.State{3}).
+				AddTransition(0, automata.E, automata.E, []automata.State{1}).
+				AddTransition(1, 'a', 'a', []automata.State{2}).
+				AddTransition(2, automata.E, automata.E, []automata.State{3, 4}).
+				AddTransition(4, 'a', 'a', []automata.State{5}).
+				AddTransition(5, automata.E, automata.E, []automata.State{3}).
+				Build(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newCompiler()
+			f := c.compile(tc.n)
+			nfa := c.b.SetStart(f.start).SetFinal([]automata.State{f.end}).Build()
+
+			assert.True(t, nfa.Equal(tc.expectedNFA), "Expected:\n%s\nGot:\n%s", tc.expectedNFA, nfa)
+		})
+	}
+}
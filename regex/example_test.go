@@ -0,0 +1,22 @@
+package regex_test
+
+import (
+	"fmt"
+
+	"github.com/moorara/algo/automata"
+	"github.com/moorara/algo/regex"
+)
+
+func ExampleCompile() {
+	nfa, err := regex.Compile(`[A-Za-z_][0-9A-Za-z_]*`)
+	if err != nil {
+		panic(err)
+	}
+
+	dfa := nfa.ToDFA().Minimize().EliminateDeadStates().ReindexStates()
+	fmt.Println(dfa)
+
+	r := dfa.Runner()
+	fmt.Println(r.Accept(automata.String{'i', 'd', '_', '1'}))
+	fmt.Println(r.Accept(automata.String{'1', 'i', 'd'}))
+}
@@ -0,0 +1,671 @@
+package regex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/moorara/algo/automata"
+)
+
+// node is a node in the abstract syntax tree of a regular expression.
+type node interface {
+	fmt.Stringer
+}
+
+type (
+	// emptyNode represents the empty string ε.
+	emptyNode struct{}
+
+	// charNode represents a set of characters, any of which matches a single character.
+	charNode struct {
+		set charSet
+	}
+
+	// concatNode represents the concatenation of regular expressions.
+	concatNode struct {
+		nodes []node
+	}
+
+	// altNode represents the alternation (union) of regular expressions.
+	altNode struct {
+		nodes []node
+	}
+
+	// repeatNode represents the repetition of a regular expression.
+	// A negative max indicates an unbounded repetition.
+	repeatNode struct {
+		node     node
+		min, max int
+	}
+)
+
+// String implements the fmt.Stringer interface.
+func (n *emptyNode) String() string {
+	return "ε"
+}
+
+// String implements the fmt.Stringer interface.
+func (n *charNode) String() string {
+	if len(n.set) == 1 && n.set[0].Lo == n.set[0].Hi {
+		return formatChar(n.set[0].Lo)
+	}
+
+	var b strings.Builder
+
+	b.WriteRune('[')
+	for _, r := range n.set {
+		b.WriteString(formatChar(r.Lo))
+		if r.Lo != r.Hi {
+			b.WriteRune('-')
+			b.WriteString(formatChar(r.Hi))
+		}
+	}
+	b.WriteRune(']')
+
+	return b.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (n *concatNode) String() string {
+	var b strings.Builder
+
+	b.WriteRune('(')
+	for _, m := range n.nodes {
+		b.WriteString(m.String())
+	}
+	b.WriteRune(')')
+
+	return b.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (n *altNode) String() string {
+	ss := make([]string, len(n.nodes))
+	for i, m := range n.nodes {
+		ss[i] = m.String()
+	}
+
+	return "(" + strings.Join(ss, "|") + ")"
+}
+
+// String implements the fmt.Stringer interface.
+func (n *repeatNode) String() string {
+	switch {
+	case n.min == 0 && n.max == 1:
+		return n.node.String() + "?"
+	case n.min == 0 && n.max < 0:
+		return n.node.String() + "*"
+	case n.min == 1 && n.max < 0:
+		return n.node.String() + "+"
+	case n.max < 0:
+		return fmt.Sprintf("%s{%d,}", n.node, n.min)
+	case n.min == n.max:
+		return fmt.Sprintf("%s{%d}", n.node, n.min)
+	default:
+		return fmt.Sprintf("%s{%d,%d}", n.node, n.min, n.max)
+	}
+}
+
+func formatChar(c automata.Symbol) string {
+	if strings.ContainsRune(`\.+*?()|[]{}^$-`, rune(c)) {
+		return `\` + string(rune(c))
+	}
+
+	if strconv.IsPrint(rune(c)) {
+		return string(rune(c))
+	}
+
+	return fmt.Sprintf(`\x{%X}`, c)
+}
+
+/* ------------------------------------------------------------------------------------------------------------------------ */
+
+// parser is a recursive-descent parser for regular expressions.
+//
+// The grammar of regular expressions is as follows:
+//
+//	regex   → alt
+//	alt     → concat ( "|" concat )*
+//	concat  → repeat*
+//	repeat  → atom ( "*" | "+" | "?" | "{" num ( "," num? )? "}" )?
+//	atom    → char | "." | class | escape | "(" ( "?:" )? alt ")"
+type parser struct {
+	runes []rune
+	pos   int
+}
+
+// parse parses a regular expression into an abstract syntax tree.
+func parse(regex string) (node, error) {
+	p := &parser{
+		runes: []rune(regex),
+	}
+
+	n, err := p.parseAlt()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.eof() {
+		// The only way for parseAlt to stop before the end of input is an unmatched closing parenthesis.
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+
+	return n, nil
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.runes)
+}
+
+func (p *parser) peek() rune {
+	return p.runes[p.pos]
+}
+
+func (p *parser) lookahead(s string) bool {
+	return strings.HasPrefix(string(p.runes[p.pos:]), s)
+}
+
+func (p *parser) next() rune {
+	c := p.runes[p.pos]
+	p.pos++
+	return c
+}
+
+func (p *parser) errorf(format string, a ...any) error {
+	return &SyntaxError{
+		Description: fmt.Sprintf(format, a...),
+		Pos:         p.pos,
+	}
+}
+
+// parseAlt parses: alt → concat ( "|" concat )*
+func (p *parser) parseAlt() (node, error) {
+	var nodes []node
+
+	for {
+		n, err := p.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+
+		if p.eof() || p.peek() != '|' {
+			break
+		}
+
+		p.next()
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+
+	return &altNode{nodes: nodes}, nil
+}
+
+// parseConcat parses: concat → repeat*
+func (p *parser) parseConcat() (node, error) {
+	var nodes []node
+
+	for !p.eof() && p.peek() != '|' && p.peek() != ')' {
+		n, err := p.parseRepeat()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+	}
+
+	switch len(nodes) {
+	case 0:
+		return &emptyNode{}, nil
+	case 1:
+		return nodes[0], nil
+	default:
+		return &concatNode{nodes: nodes}, nil
+	}
+}
+
+// parseRepeat parses: repeat → atom ( "*" | "+" | "?" | "{" num ( "," num? )? "}" )?
+func (p *parser) parseRepeat() (node, error) {
+	n, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+
+	min, max, ok, err := p.parseQuantifier()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return n, nil
+	}
+
+	// Lazy quantifiers and nested repetitions are ambiguous and not supported.
+	if _, _, ok, _ := p.parseQuantifier(); ok {
+		return nil, p.errorf("invalid nested repetition operator")
+	}
+
+	return &repeatNode{node: n, min: min, max: max}, nil
+}
+
+// parseQuantifier parses a quantifier if there is one.
+// The third return value is false if the input does not start with a quantifier.
+func (p *parser) parseQuantifier() (int, int, bool, error) {
+	if p.eof() {
+		return 0, 0, false, nil
+	}
+
+	switch p.peek() {
+	case '*':
+		p.next()
+		return 0, -1, true, nil
+	case '+':
+		p.next()
+		return 1, -1, true, nil
+	case '?':
+		p.next()
+		return 0, 1, true, nil
+	case '{':
+		return p.parseCount()
+	}
+
+	return 0, 0, false, nil
+}
+
+// parseCount parses a counted repetition: "{" num ( "," num? )? "}"
+// If the input is not a well-formed counted repetition, the opening brace is treated as a literal.
+func (p *parser) parseCount() (int, int, bool, error) {
+	start := p.pos
+	p.next() // {
+
+	min, ok := p.parseNum()
+	if !ok {
+		p.pos = start
+		return 0, 0, false, nil
+	}
+
+	max := min
+
+	if !p.eof() && p.peek() == ',' {
+		p.next()
+
+		if max, ok = p.parseNum(); !ok {
+			max = -1
+		}
+	}
+
+	if p.eof() || p.peek() != '}' {
+		p.pos = start
+		return 0, 0, false, nil
+	}
+
+	p.next() // }
+
+	if min > maxRepeat || max > maxRepeat {
+		p.pos = start
+		return 0, 0, false, p.errorf("repetition count exceeds %d", maxRepeat)
+	}
+
+	if max >= 0 && min > max {
+		p.pos = start
+		return 0, 0, false, p.errorf("invalid repetition range {%d,%d}", min, max)
+	}
+
+	return min, max, true, nil
+}
+
+// parseNum parses a decimal number.
+func (p *parser) parseNum() (int, bool) {
+	start := p.pos
+	for !p.eof() && '0' <= p.peek() && p.peek() <= '9' {
+		p.next()
+	}
+
+	if p.pos == start {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(string(p.runes[start:p.pos]))
+	if err != nil {
+		// Out of range, any count larger than maxRepeat will be rejected.
+		return maxRepeat + 1, true
+	}
+
+	return n, true
+}
+
+// parseAtom parses: atom → char | "." | class | escape | "(" ( "?:" )? alt ")"
+func (p *parser) parseAtom() (node, error) {
+	switch c := p.peek(); c {
+	case '(':
+		p.next()
+
+		if p.lookahead("?:") {
+			p.pos += 2
+		} else if p.lookahead("?") {
+			return nil, p.errorf("invalid or unsupported group flags")
+		}
+
+		n, err := p.parseAlt()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.eof() {
+			return nil, p.errorf("missing closing )")
+		}
+
+		p.next() // )
+
+		return n, nil
+
+	case '[':
+		set, err := p.parseClass()
+		if err != nil {
+			return nil, err
+		}
+
+		return &charNode{set: set}, nil
+
+	case '.':
+		p.next()
+		return &charNode{set: newCharSet(r('\n', '\n')).Negate()}, nil
+
+	case '\\':
+		set, err := p.parseEscape()
+		if err != nil {
+			return nil, err
+		}
+
+		return &charNode{set: set}, nil
+
+	case '*', '+', '?':
+		return nil, p.errorf("missing argument to repetition operator %q", c)
+
+	case '{':
+		start := p.pos
+		if _, _, ok, err := p.parseCount(); err != nil || ok {
+			p.pos = start
+			return nil, p.errorf("missing argument to repetition operator")
+		}
+
+		p.next()
+		return &charNode{set: newCharSet(r(c, c))}, nil
+
+	case '^', '$':
+		return nil, p.errorf("anchor %q is not supported", c)
+
+	default:
+		p.next()
+		return &charNode{set: newCharSet(r(c, c))}, nil
+	}
+}
+
+// parseClass parses a character class: "[" "^"? item+ "]"
+func (p *parser) parseClass() (charSet, error) {
+	start := p.pos
+	p.next() // [
+
+	negated := false
+	if !p.eof() && p.peek() == '^' {
+		p.next()
+		negated = true
+	}
+
+	var set charSet
+
+	// A closing bracket at the beginning of a class is a literal.
+	for first := true; p.eof() || p.peek() != ']' || first; first = false {
+		if p.eof() {
+			p.pos = start
+			return nil, p.errorf("missing closing ]")
+		}
+
+		// POSIX character classes
+		if p.lookahead("[:") {
+			s, err := p.parseASCIIClass()
+			if err != nil {
+				return nil, err
+			}
+
+			set = set.Union(s)
+			continue
+		}
+
+		lo, s, err := p.parseClassChar()
+		if err != nil {
+			return nil, err
+		}
+
+		if s != nil {
+			set = set.Union(s)
+			continue
+		}
+
+		hi := lo
+
+		// A hyphen at the end of a class is a literal.
+		if p.lookahead("-") && !p.lookahead("-]") && p.pos+1 < len(p.runes) {
+			p.next() // -
+
+			pos := p.pos
+			if hi, s, err = p.parseClassChar(); err != nil {
+				return nil, err
+			}
+
+			if s != nil {
+				p.pos = pos
+				return nil, p.errorf("invalid character class range")
+			}
+
+			if lo > hi {
+				p.pos = pos
+				return nil, p.errorf("invalid character class range %s-%s", formatChar(automata.Symbol(lo)), formatChar(automata.Symbol(hi)))
+			}
+		}
+
+		set = set.Union(newCharSet(r(lo, hi)))
+	}
+
+	p.next() // ]
+
+	if negated {
+		set = set.Negate()
+	}
+
+	return set, nil
+}
+
+// parseASCIIClass parses a POSIX character class: "[:" "^"? name ":]"
+func (p *parser) parseASCIIClass() (charSet, error) {
+	start := p.pos
+
+	end := p.pos + 2
+	for end+1 < len(p.runes) && (p.runes[end] != ':' || p.runes[end+1] != ']') {
+		end++
+	}
+
+	if end+1 >= len(p.runes) {
+		return nil, p.errorf("missing closing :]")
+	}
+
+	name := string(p.runes[p.pos+2 : end])
+	p.pos = end + 2
+
+	negated := strings.HasPrefix(name, "^")
+	if negated {
+		name = name[1:]
+	}
+
+	set, ok := asciiClasses[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("invalid character class [:%s:]", name)
+	}
+
+	if negated {
+		set = set.Negate()
+	}
+
+	return set, nil
+}
+
+// parseClassChar parses a single character or an escape sequence inside a character class.
+// If the escape sequence denotes a class of characters, the set is returned as the second value.
+func (p *parser) parseClassChar() (rune, charSet, error) {
+	if p.peek() != '\\' {
+		return p.next(), nil, nil
+	}
+
+	set, err := p.parseEscape()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(set) == 1 && set[0].Lo == set[0].Hi {
+		return rune(set[0].Lo), nil, nil
+	}
+
+	return 0, set, nil
+}
+
+// parseEscape parses an escape sequence starting with a backslash.
+func (p *parser) parseEscape() (charSet, error) {
+	start := p.pos
+	p.next() // \
+
+	if p.eof() {
+		p.pos = start
+		return nil, p.errorf("trailing backslash at end of expression")
+	}
+
+	c := p.next()
+
+	switch c {
+	case 'a':
+		return newCharSet(r('\a', '\a')), nil
+	case 'f':
+		return newCharSet(r('\f', '\f')), nil
+	case 't':
+		return newCharSet(r('\t', '\t')), nil
+	case 'n':
+		return newCharSet(r('\n', '\n')), nil
+	case 'r':
+		return newCharSet(r('\r', '\r')), nil
+	case 'v':
+		return newCharSet(r('\v', '\v')), nil
+	case '0':
+		return newCharSet(r(0, 0)), nil
+
+	case 'd', 's', 'w':
+		return perlClasses[c], nil
+	case 'D', 'S', 'W':
+		return perlClasses[c+'a'-'A'].Negate(), nil
+
+	case 'x', 'u':
+		v, ok := p.parseHex(c)
+		if !ok {
+			p.pos = start
+			return nil, p.errorf("invalid hexadecimal escape")
+		}
+
+		return newCharSet(r(v, v)), nil
+
+	case 'p', 'P':
+		var name string
+
+		if p.eof() {
+			p.pos = start
+			return nil, p.errorf("invalid Unicode class escape")
+		}
+
+		if p.peek() == '{' {
+			var ok bool
+			if name, ok = p.scanBraces(); !ok {
+				p.pos = start
+				return nil, p.errorf("missing closing } in Unicode class")
+			}
+		} else {
+			name = string(p.next())
+		}
+
+		negated := c == 'P'
+		if strings.HasPrefix(name, "^") {
+			negated = !negated
+			name = name[1:]
+		}
+
+		set, ok := unicodeClass(name)
+		if !ok {
+			p.pos = start
+			return nil, p.errorf("invalid Unicode class %q", name)
+		}
+
+		if negated {
+			set = set.Negate()
+		}
+
+		return set, nil
+	}
+
+	// Any punctuation character can be escaped to be treated literally.
+	if c < 0x80 && !('0' <= c && c <= '9') && !('A' <= c && c <= 'Z') && !('a' <= c && c <= 'z') {
+		return newCharSet(r(c, c)), nil
+	}
+
+	p.pos = start
+	return nil, p.errorf("invalid escape sequence \\%c", c)
+}
+
+// parseHex parses the hexadecimal digits of \xHH, \x{H...}, or \uHHHH escape sequences.
+func (p *parser) parseHex(c rune) (rune, bool) {
+	var digits string
+
+	switch {
+	case c == 'x' && p.lookahead("{"):
+		var ok bool
+		if digits, ok = p.scanBraces(); !ok || len(digits) == 0 || len(digits) > 6 {
+			return 0, false
+		}
+
+	case c == 'x':
+		if p.pos+2 > len(p.runes) {
+			return 0, false
+		}
+
+		digits = string(p.runes[p.pos : p.pos+2])
+		p.pos += 2
+
+	default: // u
+		if p.pos+4 > len(p.runes) {
+			return 0, false
+		}
+
+		digits = string(p.runes[p.pos : p.pos+4])
+		p.pos += 4
+	}
+
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || v > uint64(anyRange.Hi) {
+		return 0, false
+	}
+
+	return rune(v), true
+}
+
+// scanBraces scans a name enclosed in braces starting at the current position (e.g., {Greek}).
+func (p *parser) scanBraces() (string, bool) {
+	for i := p.pos + 1; i < len(p.runes); i++ {
+		if p.runes[i] == '}' {
+			name := string(p.runes[p.pos+1 : i])
+			p.pos = i + 1
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,246 @@
+package regex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		regex          string
+		expectedString string
+		expectedError  string
+	}{
+		{
+			name:           "Empty",
+			regex:          ``,
+			expectedString: `ε`,
+		},
+		{
+			name:           "Char",
+			regex:          `a`,
+			expectedString: `a`,
+		},
+		{
+			name:           "Concat",
+			regex:          `ab`,
+			expectedString: `(ab)`,
+		},
+		{
+			name:           "Alternation",
+			regex:          `a|b|`,
+			expectedString: `(a|b|ε)`,
+		},
+		{
+			name:           "Group",
+			regex:          `(a|b)*abb`,
+			expectedString: `((a|b)*abb)`,
+		},
+		{
+			name:           "NonCapturingGroup",
+			regex:          `(?:ab)+`,
+			expectedString: `(ab)+`,
+		},
+		{
+			name:           "Optional",
+			regex:          `a?`,
+			expectedString: `a?`,
+		},
+		{
+			name:           "RepeatExactly",
+			regex:          `a{2}`,
+			expectedString: `a{2}`,
+		},
+		{
+			name:           "RepeatAtLeast",
+			regex:          `a{2,}`,
+			expectedString: `a{2,}`,
+		},
+		{
+			name:           "RepeatRange",
+			regex:          `a{2,5}`,
+			expectedString: `a{2,5}`,
+		},
+		{
+			name:           "LiteralBrace",
+			regex:          `a{,2}`,
+			expectedString: `(a\{,2\})`,
+		},
+		{
+			name:           "Class",
+			regex:          `[a-z0-9_]+`,
+			expectedString: `[0-9_a-z]+`,
+		},
+		{
+			name:           "ClassWithLiteralBracketAndHyphen",
+			regex:          `[]a-]`,
+			expectedString: `[\-\]a]`,
+		},
+		{
+			name:           "ClassWithEscapedRange",
+			regex:          `[\t-\r]`,
+			expectedString: `[\x{9}-\x{D}]`,
+		},
+		{
+			name:           "NegatedClass",
+			regex:          `[^\n]`,
+			expectedString: `[\x{0}-\x{9}\x{B}-\x{10FFFF}]`,
+		},
+		{
+			name:           "ASCIIClass",
+			regex:          `[[:^digit:]]`,
+			expectedString: `[\x{0}-/:-\x{10FFFF}]`,
+		},
+		{
+			name:           "AnyChar",
+			regex:          `.`,
+			expectedString: `[\x{0}-\x{9}\x{B}-\x{10FFFF}]`,
+		},
+		{
+			name:           "Escapes",
+			regex:          `\d\.\w`,
+			expectedString: `([0-9]\.[0-9A-Z_a-z])`,
+		},
+		{
+			name:           "HexEscapes",
+			regex:          `\x41é\x{1F600}é`,
+			expectedString: `(Aé😀é)`,
+		},
+		{
+			name:           "UnicodeClass",
+			regex:          `\pZ`,
+			expectedString: `[ \x{A0}\x{1680}\x{2000}-\x{200A}\x{2028}-\x{2029}\x{202F}\x{205F}\x{3000}]`,
+		},
+		{
+			name:           "NegatedUnicodeClass",
+			regex:          `\P{Zl}`,
+			expectedString: `[\x{0}-‧\x{2029}-\x{10FFFF}]`,
+		},
+		{
+			name:           "DoublyNegatedUnicodeClass",
+			regex:          `\P{^Ogham}`,
+			expectedString: `[\x{1680}-᚜]`,
+		},
+		{
+			name:          "MissingClosingParenthesis",
+			regex:         `(ab`,
+			expectedError: `3: missing closing )`,
+		},
+		{
+			name:          "UnexpectedClosingParenthesis",
+			regex:         `ab)`,
+			expectedError: `2: unexpected ')'`,
+		},
+		{
+			name:          "UnsupportedGroupFlags",
+			regex:         `(?i)a`,
+			expectedError: `1: invalid or unsupported group flags`,
+		},
+		{
+			name:          "MissingRepetitionArgument",
+			regex:         `*a`,
+			expectedError: `0: missing argument to repetition operator '*'`,
+		},
+		{
+			name:          "MissingCountArgument",
+			regex:         `{2}`,
+			expectedError: `0: missing argument to repetition operator`,
+		},
+		{
+			name:          "NestedRepetition",
+			regex:         `a+?`,
+			expectedError: `3: invalid nested repetition operator`,
+		},
+		{
+			name:          "InvalidRepetitionRange",
+			regex:         `a{5,2}`,
+			expectedError: `1: invalid repetition range {5,2}`,
+		},
+		{
+			name:          "RepetitionCountTooLarge",
+			regex:         `a{1001}`,
+			expectedError: `1: repetition count exceeds 1000`,
+		},
+		{
+			name:          "Anchor",
+			regex:         `^a`,
+			expectedError: `0: anchor '^' is not supported`,
+		},
+		{
+			name:          "MissingClosingBracket",
+			regex:         `[a-z`,
+			expectedError: `0: missing closing ]`,
+		},
+		{
+			name:          "InvalidClassRange",
+			regex:         `[z-a]`,
+			expectedError: `3: invalid character class range z-a`,
+		},
+		{
+			name:          "InvalidClassRangeBound",
+			regex:         `[a-\d]`,
+			expectedError: `3: invalid character class range`,
+		},
+		{
+			name:          "MissingClosingASCIIClass",
+			regex:         `[[:alpha]`,
+			expectedError: `1: missing closing :]`,
+		},
+		{
+			name:          "InvalidASCIIClass",
+			regex:         `[[:foo:]]`,
+			expectedError: `1: invalid character class [:foo:]`,
+		},
+		{
+			name:          "TrailingBackslash",
+			regex:         `a\`,
+			expectedError: `1: trailing backslash at end of expression`,
+		},
+		{
+			name:          "InvalidEscape",
+			regex:         `\q`,
+			expectedError: `0: invalid escape sequence \q`,
+		},
+		{
+			name:          "InvalidHexEscape",
+			regex:         `\xZZ`,
+			expectedError: `0: invalid hexadecimal escape`,
+		},
+		{
+			name:          "HexEscapeOutOfRange",
+			regex:         `\x{110000}`,
+			expectedError: `0: invalid hexadecimal escape`,
+		},
+		{
+			name:          "MissingUnicodeClassName",
+			regex:         `\p`,
+			expectedError: `0: invalid Unicode class escape`,
+		},
+		{
+			name:          "MissingClosingUnicodeClass",
+			regex:         `\p{Greek`,
+			expectedError: `0: missing closing } in Unicode class`,
+		},
+		{
+			name:          "InvalidUnicodeClass",
+			regex:         `\p{Foo}`,
+			expectedError: `0: invalid Unicode class "Foo"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := parse(tc.regex)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedString, n.String())
+			} else {
+				assert.Nil(t, n)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
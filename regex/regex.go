@@ -0,0 +1,97 @@
+// Package regex implements a compiler for regular expressions.
+//
+// A regular expression is parsed into an abstract syntax tree
+// and then translated into a non-deterministic finite automaton (NFA) using the McNaughton-Yamada-Thompson algorithm.
+// The resulting NFA can be converted to a minimized deterministic finite automaton (DFA) as follows:
+//
+//	nfa, err := regex.Compile(`[A-Za-z_][0-9A-Za-z_]*`)
+//	dfa := nfa.ToDFA().Minimize()
+//
+// The supported syntax is a practical subset of the common regular expression syntaxes:
+//
+//	x          a single character (rune)
+//	.          any character except new line
+//	[xyz]      character class
+//	[^xyz]     negated character class
+//	[a-z]      character range (inside a character class)
+//	[[:word:]] ASCII character class (inside a character class)
+//	\d         digits (≡ [0-9])
+//	\D         not digits (≡ [^0-9])
+//	\s         whitespaces (≡ [\t\n\f\r ])
+//	\S         not whitespaces (≡ [^\t\n\f\r ])
+//	\w         word characters (≡ [0-9A-Za-z_])
+//	\W         not word characters (≡ [^0-9A-Za-z_])
+//	\pN        Unicode class (one-letter name)
+//	\p{Greek}  Unicode class (category or script)
+//	\PN        negated Unicode class (one-letter name)
+//	\P{Greek}  negated Unicode class (category or script)
+//
+//	xy         x followed by y
+//	x|y        x or y
+//	(x)        grouping
+//	(?:x)      grouping
+//
+//	x*         zero or more x
+//	x+         one or more x
+//	x?         zero or one x
+//	x{n}       exactly n x
+//	x{n,}      n or more x
+//	x{n,m}     n to m x
+//
+//	\a \f \t \n \r \v   control characters
+//	\0                  NUL character
+//	\xHH                hexadecimal character code (exactly two digits)
+//	\x{HHHH}            hexadecimal character code (one to six digits)
+//	\uHHHH              hexadecimal character code (exactly four digits)
+//	\*                  literal *, for any punctuation character *
+//
+// Anchors, back-references, and lazy quantifiers are not supported
+// since they cannot be expressed by finite automata.
+//
+// For more information and details, see "Compilers: Principles, Techniques, and Tools (2nd Edition)".
+package regex
+
+import (
+	"fmt"
+
+	"github.com/moorara/algo/automata"
+)
+
+// maxRepeat is the maximum count allowed in a counted repetition x{n,m}.
+const maxRepeat = 1000
+
+// Compile parses a regular expression and constructs an NFA accepting the same language.
+func Compile(regex string) (*automata.NFA, error) {
+	n, err := parse(regex)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCompiler()
+	f := c.compile(n)
+
+	return c.b.SetStart(f.start).SetFinal([]automata.State{f.end}).Build(), nil
+}
+
+// MustCompile is like Compile, but it panics if the regular expression cannot be parsed.
+// It simplifies the initialization of global variables holding compiled regular expressions.
+func MustCompile(regex string) *automata.NFA {
+	nfa, err := Compile(regex)
+	if err != nil {
+		panic(fmt.Sprintf("regex: Compile(%q): %s", regex, err))
+	}
+
+	return nfa
+}
+
+// SyntaxError represents an error encountered when parsing a regular expression.
+type SyntaxError struct {
+	Description string
+	Pos         int // The rune offset in the regular expression (0-based).
+}
+
+// Error implements the error interface.
+// It returns a formatted string describing the error in detail.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Pos, e.Description)
+}
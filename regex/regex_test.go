@@ -0,0 +1,168 @@
+package regex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/automata"
+)
+
+func toString(s string) automata.String {
+	var str automata.String
+	for _, r := range s {
+		str = append(str, automata.Symbol(r))
+	}
+
+	return str
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name          string
+		regex         string
+		accepted      []string
+		rejected      []string
+		expectedError string
+	}{
+		{
+			name:     "Empty",
+			regex:    ``,
+			accepted: []string{""},
+			rejected: []string{"a"},
+		},
+		{
+			name:     "Identifier",
+			regex:    `[A-Za-z_][0-9A-Za-z_]*`,
+			accepted: []string{"a", "_x9", "Id_01"},
+			rejected: []string{"", "9a", "a-b"},
+		},
+		{
+			name:     "Number",
+			regex:    `0|[1-9]\d*(\.\d+)?`,
+			accepted: []string{"0", "1024", "3.14"},
+			rejected: []string{"", "01", "1.", ".5"},
+		},
+		{
+			name:     "Hexadecimal",
+			regex:    `0[xX][[:xdigit:]]{1,8}`,
+			accepted: []string{"0x1", "0XdeadBEEF"},
+			rejected: []string{"0x", "0x123456789", "0xG"},
+		},
+		{
+			name:     "String",
+			regex:    `"([^"\\]|\\.)*"`,
+			accepted: []string{`""`, `"foo"`, `"a\"b"`},
+			rejected: []string{`"`, `"a"b"`},
+		},
+		{
+			name:     "Alternation",
+			regex:    `(ab|cd)+x?|`,
+			accepted: []string{"", "ab", "cdab", "abx"},
+			rejected: []string{"x", "a", "abxx"},
+		},
+		{
+			name:     "Repetition",
+			regex:    `a{2}b{2,}c{0,1}`,
+			accepted: []string{"aabb", "aabbbbc"},
+			rejected: []string{"abb", "aab", "aabbcc"},
+		},
+		{
+			name:     "AnyChar",
+			regex:    `.+`,
+			accepted: []string{"a", "é😀"},
+			rejected: []string{"", "\n"},
+		},
+		{
+			name:     "Unicode",
+			regex:    `\p{Greek}+\PL`,
+			accepted: []string{"αβγ1", "Ω "},
+			rejected: []string{"ab1", "αβγ"},
+		},
+		{
+			name:          "InvalidRegex",
+			regex:         `[a-z`,
+			expectedError: `0: missing closing ]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nfa, err := Compile(tc.regex)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, nfa)
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			nr := nfa.Runner()
+			dr := nfa.ToDFA().Minimize().Runner()
+
+			for _, s := range tc.accepted {
+				assert.True(t, nr.Accept(toString(s)), "NFA must accept %q", s)
+				assert.True(t, dr.Accept(toString(s)), "DFA must accept %q", s)
+			}
+
+			for _, s := range tc.rejected {
+				assert.False(t, nr.Accept(toString(s)), "NFA must reject %q", s)
+				assert.False(t, dr.Accept(toString(s)), "DFA must reject %q", s)
+			}
+		})
+	}
+}
+
+func TestMustCompile(t *testing.T) {
+	tests := []struct {
+		name          string
+		regex         string
+		expectedPanic string
+	}{
+		{
+			name:  "OK",
+			regex: `[0-9]+`,
+		},
+		{
+			name:          "Panic",
+			regex:         `[0-9`,
+			expectedPanic: `regex: Compile("[0-9"): 0: missing closing ]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedPanic == "" {
+				assert.NotNil(t, MustCompile(tc.regex))
+			} else {
+				assert.PanicsWithValue(t, tc.expectedPanic, func() {
+					MustCompile(tc.regex)
+				})
+			}
+		})
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	tests := []struct {
+		name          string
+		e             *SyntaxError
+		expectedError string
+	}{
+		{
+			name: "OK",
+			e: &SyntaxError{
+				Description: "missing closing )",
+				Pos:         4,
+			},
+			expectedError: "4: missing closing )",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, tc.e, tc.expectedError)
+		})
+	}
+}
@@ -1 +1,469 @@
 package spatial
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/moorara/algo/dot"
+	"github.com/moorara/algo/generic"
+)
+
+// Interval represents a closed interval [Lo, Hi].
+type Interval[T any] struct {
+	Lo, Hi T
+}
+
+// String implements the fmt.Stringer interface.
+func (i Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v]", i.Lo, i.Hi)
+}
+
+type intervalTreeNode[T, V any] struct {
+	interval     Interval[T]
+	val          V
+	max          T // The maximum high endpoint of all intervals in the subtree rooted at this node.
+	left, right  *intervalTreeNode[T, V]
+	size, height int
+}
+
+// IntervalTree is an augmented AVL tree of intervals.
+//
+// The intervals are ordered by their low endpoints and then by their high endpoints.
+// Each node keeps track of the maximum high endpoint in its subtree,
+// so the subtrees that cannot contain any overlapping interval are pruned during queries.
+type IntervalTree[T, V any] struct {
+	root *intervalTreeNode[T, V]
+	cmp  generic.CompareFunc[T]
+}
+
+// NewIntervalTree creates a new interval tree.
+//
+// Insert, Get, and Delete run in O(log n) time.
+// Stab and Overlap run in O(log n + k) time, where k is the number of reported intervals.
+func NewIntervalTree[T, V any](cmp generic.CompareFunc[T]) *IntervalTree[T, V] {
+	return &IntervalTree[T, V]{
+		root: nil,
+		cmp:  cmp,
+	}
+}
+
+// nolint: unused
+func (t *IntervalTree[T, V]) verify() bool {
+	return t._isBST(t.root, nil, nil) &&
+		t._isAVL(t.root) &&
+		t._isSizeOK(t.root) &&
+		t._isMaxOK(t.root)
+}
+
+// nolint: unused
+func (t *IntervalTree[T, V]) _isBST(n *intervalTreeNode[T, V], min, max *Interval[T]) bool {
+	if n == nil {
+		return true
+	}
+
+	if (min != nil && t.compare(n.interval, *min) <= 0) ||
+		(max != nil && t.compare(n.interval, *max) >= 0) {
+		return false
+	}
+
+	return t._isBST(n.left, min, &n.interval) && t._isBST(n.right, &n.interval, max)
+}
+
+// nolint: unused
+func (t *IntervalTree[T, V]) _isAVL(n *intervalTreeNode[T, V]) bool {
+	if n == nil {
+		return true
+	}
+
+	bf := t.balanceFactor(n)
+	if bf < -1 || 1 < bf {
+		return false
+	}
+
+	return t._isAVL(n.left) && t._isAVL(n.right)
+}
+
+// nolint: unused
+func (t *IntervalTree[T, V]) _isSizeOK(n *intervalTreeNode[T, V]) bool {
+	if n == nil {
+		return true
+	}
+
+	if n.size != 1+t._size(n.left)+t._size(n.right) {
+		return false
+	}
+
+	return t._isSizeOK(n.left) && t._isSizeOK(n.right)
+}
+
+// nolint: unused
+func (t *IntervalTree[T, V]) _isMaxOK(n *intervalTreeNode[T, V]) bool {
+	if n == nil {
+		return true
+	}
+
+	max := n.interval.Hi
+	if n.left != nil && t.cmp(n.left.max, max) > 0 {
+		max = n.left.max
+	}
+	if n.right != nil && t.cmp(n.right.max, max) > 0 {
+		max = n.right.max
+	}
+
+	if t.cmp(n.max, max) != 0 {
+		return false
+	}
+
+	return t._isMaxOK(n.left) && t._isMaxOK(n.right)
+}
+
+// compare orders two intervals by their low endpoints first and then by their high endpoints.
+func (t *IntervalTree[T, V]) compare(a, b Interval[T]) int {
+	if cmp := t.cmp(a.Lo, b.Lo); cmp != 0 {
+		return cmp
+	}
+
+	return t.cmp(a.Hi, b.Hi)
+}
+
+// overlaps determines whether or not two closed intervals have at least one point in common.
+func (t *IntervalTree[T, V]) overlaps(a, b Interval[T]) bool {
+	return t.cmp(a.Lo, b.Hi) <= 0 && t.cmp(b.Lo, a.Hi) <= 0
+}
+
+func (t *IntervalTree[T, V]) validate(i Interval[T]) {
+	if t.cmp(i.Lo, i.Hi) > 0 {
+		panic(fmt.Sprintf("invalid interval: %s", i))
+	}
+}
+
+// update recomputes the size, height, and max endpoint of a node from its children.
+func (t *IntervalTree[T, V]) update(n *intervalTreeNode[T, V]) {
+	n.size = 1 + t._size(n.left) + t._size(n.right)
+	n.height = 1 + max(t._height(n.left), t._height(n.right))
+
+	n.max = n.interval.Hi
+	if n.left != nil && t.cmp(n.left.max, n.max) > 0 {
+		n.max = n.left.max
+	}
+	if n.right != nil && t.cmp(n.right.max, n.max) > 0 {
+		n.max = n.right.max
+	}
+}
+
+func (t *IntervalTree[T, V]) balance(n *intervalTreeNode[T, V]) *intervalTreeNode[T, V] {
+	if t.balanceFactor(n) == 2 {
+		if t.balanceFactor(n.left) == -1 {
+			n.left = t.rotateLeft(n.left)
+		}
+		n = t.rotateRight(n)
+	} else if t.balanceFactor(n) == -2 {
+		if t.balanceFactor(n.right) == 1 {
+			n.right = t.rotateRight(n.right)
+		}
+		n = t.rotateLeft(n)
+	}
+
+	return n
+}
+
+func (t *IntervalTree[T, V]) balanceFactor(n *intervalTreeNode[T, V]) int {
+	return t._height(n.left) - t._height(n.right)
+}
+
+func (t *IntervalTree[T, V]) rotateLeft(n *intervalTreeNode[T, V]) *intervalTreeNode[T, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+
+	t.update(n)
+	t.update(r)
+
+	return r
+}
+
+func (t *IntervalTree[T, V]) rotateRight(n *intervalTreeNode[T, V]) *intervalTreeNode[T, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+
+	t.update(n)
+	t.update(l)
+
+	return l
+}
+
+// Size returns the number of intervals in the interval tree.
+func (t *IntervalTree[T, V]) Size() int {
+	return t._size(t.root)
+}
+
+func (t *IntervalTree[T, V]) _size(n *intervalTreeNode[T, V]) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// Height returns the height of the interval tree.
+func (t *IntervalTree[T, V]) Height() int {
+	return t._height(t.root)
+}
+
+func (t *IntervalTree[T, V]) _height(n *intervalTreeNode[T, V]) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+// IsEmpty returns true if the interval tree is empty.
+func (t *IntervalTree[T, V]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Insert adds a new interval and its associated value to the interval tree.
+// If the interval already exists, its value is replaced.
+//
+// It panics if the low endpoint of the interval is greater than its high endpoint.
+func (t *IntervalTree[T, V]) Insert(i Interval[T], val V) {
+	t.validate(i)
+	t.root = t._insert(t.root, i, val)
+}
+
+func (t *IntervalTree[T, V]) _insert(n *intervalTreeNode[T, V], i Interval[T], val V) *intervalTreeNode[T, V] {
+	if n == nil {
+		return &intervalTreeNode[T, V]{
+			interval: i,
+			val:      val,
+			max:      i.Hi,
+			size:     1,
+			height:   1,
+		}
+	}
+
+	cmp := t.compare(i, n.interval)
+	switch {
+	case cmp < 0:
+		n.left = t._insert(n.left, i, val)
+	case cmp > 0:
+		n.right = t._insert(n.right, i, val)
+	default:
+		n.val = val
+		return n
+	}
+
+	t.update(n)
+
+	return t.balance(n)
+}
+
+// Get returns the value associated with an interval in the interval tree.
+func (t *IntervalTree[T, V]) Get(i Interval[T]) (V, bool) {
+	for n := t.root; n != nil; {
+		cmp := t.compare(i, n.interval)
+		switch {
+		case cmp < 0:
+			n = n.left
+		case cmp > 0:
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+
+	var zeroV V
+	return zeroV, false
+}
+
+// Delete deletes an interval and its associated value from the interval tree.
+func (t *IntervalTree[T, V]) Delete(i Interval[T]) (val V, ok bool) {
+	t.root, val, ok = t._delete(t.root, i)
+	return val, ok
+}
+
+func (t *IntervalTree[T, V]) _delete(n *intervalTreeNode[T, V], i Interval[T]) (*intervalTreeNode[T, V], V, bool) {
+	if n == nil {
+		var zeroV V
+		return nil, zeroV, false
+	}
+
+	var ok bool
+	var val V
+
+	cmp := t.compare(i, n.interval)
+	if cmp < 0 {
+		n.left, val, ok = t._delete(n.left, i)
+	} else if cmp > 0 {
+		n.right, val, ok = t._delete(n.right, i)
+	} else {
+		ok = true
+		val = n.val
+
+		if n.left == nil {
+			return n.right, val, ok
+		} else if n.right == nil {
+			return n.left, val, ok
+		} else {
+			m := n
+			n = t._min(m.right)
+			n.right = t._deleteMin(m.right)
+			n.left = m.left
+		}
+	}
+
+	t.update(n)
+
+	return t.balance(n), val, ok
+}
+
+func (t *IntervalTree[T, V]) _min(n *intervalTreeNode[T, V]) *intervalTreeNode[T, V] {
+	if n.left == nil {
+		return n
+	}
+
+	return t._min(n.left)
+}
+
+func (t *IntervalTree[T, V]) _deleteMin(n *intervalTreeNode[T, V]) *intervalTreeNode[T, V] {
+	if n.left == nil {
+		return n.right
+	}
+
+	n.left = t._deleteMin(n.left)
+	t.update(n)
+
+	return t.balance(n)
+}
+
+// Stab returns all intervals in the interval tree that contain a given point.
+// The intervals are returned in ascending order.
+func (t *IntervalTree[T, V]) Stab(p T) []generic.KeyValue[Interval[T], V] {
+	return t.Overlap(Interval[T]{Lo: p, Hi: p})
+}
+
+// Overlap returns all intervals in the interval tree that overlap a given interval.
+// The intervals are returned in ascending order.
+//
+// It panics if the low endpoint of the interval is greater than its high endpoint.
+func (t *IntervalTree[T, V]) Overlap(i Interval[T]) []generic.KeyValue[Interval[T], V] {
+	t.validate(i)
+
+	kvs := make([]generic.KeyValue[Interval[T], V], 0)
+	t._overlap(t.root, i, &kvs)
+
+	return kvs
+}
+
+func (t *IntervalTree[T, V]) _overlap(n *intervalTreeNode[T, V], i Interval[T], kvs *[]generic.KeyValue[Interval[T], V]) {
+	// No interval in this subtree ends at or after the low endpoint of the query interval.
+	if n == nil || t.cmp(n.max, i.Lo) < 0 {
+		return
+	}
+
+	t._overlap(n.left, i, kvs)
+
+	if t.overlaps(n.interval, i) {
+		*kvs = append(*kvs, generic.KeyValue[Interval[T], V]{Key: n.interval, Val: n.val})
+	}
+
+	// All intervals in the right subtree start after the high endpoint of the query interval.
+	if t.cmp(n.interval.Lo, i.Hi) <= 0 {
+		t._overlap(n.right, i, kvs)
+	}
+}
+
+// String returns a string representation of the interval tree.
+func (t *IntervalTree[T, V]) String() string {
+	i := 0
+	pairs := make([]string, t.Size())
+
+	t._traverse(t.root, generic.Ascending, func(n *intervalTreeNode[T, V]) bool {
+		pairs[i] = fmt.Sprintf("<%s:%v>", n.interval, n.val)
+		i++
+		return true
+	})
+
+	return fmt.Sprintf("{%s}", strings.Join(pairs, " "))
+}
+
+// All returns an iterator sequence containing all the intervals and their values in the interval tree.
+func (t *IntervalTree[T, V]) All() iter.Seq2[Interval[T], V] {
+	return func(yield func(Interval[T], V) bool) {
+		t._traverse(t.root, generic.Ascending, func(n *intervalTreeNode[T, V]) bool {
+			return yield(n.interval, n.val)
+		})
+	}
+}
+
+// Traverse performs a traversal of the interval tree using the specified traversal order
+// and yields the interval and value of each node to the provided VisitFunc2 function.
+//
+// If the function returns false, the traversal is halted.
+func (t *IntervalTree[T, V]) Traverse(order generic.TraverseOrder, visit generic.VisitFunc2[Interval[T], V]) {
+	t._traverse(t.root, order, func(n *intervalTreeNode[T, V]) bool {
+		return visit(n.interval, n.val)
+	})
+}
+
+func (t *IntervalTree[T, V]) _traverse(n *intervalTreeNode[T, V], order generic.TraverseOrder, visit func(*intervalTreeNode[T, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	switch order {
+	case generic.VLR:
+		return visit(n) && t._traverse(n.left, order, visit) && t._traverse(n.right, order, visit)
+	case generic.VRL:
+		return visit(n) && t._traverse(n.right, order, visit) && t._traverse(n.left, order, visit)
+	case generic.LVR, generic.Ascending:
+		return t._traverse(n.left, order, visit) && visit(n) && t._traverse(n.right, order, visit)
+	case generic.RVL, generic.Descending:
+		return t._traverse(n.right, order, visit) && visit(n) && t._traverse(n.left, order, visit)
+	case generic.LRV:
+		return t._traverse(n.left, order, visit) && t._traverse(n.right, order, visit) && visit(n)
+	case generic.RLV:
+		return t._traverse(n.right, order, visit) && t._traverse(n.left, order, visit) && visit(n)
+	default:
+		return false
+	}
+}
+
+// DOT generates a representation of the interval tree in DOT format.
+// This format is commonly used for visualizing graphs with Graphviz tools.
+func (t *IntervalTree[T, V]) DOT() string {
+	// Create a map of node --> id
+	var id int
+	nodeID := map[*intervalTreeNode[T, V]]int{}
+	t._traverse(t.root, generic.VLR, func(n *intervalTreeNode[T, V]) bool {
+		id++
+		nodeID[n] = id
+		return true
+	})
+
+	graph := dot.NewGraph(true, true, false, "Interval Tree", "", "", "", dot.ShapeOval)
+
+	t._traverse(t.root, generic.VLR, func(n *intervalTreeNode[T, V]) bool {
+		name := fmt.Sprintf("%d", nodeID[n])
+		label := fmt.Sprintf("%s,%v,%v", n.interval, n.val, n.max)
+
+		graph.AddNode(dot.NewNode(name, "", label, "", "", "", "", ""))
+
+		if n.left != nil {
+			left := fmt.Sprintf("%d", nodeID[n.left])
+			graph.AddEdge(dot.NewEdge(name, left, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+		}
+
+		if n.right != nil {
+			right := fmt.Sprintf("%d", nodeID[n.right])
+			graph.AddEdge(dot.NewEdge(name, right, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+		}
+
+		return true
+	})
+
+	return graph.DOT()
+}
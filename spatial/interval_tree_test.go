@@ -1 +1,270 @@
 package spatial
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/generic"
+)
+
+type intervalKV = generic.KeyValue[Interval[int], string]
+
+func iv(lo, hi int) Interval[int] {
+	return Interval[int]{Lo: lo, Hi: hi}
+}
+
+func TestInterval_String(t *testing.T) {
+	tests := []struct {
+		name           string
+		i              Interval[int]
+		expectedString string
+	}{
+		{
+			name:           "OK",
+			i:              iv(2, 8),
+			expectedString: "[2, 8]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.i.String())
+		})
+	}
+}
+
+func TestIntervalTree(t *testing.T) {
+	tests := []struct {
+		name                string
+		intervals           []intervalKV
+		expectedSize        int
+		expectedHeight      int
+		expectedIsEmpty     bool
+		getInterval         Interval[int]
+		expectedGetVal      string
+		expectedGetOK       bool
+		stabPoint           int
+		expectedStab        []intervalKV
+		overlapInterval     Interval[int]
+		expectedOverlap     []intervalKV
+		expectedString      string
+		expectedVLRTraverse []intervalKV
+		expectedAll         []intervalKV
+		expectedDOT         string
+	}{
+		{
+			name:                "Empty",
+			intervals:           []intervalKV{},
+			expectedSize:        0,
+			expectedHeight:      0,
+			expectedIsEmpty:     true,
+			getInterval:         iv(1, 2),
+			expectedGetVal:      "",
+			expectedGetOK:       false,
+			stabPoint:           1,
+			expectedStab:        []intervalKV{},
+			overlapInterval:     iv(1, 2),
+			expectedOverlap:     []intervalKV{},
+			expectedString:      "{}",
+			expectedVLRTraverse: nil,
+			expectedAll:         []intervalKV{},
+			expectedDOT: `strict digraph "Interval Tree" {
+  concentrate=false;
+  node [shape=oval];
+}`,
+		},
+		{
+			name: "OK",
+			intervals: []intervalKV{
+				{Key: iv(15, 20), Val: "a"},
+				{Key: iv(10, 30), Val: "b"},
+				{Key: iv(17, 19), Val: "c"},
+				{Key: iv(5, 20), Val: "d"},
+				{Key: iv(12, 15), Val: "e"},
+				{Key: iv(30, 40), Val: "f"},
+				{Key: iv(17, 19), Val: "g"},
+			},
+			expectedSize:    6,
+			expectedHeight:  3,
+			expectedIsEmpty: false,
+			getInterval:     iv(17, 19),
+			expectedGetVal:  "g",
+			expectedGetOK:   true,
+			stabPoint:       18,
+			expectedStab: []intervalKV{
+				{Key: iv(5, 20), Val: "d"},
+				{Key: iv(10, 30), Val: "b"},
+				{Key: iv(15, 20), Val: "a"},
+				{Key: iv(17, 19), Val: "g"},
+			},
+			overlapInterval: iv(25, 30),
+			expectedOverlap: []intervalKV{
+				{Key: iv(10, 30), Val: "b"},
+				{Key: iv(30, 40), Val: "f"},
+			},
+			expectedString: "{<[5, 20]:d> <[10, 30]:b> <[12, 15]:e> <[15, 20]:a> <[17, 19]:g> <[30, 40]:f>}",
+			expectedVLRTraverse: []intervalKV{
+				{Key: iv(15, 20), Val: "a"},
+				{Key: iv(10, 30), Val: "b"},
+				{Key: iv(5, 20), Val: "d"},
+				{Key: iv(12, 15), Val: "e"},
+				{Key: iv(17, 19), Val: "g"},
+				{Key: iv(30, 40), Val: "f"},
+			},
+			expectedAll: []intervalKV{
+				{Key: iv(5, 20), Val: "d"},
+				{Key: iv(10, 30), Val: "b"},
+				{Key: iv(12, 15), Val: "e"},
+				{Key: iv(15, 20), Val: "a"},
+				{Key: iv(17, 19), Val: "g"},
+				{Key: iv(30, 40), Val: "f"},
+			},
+			expectedDOT: `strict digraph "Interval Tree" {
+  concentrate=false;
+  node [shape=oval];
+
+  1 [label="[15, 20],a,40"];
+  2 [label="[10, 30],b,30"];
+  3 [label="[5, 20],d,20"];
+  4 [label="[12, 15],e,15"];
+  5 [label="[17, 19],g,40"];
+  6 [label="[30, 40],f,40"];
+
+  1 -> 2 [];
+  1 -> 5 [];
+  2 -> 3 [];
+  2 -> 4 [];
+  5 -> 6 [];
+}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewIntervalTree[int, string](generic.NewCompareFunc[int]())
+
+			for _, kv := range tc.intervals {
+				tree.Insert(kv.Key, kv.Val)
+				assert.True(t, tree.verify())
+			}
+
+			assert.Equal(t, tc.expectedSize, tree.Size())
+			assert.Equal(t, tc.expectedHeight, tree.Height())
+			assert.Equal(t, tc.expectedIsEmpty, tree.IsEmpty())
+
+			val, ok := tree.Get(tc.getInterval)
+			assert.Equal(t, tc.expectedGetVal, val)
+			assert.Equal(t, tc.expectedGetOK, ok)
+
+			assert.Equal(t, tc.expectedStab, tree.Stab(tc.stabPoint))
+			assert.Equal(t, tc.expectedOverlap, tree.Overlap(tc.overlapInterval))
+			assert.Equal(t, tc.expectedString, tree.String())
+
+			var vlr []intervalKV
+			tree.Traverse(generic.VLR, func(i Interval[int], v string) bool {
+				vlr = append(vlr, intervalKV{Key: i, Val: v})
+				return true
+			})
+			assert.Equal(t, tc.expectedVLRTraverse, vlr)
+
+			assert.Equal(t, tc.expectedAll, generic.Collect2(tree.All()))
+			assert.Equal(t, tc.expectedDOT, tree.DOT())
+		})
+	}
+}
+
+func TestIntervalTree_Delete(t *testing.T) {
+	tests := []struct {
+		name              string
+		intervals         []Interval[int]
+		delete            []Interval[int]
+		expectedOK        []bool
+		expectedIntervals []Interval[int]
+	}{
+		{
+			name:              "Empty",
+			intervals:         []Interval[int]{},
+			delete:            []Interval[int]{iv(1, 2)},
+			expectedOK:        []bool{false},
+			expectedIntervals: nil,
+		},
+		{
+			name:              "OK",
+			intervals:         []Interval[int]{iv(15, 20), iv(10, 30), iv(17, 19), iv(5, 20), iv(12, 15), iv(30, 40), iv(1, 3)},
+			delete:            []Interval[int]{iv(15, 20), iv(17, 20), iv(5, 20), iv(30, 40), iv(10, 30)},
+			expectedOK:        []bool{true, false, true, true, true},
+			expectedIntervals: []Interval[int]{iv(1, 3), iv(12, 15), iv(17, 19)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewIntervalTree[int, int](generic.NewCompareFunc[int]())
+
+			for i, interval := range tc.intervals {
+				tree.Insert(interval, i)
+			}
+
+			for i, interval := range tc.delete {
+				_, ok := tree.Delete(interval)
+				assert.Equal(t, tc.expectedOK[i], ok)
+				assert.True(t, tree.verify())
+			}
+
+			var intervals []Interval[int]
+			for i := range tree.All() {
+				intervals = append(intervals, i)
+			}
+
+			assert.Equal(t, tc.expectedIntervals, intervals)
+		})
+	}
+}
+
+func TestIntervalTree_Overlap(t *testing.T) {
+	cmp := generic.NewCompareFunc[int]()
+	eq := generic.NewEqualFunc[Interval[int]]()
+
+	// Compare the interval tree against a brute-force search.
+	intervals := []Interval[int]{}
+	tree := NewIntervalTree[int, int](cmp)
+	for i := range 200 {
+		lo := (i * 37) % 101
+		hi := lo + (i*13)%17
+		intervals = append(intervals, iv(lo, hi))
+		tree.Insert(iv(lo, hi), i)
+	}
+
+	assert.True(t, tree.verify())
+
+	for lo := -5; lo <= 120; lo += 3 {
+		for _, hi := range []int{lo, lo + 4, lo + 20} {
+			var expected []Interval[int]
+			for _, i := range intervals {
+				if i.Lo <= hi && lo <= i.Hi && !generic.Contains(expected, eq, i) {
+					expected = append(expected, i)
+				}
+			}
+
+			var got []Interval[int]
+			for _, kv := range tree.Overlap(iv(lo, hi)) {
+				got = append(got, kv.Key)
+			}
+
+			assert.ElementsMatch(t, expected, got, "Overlap(%s)", iv(lo, hi))
+		}
+	}
+}
+
+func TestIntervalTree_Panic(t *testing.T) {
+	tree := NewIntervalTree[int, string](generic.NewCompareFunc[int]())
+
+	assert.PanicsWithValue(t, "invalid interval: [5, 2]", func() {
+		tree.Insert(iv(5, 2), "x")
+	})
+
+	assert.PanicsWithValue(t, "invalid interval: [5, 2]", func() {
+		tree.Overlap(iv(5, 2))
+	})
+}
@@ -0,0 +1,537 @@
+package spatial
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/moorara/algo/dot"
+	"github.com/moorara/algo/generic"
+	"github.com/moorara/algo/heap"
+)
+
+type kdTreeNode[T any] struct {
+	point       PointND[T]
+	axis        int // The splitting dimension of this node.
+	left, right *kdTreeNode[T]
+}
+
+// KDTree is a k-dimensional tree for organizing points in k-dimensional space.
+//
+// Each node splits the space into two half-spaces along one of the dimensions (axis),
+// cycling through the dimensions as the tree gets deeper.
+// All points in the left subtree of a node have a smaller coordinate along the node's axis,
+// and all points in the right subtree have a greater than or equal coordinate.
+//
+// The distance function used for nearest-neighbour and radius searches must be monotonic in
+// the absolute difference of each coordinate; this holds for all Minkowski distances
+// such as Euclidean, Manhattan, and Chebyshev.
+type KDTree[T any] struct {
+	dim  int
+	size int
+	root *kdTreeNode[T]
+	cmp  generic.CompareFunc[T]
+	dist DistanceFunc[T]
+}
+
+// NewKDTree creates a new k-d tree for a set of points in d-dimensional space.
+// The initial points are inserted in bulk by recursively splitting them at the median,
+// which results in a balanced tree.
+//
+// It panics if d is not positive or if any of the points does not have exactly d coordinates.
+func NewKDTree[T any](d int, cmp generic.CompareFunc[T], dist DistanceFunc[T], points ...PointND[T]) *KDTree[T] {
+	if d <= 0 {
+		panic(fmt.Sprintf("invalid dimension: %d is not positive", d))
+	}
+
+	t := &KDTree[T]{
+		dim:  d,
+		cmp:  cmp,
+		dist: dist,
+	}
+
+	for _, p := range points {
+		t.validate(p)
+	}
+
+	t.size = len(points)
+	t.root = t.build(slices.Clone(points), 0)
+
+	return t
+}
+
+// NewKDTree2D creates a new k-d tree for a set of points in two-dimensional space.
+func NewKDTree2D[T any](cmp generic.CompareFunc[T], dist DistanceFunc[T], points ...Point2D[T]) *KDTree[T] {
+	nd := make([]PointND[T], len(points))
+	for i, p := range points {
+		nd[i] = p.ND()
+	}
+
+	return NewKDTree(2, cmp, dist, nd...)
+}
+
+// NewKDTree3D creates a new k-d tree for a set of points in three-dimensional space.
+func NewKDTree3D[T any](cmp generic.CompareFunc[T], dist DistanceFunc[T], points ...Point3D[T]) *KDTree[T] {
+	nd := make([]PointND[T], len(points))
+	for i, p := range points {
+		nd[i] = p.ND()
+	}
+
+	return NewKDTree(3, cmp, dist, nd...)
+}
+
+// nolint: unused
+func (t *KDTree[T]) verify() bool {
+	return t._isKD(t.root, 0)
+}
+
+// nolint: unused
+func (t *KDTree[T]) _isKD(n *kdTreeNode[T], depth int) bool {
+	if n == nil {
+		return true
+	}
+
+	if n.axis != depth%t.dim {
+		return false
+	}
+
+	ok := true
+
+	t._traverse(n.left, generic.VLR, func(m *kdTreeNode[T]) bool {
+		ok = t.cmp(m.point.Coordinates[n.axis], n.point.Coordinates[n.axis]) < 0
+		return ok
+	})
+
+	t._traverse(n.right, generic.VLR, func(m *kdTreeNode[T]) bool {
+		ok = ok && t.cmp(m.point.Coordinates[n.axis], n.point.Coordinates[n.axis]) >= 0
+		return ok
+	})
+
+	return ok && t._isKD(n.left, depth+1) && t._isKD(n.right, depth+1)
+}
+
+func (t *KDTree[T]) validate(p PointND[T]) {
+	if len(p.Coordinates) != t.dim {
+		panic(fmt.Sprintf("invalid point: %s is not %d-dimensional", p, t.dim))
+	}
+}
+
+func (t *KDTree[T]) equal(p, q PointND[T]) bool {
+	for i := range t.dim {
+		if t.cmp(p.Coordinates[i], q.Coordinates[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// build constructs a balanced k-d tree by splitting the points at the median along the axis of each depth.
+func (t *KDTree[T]) build(points []PointND[T], depth int) *kdTreeNode[T] {
+	if len(points) == 0 {
+		return nil
+	}
+
+	axis := depth % t.dim
+	slices.SortFunc(points, func(a, b PointND[T]) int {
+		return t.cmp(a.Coordinates[axis], b.Coordinates[axis])
+	})
+
+	// Points with the same coordinate as the median must go to the right subtree.
+	mid := len(points) / 2
+	for mid > 0 && t.cmp(points[mid-1].Coordinates[axis], points[mid].Coordinates[axis]) == 0 {
+		mid--
+	}
+
+	return &kdTreeNode[T]{
+		point: points[mid],
+		axis:  axis,
+		left:  t.build(points[:mid], depth+1),
+		right: t.build(points[mid+1:], depth+1),
+	}
+}
+
+// Dimension returns the number of dimensions of the points in the k-d tree.
+func (t *KDTree[T]) Dimension() int {
+	return t.dim
+}
+
+// Size returns the number of points in the k-d tree.
+func (t *KDTree[T]) Size() int {
+	return t.size
+}
+
+// Height returns the height of the k-d tree.
+func (t *KDTree[T]) Height() int {
+	return t._height(t.root)
+}
+
+func (t *KDTree[T]) _height(n *kdTreeNode[T]) int {
+	if n == nil {
+		return 0
+	}
+
+	return 1 + max(t._height(n.left), t._height(n.right))
+}
+
+// IsEmpty returns true if the k-d tree is empty.
+func (t *KDTree[T]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Insert adds a new point to the k-d tree.
+//
+// It panics if the point does not have the same dimension as the k-d tree.
+func (t *KDTree[T]) Insert(p PointND[T]) {
+	t.validate(p)
+	t.root = t._insert(t.root, p, 0)
+	t.size++
+}
+
+func (t *KDTree[T]) _insert(n *kdTreeNode[T], p PointND[T], depth int) *kdTreeNode[T] {
+	if n == nil {
+		return &kdTreeNode[T]{
+			point: p,
+			axis:  depth % t.dim,
+		}
+	}
+
+	if t.cmp(p.Coordinates[n.axis], n.point.Coordinates[n.axis]) < 0 {
+		n.left = t._insert(n.left, p, depth+1)
+	} else {
+		n.right = t._insert(n.right, p, depth+1)
+	}
+
+	return n
+}
+
+// Contains determines whether or not a point exists in the k-d tree.
+//
+// It panics if the point does not have the same dimension as the k-d tree.
+func (t *KDTree[T]) Contains(p PointND[T]) bool {
+	t.validate(p)
+
+	for n := t.root; n != nil; {
+		if t.equal(p, n.point) {
+			return true
+		}
+
+		if t.cmp(p.Coordinates[n.axis], n.point.Coordinates[n.axis]) < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return false
+}
+
+// Delete deletes a point from the k-d tree.
+// If the point exists more than once, only one occurrence of it is deleted.
+//
+// It panics if the point does not have the same dimension as the k-d tree.
+func (t *KDTree[T]) Delete(p PointND[T]) (ok bool) {
+	t.validate(p)
+	if t.root, ok = t._delete(t.root, p); ok {
+		t.size--
+	}
+
+	return ok
+}
+
+func (t *KDTree[T]) _delete(n *kdTreeNode[T], p PointND[T]) (*kdTreeNode[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var ok bool
+
+	if !t.equal(p, n.point) {
+		if t.cmp(p.Coordinates[n.axis], n.point.Coordinates[n.axis]) < 0 {
+			n.left, ok = t._delete(n.left, p)
+		} else {
+			n.right, ok = t._delete(n.right, p)
+		}
+
+		return n, ok
+	}
+
+	switch {
+	case n.right != nil:
+		// Replace the point with the minimum point along the same axis in the right subtree.
+		m := t._min(n.right, n.axis)
+		n.point = m.point
+		n.right, _ = t._delete(n.right, m.point)
+	case n.left != nil:
+		// Replace the point with the minimum point along the same axis in the left subtree,
+		// and move the left subtree to the right, so the points equal to the minimum stay on the right side.
+		m := t._min(n.left, n.axis)
+		n.point = m.point
+		n.right, _ = t._delete(n.left, m.point)
+		n.left = nil
+	default:
+		return nil, true
+	}
+
+	return n, true
+}
+
+// _min returns the node with the minimum coordinate along an axis in a subtree.
+func (t *KDTree[T]) _min(n *kdTreeNode[T], axis int) *kdTreeNode[T] {
+	if n == nil {
+		return nil
+	}
+
+	// The minimum can only be in the left subtree if the node splits along the same axis.
+	if n.axis == axis {
+		if n.left == nil {
+			return n
+		}
+		return t._min(n.left, axis)
+	}
+
+	m := n
+	for _, c := range []*kdTreeNode[T]{t._min(n.left, axis), t._min(n.right, axis)} {
+		if c != nil && t.cmp(c.point.Coordinates[axis], m.point.Coordinates[axis]) < 0 {
+			m = c
+		}
+	}
+
+	return m
+}
+
+// Nearest returns the k nearest points in the k-d tree to a given point.
+// The points are returned in ascending order of their distances to the given point.
+//
+// It panics if the point does not have the same dimension as the k-d tree.
+func (t *KDTree[T]) Nearest(q PointND[T], k int) []PointND[T] {
+	t.validate(q)
+
+	if k <= 0 {
+		return []PointND[T]{}
+	}
+
+	// A max-heap holding the k nearest points found so far.
+	h := heap.NewBinary[float64, PointND[T]](k, generic.NewReverseCompareFunc[float64](), nil)
+
+	closest := PointND[T]{Coordinates: slices.Clone(q.Coordinates)}
+	t._nearest(t.root, q, k, closest, h)
+
+	points := make([]PointND[T], h.Size())
+	for i := len(points) - 1; i >= 0; i-- {
+		_, points[i], _ = h.Delete()
+	}
+
+	return points
+}
+
+// _nearest searches a subtree for the k nearest points to q.
+// closest is the closest point of the region covered by the subtree to q.
+func (t *KDTree[T]) _nearest(n *kdTreeNode[T], q PointND[T], k int, closest PointND[T], h heap.Heap[float64, PointND[T]]) {
+	if n == nil {
+		return
+	}
+
+	// Prune the subtree if its region is farther than the k-th nearest point found so far.
+	if worst, _, ok := h.Peek(); ok && h.Size() == k && t.dist(q, closest) > worst {
+		return
+	}
+
+	if d := t.dist(q, n.point); h.Size() < k {
+		h.Insert(d, n.point)
+	} else if worst, _, _ := h.Peek(); d < worst {
+		h.Delete()
+		h.Insert(d, n.point)
+	}
+
+	near, far := n.left, n.right
+	if t.cmp(q.Coordinates[n.axis], n.point.Coordinates[n.axis]) >= 0 {
+		near, far = n.right, n.left
+	}
+
+	t._nearest(near, q, k, closest, h)
+
+	// The closest point of the far region lies on the splitting hyperplane.
+	prev := closest.Coordinates[n.axis]
+	closest.Coordinates[n.axis] = n.point.Coordinates[n.axis]
+	t._nearest(far, q, k, closest, h)
+	closest.Coordinates[n.axis] = prev
+}
+
+// Radius returns all points in the k-d tree whose distances to a given point are less than or equal to r.
+// The points are returned in ascending order of their distances to the given point.
+//
+// It panics if the point does not have the same dimension as the k-d tree.
+func (t *KDTree[T]) Radius(q PointND[T], r float64) []PointND[T] {
+	t.validate(q)
+
+	type result struct {
+		point PointND[T]
+		dist  float64
+	}
+
+	results := make([]result, 0)
+	cmp := generic.NewCompareFunc[float64]()
+
+	closest := PointND[T]{Coordinates: slices.Clone(q.Coordinates)}
+	t._traverseRegion(t.root, q, closest, func(closest PointND[T]) bool {
+		return t.dist(q, closest) <= r
+	}, func(n *kdTreeNode[T]) {
+		if d := t.dist(q, n.point); d <= r {
+			results = append(results, result{n.point, d})
+		}
+	})
+
+	slices.SortStableFunc(results, func(a, b result) int {
+		return cmp(a.dist, b.dist)
+	})
+
+	points := make([]PointND[T], len(results))
+	for i, res := range results {
+		points[i] = res.point
+	}
+
+	return points
+}
+
+// _traverseRegion visits the nodes of a subtree whose regions may contain points of interest.
+// The visit function is called for every node whose region is accepted by the accept function,
+// which receives the closest point of the region to q.
+func (t *KDTree[T]) _traverseRegion(n *kdTreeNode[T], q, closest PointND[T], accept func(PointND[T]) bool, visit func(*kdTreeNode[T])) {
+	if n == nil || !accept(closest) {
+		return
+	}
+
+	visit(n)
+
+	near, far := n.left, n.right
+	if t.cmp(q.Coordinates[n.axis], n.point.Coordinates[n.axis]) >= 0 {
+		near, far = n.right, n.left
+	}
+
+	t._traverseRegion(near, q, closest, accept, visit)
+
+	prev := closest.Coordinates[n.axis]
+	closest.Coordinates[n.axis] = n.point.Coordinates[n.axis]
+	t._traverseRegion(far, q, closest, accept, visit)
+	closest.Coordinates[n.axis] = prev
+}
+
+// Range returns all points in the k-d tree inside the axis-aligned box defined by two corner points.
+// The box is closed, i.e., the points on its boundary are included.
+// The order of the returned points is unspecified.
+//
+// It panics if any of the corner points does not have the same dimension as the k-d tree.
+func (t *KDTree[T]) Range(lo, hi PointND[T]) []PointND[T] {
+	t.validate(lo)
+	t.validate(hi)
+
+	points := make([]PointND[T], 0)
+	t._range(t.root, lo, hi, &points)
+
+	return points
+}
+
+func (t *KDTree[T]) _range(n *kdTreeNode[T], lo, hi PointND[T], points *[]PointND[T]) {
+	if n == nil {
+		return
+	}
+
+	inside := true
+	for i := range t.dim {
+		if t.cmp(n.point.Coordinates[i], lo.Coordinates[i]) < 0 || t.cmp(hi.Coordinates[i], n.point.Coordinates[i]) < 0 {
+			inside = false
+			break
+		}
+	}
+
+	if inside {
+		*points = append(*points, n.point)
+	}
+
+	// The left subtree has smaller coordinates and the right subtree has greater or equal coordinates along the axis.
+	if t.cmp(lo.Coordinates[n.axis], n.point.Coordinates[n.axis]) < 0 {
+		t._range(n.left, lo, hi, points)
+	}
+	if t.cmp(hi.Coordinates[n.axis], n.point.Coordinates[n.axis]) >= 0 {
+		t._range(n.right, lo, hi, points)
+	}
+}
+
+// All returns an iterator sequence containing all the points in the k-d tree.
+func (t *KDTree[T]) All() iter.Seq[PointND[T]] {
+	return func(yield func(PointND[T]) bool) {
+		t._traverse(t.root, generic.LVR, func(n *kdTreeNode[T]) bool {
+			return yield(n.point)
+		})
+	}
+}
+
+// Traverse performs a traversal of the k-d tree using the specified traversal order
+// and yields the point of each node to the provided VisitFunc1 function.
+//
+// If the function returns false, the traversal is halted.
+func (t *KDTree[T]) Traverse(order generic.TraverseOrder, visit generic.VisitFunc1[PointND[T]]) {
+	t._traverse(t.root, order, func(n *kdTreeNode[T]) bool {
+		return visit(n.point)
+	})
+}
+
+func (t *KDTree[T]) _traverse(n *kdTreeNode[T], order generic.TraverseOrder, visit func(*kdTreeNode[T]) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	switch order {
+	case generic.VLR:
+		return visit(n) && t._traverse(n.left, order, visit) && t._traverse(n.right, order, visit)
+	case generic.VRL:
+		return visit(n) && t._traverse(n.right, order, visit) && t._traverse(n.left, order, visit)
+	case generic.LVR, generic.Ascending:
+		return t._traverse(n.left, order, visit) && visit(n) && t._traverse(n.right, order, visit)
+	case generic.RVL, generic.Descending:
+		return t._traverse(n.right, order, visit) && visit(n) && t._traverse(n.left, order, visit)
+	case generic.LRV:
+		return t._traverse(n.left, order, visit) && t._traverse(n.right, order, visit) && visit(n)
+	case generic.RLV:
+		return t._traverse(n.right, order, visit) && t._traverse(n.left, order, visit) && visit(n)
+	default:
+		return false
+	}
+}
+
+// DOT generates a representation of the k-d tree in DOT format.
+// This format is commonly used for visualizing graphs with Graphviz tools.
+func (t *KDTree[T]) DOT() string {
+	// Create a map of node --> id
+	var id int
+	nodeID := map[*kdTreeNode[T]]int{}
+	t._traverse(t.root, generic.VLR, func(n *kdTreeNode[T]) bool {
+		id++
+		nodeID[n] = id
+		return true
+	})
+
+	graph := dot.NewGraph(true, true, false, "KD Tree", "", "", "", dot.ShapeOval)
+
+	t._traverse(t.root, generic.VLR, func(n *kdTreeNode[T]) bool {
+		name := fmt.Sprintf("%d", nodeID[n])
+		label := fmt.Sprintf("%s,%d", n.point, n.axis)
+
+		graph.AddNode(dot.NewNode(name, "", label, "", "", "", "", ""))
+
+		if n.left != nil {
+			left := fmt.Sprintf("%d", nodeID[n.left])
+			graph.AddEdge(dot.NewEdge(name, left, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+		}
+
+		if n.right != nil {
+			right := fmt.Sprintf("%d", nodeID[n.right])
+			graph.AddEdge(dot.NewEdge(name, right, dot.EdgeTypeDirected, "", "", "", "", "", ""))
+		}
+
+		return true
+	})
+
+	return graph.DOT()
+}
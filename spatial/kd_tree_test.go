@@ -0,0 +1,351 @@
+package spatial
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/generic"
+)
+
+func TestNewKDTree2D(t *testing.T) {
+	tree := NewKDTree2D(generic.NewCompareFunc[int](), Euclidean[int],
+		Point2D[int]{X: 1, Y: 2},
+		Point2D[int]{X: 3, Y: 4},
+	)
+
+	assert.Equal(t, 2, tree.Dimension())
+	assert.Equal(t, 2, tree.Size())
+	assert.True(t, tree.Contains(pnd(3, 4)))
+}
+
+func TestNewKDTree3D(t *testing.T) {
+	tree := NewKDTree3D(generic.NewCompareFunc[int](), Euclidean[int],
+		Point3D[int]{X: 1, Y: 2, Z: 3},
+	)
+
+	assert.Equal(t, 3, tree.Dimension())
+	assert.Equal(t, 1, tree.Size())
+	assert.True(t, tree.Contains(pnd(1, 2, 3)))
+}
+
+func TestKDTree(t *testing.T) {
+	type nearest struct {
+		q              PointND[int]
+		k              int
+		expectedPoints []PointND[int]
+	}
+
+	type radius struct {
+		q              PointND[int]
+		r              float64
+		expectedPoints []PointND[int]
+	}
+
+	type box struct {
+		lo, hi         PointND[int]
+		expectedPoints []PointND[int]
+	}
+
+	tests := []struct {
+		name             string
+		points           []PointND[int]
+		dist             DistanceFunc[int]
+		insert           []PointND[int]
+		expectedSize     int
+		expectedHeight   int
+		expectedIsEmpty  bool
+		expectedContains []PointND[int]
+		expectedMissing  []PointND[int]
+		nearest          []nearest
+		radius           []radius
+		box              []box
+		expectedVLR      []PointND[int]
+		expectedDOT      string
+	}{
+		{
+			name:             "Empty",
+			points:           []PointND[int]{},
+			dist:             Euclidean[int],
+			expectedSize:     0,
+			expectedHeight:   0,
+			expectedIsEmpty:  true,
+			expectedContains: nil,
+			expectedMissing:  []PointND[int]{pnd(0, 0)},
+			nearest: []nearest{
+				{q: pnd(0, 0), k: 1, expectedPoints: []PointND[int]{}},
+			},
+			radius: []radius{
+				{q: pnd(0, 0), r: 10, expectedPoints: []PointND[int]{}},
+			},
+			box: []box{
+				{lo: pnd(0, 0), hi: pnd(10, 10), expectedPoints: []PointND[int]{}},
+			},
+			expectedVLR: nil,
+			expectedDOT: `strict digraph "KD Tree" {
+  concentrate=false;
+  node [shape=oval];
+}`,
+		},
+		{
+			name:             "Euclidean",
+			points:           []PointND[int]{pnd(2, 3), pnd(5, 4), pnd(9, 6), pnd(4, 7), pnd(8, 1), pnd(7, 2)},
+			dist:             Euclidean[int],
+			insert:           []PointND[int]{pnd(7, 5)},
+			expectedSize:     7,
+			expectedHeight:   4,
+			expectedIsEmpty:  false,
+			expectedContains: []PointND[int]{pnd(2, 3), pnd(7, 2), pnd(7, 5)},
+			expectedMissing:  []PointND[int]{pnd(3, 2), pnd(7, 1)},
+			nearest: []nearest{
+				{q: pnd(9, 2), k: 1, expectedPoints: []PointND[int]{pnd(8, 1)}},
+				{q: pnd(6, 4), k: 3, expectedPoints: []PointND[int]{pnd(5, 4), pnd(7, 5), pnd(7, 2)}},
+				{q: pnd(0, 0), k: 0, expectedPoints: []PointND[int]{}},
+				{q: pnd(0, 0), k: 10, expectedPoints: []PointND[int]{pnd(2, 3), pnd(5, 4), pnd(7, 2), pnd(8, 1), pnd(4, 7), pnd(7, 5), pnd(9, 6)}},
+			},
+			radius: []radius{
+				{q: pnd(6, 4), r: 2, expectedPoints: []PointND[int]{pnd(5, 4), pnd(7, 5)}},
+				{q: pnd(0, 9), r: 1, expectedPoints: []PointND[int]{}},
+			},
+			box: []box{
+				{lo: pnd(4, 2), hi: pnd(8, 5), expectedPoints: []PointND[int]{pnd(5, 4), pnd(7, 2), pnd(7, 5)}},
+				{lo: pnd(7, 0), hi: pnd(7, 9), expectedPoints: []PointND[int]{pnd(7, 2), pnd(7, 5)}},
+			},
+			expectedVLR: []PointND[int]{pnd(7, 2), pnd(5, 4), pnd(2, 3), pnd(4, 7), pnd(9, 6), pnd(8, 1), pnd(7, 5)},
+			expectedDOT: `strict digraph "KD Tree" {
+  concentrate=false;
+  node [shape=oval];
+
+  1 [label="(7, 2),0"];
+  2 [label="(5, 4),1"];
+  3 [label="(2, 3),0"];
+  4 [label="(4, 7),0"];
+  5 [label="(9, 6),1"];
+  6 [label="(8, 1),0"];
+  7 [label="(7, 5),1"];
+
+  1 -> 2 [];
+  1 -> 5 [];
+  2 -> 3 [];
+  2 -> 4 [];
+  5 -> 6 [];
+  6 -> 7 [];
+}`,
+		},
+		{
+			name:             "Manhattan",
+			points:           []PointND[int]{pnd(0, 0), pnd(3, 0), pnd(2, 2), pnd(0, 4)},
+			dist:             Manhattan[int],
+			expectedSize:     4,
+			expectedHeight:   3,
+			expectedIsEmpty:  false,
+			expectedContains: []PointND[int]{pnd(0, 4)},
+			expectedMissing:  []PointND[int]{pnd(4, 0)},
+			nearest: []nearest{
+				{q: pnd(1, 1), k: 2, expectedPoints: []PointND[int]{pnd(0, 0), pnd(2, 2)}},
+			},
+			radius: []radius{
+				{q: pnd(2, 0), r: 2, expectedPoints: []PointND[int]{pnd(3, 0), pnd(2, 2), pnd(0, 0)}},
+			},
+			box: []box{
+				{lo: pnd(0, 1), hi: pnd(3, 4), expectedPoints: []PointND[int]{pnd(2, 2), pnd(0, 4)}},
+			},
+			expectedVLR: []PointND[int]{pnd(2, 2), pnd(0, 4), pnd(0, 0), pnd(3, 0)},
+			expectedDOT: `strict digraph "KD Tree" {
+  concentrate=false;
+  node [shape=oval];
+
+  1 [label="(2, 2),0"];
+  2 [label="(0, 4),1"];
+  3 [label="(0, 0),0"];
+  4 [label="(3, 0),1"];
+
+  1 -> 2 [];
+  1 -> 4 [];
+  2 -> 3 [];
+}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewKDTree(2, generic.NewCompareFunc[int](), tc.dist, tc.points...)
+			assert.True(t, tree.verify())
+
+			for _, p := range tc.insert {
+				tree.Insert(p)
+				assert.True(t, tree.verify())
+			}
+
+			assert.Equal(t, tc.expectedSize, tree.Size())
+			assert.Equal(t, tc.expectedHeight, tree.Height())
+			assert.Equal(t, tc.expectedIsEmpty, tree.IsEmpty())
+
+			for _, p := range tc.expectedContains {
+				assert.True(t, tree.Contains(p), "Contains(%s)", p)
+			}
+
+			for _, p := range tc.expectedMissing {
+				assert.False(t, tree.Contains(p), "Contains(%s)", p)
+			}
+
+			for _, n := range tc.nearest {
+				assert.Equal(t, n.expectedPoints, tree.Nearest(n.q, n.k), "Nearest(%s, %d)", n.q, n.k)
+			}
+
+			for _, r := range tc.radius {
+				assert.Equal(t, r.expectedPoints, tree.Radius(r.q, r.r), "Radius(%s, %f)", r.q, r.r)
+			}
+
+			for _, b := range tc.box {
+				assert.ElementsMatch(t, b.expectedPoints, tree.Range(b.lo, b.hi), "Range(%s, %s)", b.lo, b.hi)
+			}
+
+			var vlr []PointND[int]
+			tree.Traverse(generic.VLR, func(p PointND[int]) bool {
+				vlr = append(vlr, p)
+				return true
+			})
+
+			assert.Equal(t, tc.expectedVLR, vlr)
+			assert.Equal(t, tc.expectedDOT, tree.DOT())
+		})
+	}
+}
+
+func TestKDTree_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		points         []PointND[int]
+		delete         []PointND[int]
+		expectedOK     []bool
+		expectedPoints []PointND[int]
+	}{
+		{
+			name:           "Empty",
+			points:         []PointND[int]{},
+			delete:         []PointND[int]{pnd(1, 1)},
+			expectedOK:     []bool{false},
+			expectedPoints: []PointND[int]{},
+		},
+		{
+			name:           "Duplicates",
+			points:         []PointND[int]{pnd(3, 3), pnd(3, 3), pnd(3, 1), pnd(1, 3)},
+			delete:         []PointND[int]{pnd(3, 3), pnd(3, 3), pnd(3, 3)},
+			expectedOK:     []bool{true, true, false},
+			expectedPoints: []PointND[int]{pnd(1, 3), pnd(3, 1)},
+		},
+		{
+			name:           "OK",
+			points:         []PointND[int]{pnd(2, 3), pnd(5, 4), pnd(9, 6), pnd(4, 7), pnd(8, 1), pnd(7, 2), pnd(7, 5)},
+			delete:         []PointND[int]{pnd(7, 2), pnd(5, 4), pnd(1, 1), pnd(9, 6), pnd(2, 3)},
+			expectedOK:     []bool{true, true, false, true, true},
+			expectedPoints: []PointND[int]{pnd(4, 7), pnd(7, 5), pnd(8, 1)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewKDTree(2, generic.NewCompareFunc[int](), Euclidean[int], tc.points...)
+
+			for i, p := range tc.delete {
+				assert.Equal(t, tc.expectedOK[i], tree.Delete(p), "Delete(%s)", p)
+				assert.True(t, tree.verify())
+			}
+
+			assert.Equal(t, len(tc.expectedPoints), tree.Size())
+			assert.ElementsMatch(t, tc.expectedPoints, generic.Collect1(tree.All()))
+		})
+	}
+}
+
+func TestKDTree_BruteForce(t *testing.T) {
+	points := make([]PointND[int], 0)
+	tree := NewKDTree(3, generic.NewCompareFunc[int](), Euclidean[int])
+
+	for i := range 300 {
+		tree.Insert(pnd((i*37)%53, (i*13)%31, (i*7)%17))
+	}
+
+	// Delete some points to exercise the tree after restructuring.
+	for i := range 300 {
+		if p := pnd((i*37)%53, (i*13)%31, (i*7)%17); i%7 == 0 {
+			assert.True(t, tree.Delete(p))
+		} else {
+			points = append(points, p)
+		}
+	}
+
+	assert.True(t, tree.verify())
+
+	for i := range 40 {
+		q := pnd((i*11)%60-3, (i*5)%35-2, (i*3)%20-1)
+
+		dists := make([]float64, len(points))
+		for j, p := range points {
+			dists[j] = Euclidean(q, p)
+		}
+		slices.Sort(dists)
+
+		// k-NN: compare the distances since points at equal distances can be returned in any order.
+		k := 1 + i%8
+		nearest := tree.Nearest(q, k)
+		if assert.Len(t, nearest, k) {
+			for j, p := range nearest {
+				assert.Equal(t, dists[j], Euclidean(q, p), "Nearest(%s, %d)", q, k)
+			}
+		}
+
+		// Radius search
+		r := float64(i%10) + 0.5
+		expected := 0
+		for _, d := range dists {
+			if d <= r {
+				expected++
+			}
+		}
+
+		radius := tree.Radius(q, r)
+		assert.Len(t, radius, expected, "Radius(%s, %f)", q, r)
+		assert.True(t, slices.IsSortedFunc(radius, func(a, b PointND[int]) int {
+			return generic.NewCompareFunc[float64]()(Euclidean(q, a), Euclidean(q, b))
+		}))
+
+		// Box query
+		hi := pnd(q.Coordinates[0]+i%15, q.Coordinates[1]+i%9, q.Coordinates[2]+i%6)
+		var inside []PointND[int]
+		for _, p := range points {
+			if p.Coordinates[0] >= q.Coordinates[0] && p.Coordinates[0] <= hi.Coordinates[0] &&
+				p.Coordinates[1] >= q.Coordinates[1] && p.Coordinates[1] <= hi.Coordinates[1] &&
+				p.Coordinates[2] >= q.Coordinates[2] && p.Coordinates[2] <= hi.Coordinates[2] {
+				inside = append(inside, p)
+			}
+		}
+
+		if inside == nil {
+			inside = []PointND[int]{}
+		}
+
+		assert.ElementsMatch(t, inside, tree.Range(q, hi), "Range(%s, %s)", q, hi)
+	}
+
+	assert.Equal(t, []PointND[int]{}, tree.Radius(pnd(1000, 1000, 1000), math.SmallestNonzeroFloat64))
+}
+
+func TestKDTree_Panic(t *testing.T) {
+	tree := NewKDTree(2, generic.NewCompareFunc[int](), Euclidean[int])
+
+	assert.PanicsWithValue(t, "invalid point: (1, 2, 3) is not 2-dimensional", func() {
+		tree.Insert(pnd(1, 2, 3))
+	})
+
+	assert.PanicsWithValue(t, "invalid point: (1) is not 2-dimensional", func() {
+		NewKDTree(2, generic.NewCompareFunc[int](), Euclidean[int], pnd(1))
+	})
+
+	assert.PanicsWithValue(t, "invalid dimension: 0 is not positive", func() {
+		NewKDTree(0, generic.NewCompareFunc[int](), Euclidean[int])
+	})
+}
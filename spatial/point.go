@@ -1,21 +1,100 @@
 package spatial
 
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Numeric represents numerical types that can be used as point coordinates for measuring distances.
+type Numeric interface {
+	constraints.Integer | constraints.Float
+}
+
 // Point1D represents a point in one-dimensional space.
 type Point1D[T any] struct {
 	X T
 }
 
+// ND converts the point to a point in n-dimensional space.
+func (p Point1D[T]) ND() PointND[T] {
+	return PointND[T]{Coordinates: []T{p.X}}
+}
+
 // Point2D represents a point in two-dimensional space.
 type Point2D[T any] struct {
 	X, Y T
 }
 
+// ND converts the point to a point in n-dimensional space.
+func (p Point2D[T]) ND() PointND[T] {
+	return PointND[T]{Coordinates: []T{p.X, p.Y}}
+}
+
 // Point3D represents a point in three-dimensional space.
 type Point3D[T any] struct {
 	X, Y, Z T
 }
 
+// ND converts the point to a point in n-dimensional space.
+func (p Point3D[T]) ND() PointND[T] {
+	return PointND[T]{Coordinates: []T{p.X, p.Y, p.Z}}
+}
+
 // PointND represents a point in n-dimensional space.
 type PointND[T any] struct {
 	Coordinates []T
 }
+
+// Dimension returns the number of coordinates of the point.
+func (p PointND[T]) Dimension() int {
+	return len(p.Coordinates)
+}
+
+// String implements the fmt.Stringer interface.
+func (p PointND[T]) String() string {
+	coordinates := make([]string, len(p.Coordinates))
+	for i, c := range p.Coordinates {
+		coordinates[i] = fmt.Sprintf("%v", c)
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(coordinates, ", "))
+}
+
+// DistanceFunc is a generic function type for measuring the distance between two points.
+type DistanceFunc[T any] func(PointND[T], PointND[T]) float64
+
+// Euclidean returns the Euclidean (L₂) distance between two points.
+func Euclidean[T Numeric](a, b PointND[T]) float64 {
+	var sum float64
+	for i := range a.Coordinates {
+		d := float64(a.Coordinates[i]) - float64(b.Coordinates[i])
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Manhattan returns the Manhattan (L₁) distance between two points.
+func Manhattan[T Numeric](a, b PointND[T]) float64 {
+	var sum float64
+	for i := range a.Coordinates {
+		sum += math.Abs(float64(a.Coordinates[i]) - float64(b.Coordinates[i]))
+	}
+
+	return sum
+}
+
+// Chebyshev returns the Chebyshev (L∞) distance between two points.
+func Chebyshev[T Numeric](a, b PointND[T]) float64 {
+	var max float64
+	for i := range a.Coordinates {
+		if d := math.Abs(float64(a.Coordinates[i]) - float64(b.Coordinates[i])); d > max {
+			max = d
+		}
+	}
+
+	return max
+}
@@ -1 +1,101 @@
 package spatial
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoint_ND(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             interface{ ND() PointND[int] }
+		expectedPoint PointND[int]
+	}{
+		{
+			name:          "Point1D",
+			p:             Point1D[int]{X: 1},
+			expectedPoint: PointND[int]{Coordinates: []int{1}},
+		},
+		{
+			name:          "Point2D",
+			p:             Point2D[int]{X: 1, Y: 2},
+			expectedPoint: PointND[int]{Coordinates: []int{1, 2}},
+		},
+		{
+			name:          "Point3D",
+			p:             Point3D[int]{X: 1, Y: 2, Z: 3},
+			expectedPoint: PointND[int]{Coordinates: []int{1, 2, 3}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPoint, tc.p.ND())
+		})
+	}
+}
+
+func TestPointND(t *testing.T) {
+	tests := []struct {
+		name              string
+		p                 PointND[float64]
+		expectedDimension int
+		expectedString    string
+	}{
+		{
+			name:              "Empty",
+			p:                 PointND[float64]{},
+			expectedDimension: 0,
+			expectedString:    "()",
+		},
+		{
+			name:              "OK",
+			p:                 PointND[float64]{Coordinates: []float64{1.5, -2, 3}},
+			expectedDimension: 3,
+			expectedString:    "(1.5, -2, 3)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDimension, tc.p.Dimension())
+			assert.Equal(t, tc.expectedString, tc.p.String())
+		})
+	}
+}
+
+func TestDistanceFunc(t *testing.T) {
+	tests := []struct {
+		name              string
+		a, b              PointND[int]
+		expectedEuclidean float64
+		expectedManhattan float64
+		expectedChebyshev float64
+	}{
+		{
+			name:              "Same",
+			a:                 PointND[int]{Coordinates: []int{1, 2}},
+			b:                 PointND[int]{Coordinates: []int{1, 2}},
+			expectedEuclidean: 0,
+			expectedManhattan: 0,
+			expectedChebyshev: 0,
+		},
+		{
+			name:              "Different",
+			a:                 PointND[int]{Coordinates: []int{1, 2, 3}},
+			b:                 PointND[int]{Coordinates: []int{4, -2, 3}},
+			expectedEuclidean: 5,
+			expectedManhattan: 7,
+			expectedChebyshev: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedEuclidean, Euclidean(tc.a, tc.b))
+			assert.Equal(t, tc.expectedManhattan, Manhattan(tc.a, tc.b))
+			assert.Equal(t, tc.expectedChebyshev, Chebyshev(tc.a, tc.b))
+		})
+	}
+}
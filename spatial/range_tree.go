@@ -1,6 +1,173 @@
 package spatial
 
-// nolint: unused
-type rangeTreeNode struct{}
+import (
+	"fmt"
+	"slices"
 
-type RangeTree struct{}
+	"github.com/moorara/algo/generic"
+)
+
+type rangeTreeNode[T any] struct {
+	point       PointND[T]
+	min, max    T // The minimum and maximum coordinates in the subtree along the dimension of the tree.
+	left, right *rangeTreeNode[T]
+	size        int
+	assoc       *rangeTreeNode[T] // The associated tree on the next dimension for all points in the subtree.
+}
+
+// RangeTree is a static multi-level balanced binary search tree for orthogonal range searching.
+//
+// The first level is a balanced binary search tree on the first coordinate of the points.
+// Each node has an associated range tree on the next coordinate
+// holding all points stored in the subtree rooted at that node.
+//
+// A range tree on n points in d dimensions uses O(n logᵈ⁻¹ n) space and is built in O(n logᵈ n) time.
+// An axis-aligned box query is answered in O(logᵈ n + k) time, where k is the number of reported points.
+type RangeTree[T any] struct {
+	dim  int
+	root *rangeTreeNode[T]
+	cmp  generic.CompareFunc[T]
+}
+
+// NewRangeTree creates a new range tree for a set of points in d-dimensional space.
+//
+// It panics if any of the points does not have exactly d coordinates.
+func NewRangeTree[T any](d int, cmp generic.CompareFunc[T], points ...PointND[T]) *RangeTree[T] {
+	for _, p := range points {
+		if len(p.Coordinates) != d {
+			panic(fmt.Sprintf("invalid point: %v is not %d-dimensional", p.Coordinates, d))
+		}
+	}
+
+	t := &RangeTree[T]{
+		dim: d,
+		cmp: cmp,
+	}
+
+	if d > 0 {
+		t.root = t.build(slices.Clone(points), 0)
+	}
+
+	return t
+}
+
+// build constructs a range tree on dimension d for a set of points.
+func (t *RangeTree[T]) build(points []PointND[T], d int) *rangeTreeNode[T] {
+	slices.SortFunc(points, func(a, b PointND[T]) int {
+		return t.cmp(a.Coordinates[d], b.Coordinates[d])
+	})
+
+	return t._build(points, d)
+}
+
+// _build constructs a balanced binary search tree from a set of points sorted on dimension d.
+func (t *RangeTree[T]) _build(points []PointND[T], d int) *rangeTreeNode[T] {
+	if len(points) == 0 {
+		return nil
+	}
+
+	mid := len(points) / 2
+
+	n := &rangeTreeNode[T]{
+		point: points[mid],
+		min:   points[0].Coordinates[d],
+		max:   points[len(points)-1].Coordinates[d],
+		left:  t._build(points[:mid], d),
+		right: t._build(points[mid+1:], d),
+		size:  len(points),
+	}
+
+	if d+1 < t.dim {
+		n.assoc = t.build(slices.Clone(points), d+1)
+	}
+
+	return n
+}
+
+// Dimension returns the number of dimensions of the points in the range tree.
+func (t *RangeTree[T]) Dimension() int {
+	return t.dim
+}
+
+// Size returns the number of points in the range tree.
+func (t *RangeTree[T]) Size() int {
+	if t.root == nil {
+		return 0
+	}
+
+	return t.root.size
+}
+
+// IsEmpty returns true if the range tree is empty.
+func (t *RangeTree[T]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Query returns all points in the range tree inside the axis-aligned box defined by two corner points.
+// The box is closed, i.e., the points on its boundary are included.
+// The order of the returned points is unspecified.
+//
+// It panics if any of the corner points does not have the same dimension as the range tree.
+func (t *RangeTree[T]) Query(lo, hi PointND[T]) []PointND[T] {
+	if len(lo.Coordinates) != t.dim || len(hi.Coordinates) != t.dim {
+		panic(fmt.Sprintf("invalid box: %v and %v are not %d-dimensional", lo.Coordinates, hi.Coordinates, t.dim))
+	}
+
+	points := make([]PointND[T], 0)
+	t._query(t.root, 0, lo, hi, &points)
+
+	return points
+}
+
+func (t *RangeTree[T]) _query(n *rangeTreeNode[T], d int, lo, hi PointND[T], points *[]PointND[T]) {
+	if n == nil {
+		return
+	}
+
+	l, h := lo.Coordinates[d], hi.Coordinates[d]
+
+	// The subtree is entirely outside the box along dimension d.
+	if t.cmp(n.max, l) < 0 || t.cmp(h, n.min) < 0 {
+		return
+	}
+
+	// The subtree is entirely inside the box along dimension d.
+	if t.cmp(l, n.min) <= 0 && t.cmp(n.max, h) <= 0 {
+		if d+1 < t.dim {
+			t._query(n.assoc, d+1, lo, hi, points)
+		} else {
+			t.collect(n, points)
+		}
+		return
+	}
+
+	t._query(n.left, d, lo, hi, points)
+
+	if t.contains(n.point, d, lo, hi) {
+		*points = append(*points, n.point)
+	}
+
+	t._query(n.right, d, lo, hi, points)
+}
+
+// contains determines whether or not a point lies inside the box along dimensions d and higher.
+func (t *RangeTree[T]) contains(p PointND[T], d int, lo, hi PointND[T]) bool {
+	for i := d; i < t.dim; i++ {
+		if t.cmp(p.Coordinates[i], lo.Coordinates[i]) < 0 || t.cmp(hi.Coordinates[i], p.Coordinates[i]) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// collect appends all points in a subtree to a list.
+func (t *RangeTree[T]) collect(n *rangeTreeNode[T], points *[]PointND[T]) {
+	if n == nil {
+		return
+	}
+
+	t.collect(n.left, points)
+	*points = append(*points, n.point)
+	t.collect(n.right, points)
+}
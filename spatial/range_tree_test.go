@@ -1 +1,143 @@
 package spatial
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/algo/generic"
+)
+
+func pnd(coordinates ...int) PointND[int] {
+	return PointND[int]{Coordinates: coordinates}
+}
+
+func TestRangeTree(t *testing.T) {
+	type query struct {
+		lo, hi         PointND[int]
+		expectedPoints []PointND[int]
+	}
+
+	tests := []struct {
+		name              string
+		d                 int
+		points            []PointND[int]
+		expectedDimension int
+		expectedSize      int
+		expectedIsEmpty   bool
+		queries           []query
+	}{
+		{
+			name:              "Empty",
+			d:                 2,
+			points:            []PointND[int]{},
+			expectedDimension: 2,
+			expectedSize:      0,
+			expectedIsEmpty:   true,
+			queries: []query{
+				{lo: pnd(0, 0), hi: pnd(10, 10), expectedPoints: []PointND[int]{}},
+			},
+		},
+		{
+			name:              "1D",
+			d:                 1,
+			points:            []PointND[int]{pnd(7), pnd(2), pnd(9), pnd(4), pnd(4)},
+			expectedDimension: 1,
+			expectedSize:      5,
+			expectedIsEmpty:   false,
+			queries: []query{
+				{lo: pnd(3), hi: pnd(7), expectedPoints: []PointND[int]{pnd(4), pnd(4), pnd(7)}},
+				{lo: pnd(10), hi: pnd(20), expectedPoints: []PointND[int]{}},
+			},
+		},
+		{
+			name: "2D",
+			d:    2,
+			points: []PointND[int]{
+				pnd(2, 3), pnd(5, 4), pnd(9, 6), pnd(4, 7), pnd(8, 1), pnd(7, 2), pnd(5, 5), pnd(3, 9),
+			},
+			expectedDimension: 2,
+			expectedSize:      8,
+			expectedIsEmpty:   false,
+			queries: []query{
+				{lo: pnd(0, 0), hi: pnd(10, 10), expectedPoints: []PointND[int]{pnd(2, 3), pnd(5, 4), pnd(9, 6), pnd(4, 7), pnd(8, 1), pnd(7, 2), pnd(5, 5), pnd(3, 9)}},
+				{lo: pnd(3, 2), hi: pnd(7, 5), expectedPoints: []PointND[int]{pnd(5, 4), pnd(7, 2), pnd(5, 5)}},
+				{lo: pnd(5, 5), hi: pnd(5, 5), expectedPoints: []PointND[int]{pnd(5, 5)}},
+				{lo: pnd(6, 7), hi: pnd(9, 9), expectedPoints: []PointND[int]{}},
+				{lo: pnd(7, 0), hi: pnd(3, 10), expectedPoints: []PointND[int]{}},
+			},
+		},
+		{
+			name: "3D",
+			d:    3,
+			points: []PointND[int]{
+				pnd(1, 2, 3), pnd(4, 5, 6), pnd(7, 8, 9), pnd(2, 8, 4), pnd(6, 1, 7), pnd(3, 3, 3),
+			},
+			expectedDimension: 3,
+			expectedSize:      6,
+			expectedIsEmpty:   false,
+			queries: []query{
+				{lo: pnd(1, 1, 1), hi: pnd(4, 5, 6), expectedPoints: []PointND[int]{pnd(1, 2, 3), pnd(4, 5, 6), pnd(3, 3, 3)}},
+				{lo: pnd(2, 0, 4), hi: pnd(8, 9, 9), expectedPoints: []PointND[int]{pnd(4, 5, 6), pnd(7, 8, 9), pnd(2, 8, 4), pnd(6, 1, 7)}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewRangeTree(tc.d, generic.NewCompareFunc[int](), tc.points...)
+
+			assert.Equal(t, tc.expectedDimension, tree.Dimension())
+			assert.Equal(t, tc.expectedSize, tree.Size())
+			assert.Equal(t, tc.expectedIsEmpty, tree.IsEmpty())
+
+			for _, q := range tc.queries {
+				assert.ElementsMatch(t, q.expectedPoints, tree.Query(q.lo, q.hi), "Query(%v, %v)", q.lo, q.hi)
+			}
+		})
+	}
+}
+
+func TestRangeTree_BruteForce(t *testing.T) {
+	points := make([]PointND[int], 0)
+	for i := range 150 {
+		points = append(points, pnd((i*37)%41, (i*13)%29, (i*7)%11))
+	}
+
+	tree := NewRangeTree(3, generic.NewCompareFunc[int](), points...)
+
+	for i := range 50 {
+		lo := pnd((i*11)%41, (i*5)%29, i%11)
+		hi := pnd(lo.Coordinates[0]+i%17, lo.Coordinates[1]+i%13, lo.Coordinates[2]+i%5)
+
+		expected := make([]PointND[int], 0)
+		for _, p := range points {
+			inside := true
+			for d := range 3 {
+				if p.Coordinates[d] < lo.Coordinates[d] || p.Coordinates[d] > hi.Coordinates[d] {
+					inside = false
+				}
+			}
+
+			if inside {
+				expected = append(expected, p)
+			}
+		}
+
+		assert.ElementsMatch(t, expected, tree.Query(lo, hi), "Query(%v, %v)", lo, hi)
+	}
+}
+
+func TestRangeTree_Panic(t *testing.T) {
+	cmp := generic.NewCompareFunc[int]()
+
+	assert.PanicsWithValue(t, "invalid point: [1 2 3] is not 2-dimensional", func() {
+		NewRangeTree(2, cmp, pnd(1, 2), pnd(1, 2, 3))
+	})
+
+	tree := NewRangeTree(2, cmp, pnd(1, 2))
+
+	assert.PanicsWithValue(t, "invalid box: [0] and [5 5] are not 2-dimensional", func() {
+		tree.Query(pnd(0), pnd(5, 5))
+	})
+}
@@ -1 +1,223 @@
 package spatial
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// AggregateFunc is an associative binary function for combining the values of two adjacent segments.
+	// Common examples are sum, minimum, maximum, and greatest common divisor.
+	AggregateFunc[T any] func(T, T) T
+
+	// ApplyFunc applies an update to the aggregated value of a segment containing n elements.
+	// For example, adding u to every element of a segment changes its sum by u × n.
+	ApplyFunc[T, U any] func(val T, upd U, n int) T
+
+	// ComposeFunc combines two updates into a single one.
+	// Applying the result must be equivalent to applying the first update and then the second one.
+	ComposeFunc[U any] func(first, second U) U
+)
+
+// SegmentTree is a binary tree for answering range queries over a sequence of values.
+//
+// Each node stores the aggregate of a contiguous segment of the sequence.
+// Range updates are propagated lazily: an update is recorded on the nodes covering the updated range
+// and pushed down to their children only when the children are visited.
+type SegmentTree[T, U any] struct {
+	n        int
+	tree     []T
+	lazy     []U
+	pending  []bool
+	identity T
+	agg      AggregateFunc[T]
+	apply    ApplyFunc[T, U]
+	compose  ComposeFunc[U]
+}
+
+// NewSegmentTree creates a new segment tree from a sequence of values.
+//
+// The identity value must satisfy agg(identity, v) = agg(v, identity) = v for every value v.
+// The apply and compose functions are needed only if you want to use the Update method.
+//
+// Building the tree takes O(n) time. Get, Set, Query, and Update run in O(log n) time.
+func NewSegmentTree[T, U any](vals []T, identity T, agg AggregateFunc[T], apply ApplyFunc[T, U], compose ComposeFunc[U]) *SegmentTree[T, U] {
+	n := len(vals)
+
+	t := &SegmentTree[T, U]{
+		n:        n,
+		tree:     make([]T, 4*n),
+		lazy:     make([]U, 4*n),
+		pending:  make([]bool, 4*n),
+		identity: identity,
+		agg:      agg,
+		apply:    apply,
+		compose:  compose,
+	}
+
+	if n > 0 {
+		t.build(vals, 1, 0, n-1)
+	}
+
+	return t
+}
+
+func (t *SegmentTree[T, U]) build(vals []T, node, lo, hi int) {
+	if lo == hi {
+		t.tree[node] = vals[lo]
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	t.build(vals, 2*node, lo, mid)
+	t.build(vals, 2*node+1, mid+1, hi)
+	t.tree[node] = t.agg(t.tree[2*node], t.tree[2*node+1])
+}
+
+// mark records an update on a node covering n elements.
+func (t *SegmentTree[T, U]) mark(node, n int, upd U) {
+	t.tree[node] = t.apply(t.tree[node], upd, n)
+
+	if t.pending[node] {
+		t.lazy[node] = t.compose(t.lazy[node], upd)
+	} else {
+		t.lazy[node] = upd
+		t.pending[node] = true
+	}
+}
+
+// push propagates the pending update of a node to its children.
+func (t *SegmentTree[T, U]) push(node, lo, hi int) {
+	if !t.pending[node] {
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	t.mark(2*node, mid-lo+1, t.lazy[node])
+	t.mark(2*node+1, hi-mid, t.lazy[node])
+
+	var zeroU U
+	t.lazy[node] = zeroU
+	t.pending[node] = false
+}
+
+func (t *SegmentTree[T, U]) isValid(lo, hi int) bool {
+	return 0 <= lo && lo <= hi && hi < t.n
+}
+
+// Size returns the number of values in the segment tree.
+func (t *SegmentTree[T, U]) Size() int {
+	return t.n
+}
+
+// Get returns the value at a given index.
+func (t *SegmentTree[T, U]) Get(i int) (T, bool) {
+	return t.Query(i, i)
+}
+
+// Set replaces the value at a given index.
+// It returns false if the index is out of range.
+func (t *SegmentTree[T, U]) Set(i int, val T) bool {
+	if !t.isValid(i, i) {
+		return false
+	}
+
+	t._set(1, 0, t.n-1, i, val)
+
+	return true
+}
+
+func (t *SegmentTree[T, U]) _set(node, lo, hi, i int, val T) {
+	if lo == hi {
+		t.tree[node] = val
+		return
+	}
+
+	t.push(node, lo, hi)
+
+	mid := lo + (hi-lo)/2
+	if i <= mid {
+		t._set(2*node, lo, mid, i, val)
+	} else {
+		t._set(2*node+1, mid+1, hi, i, val)
+	}
+
+	t.tree[node] = t.agg(t.tree[2*node], t.tree[2*node+1])
+}
+
+// Query returns the aggregate of all values between two given indices (inclusive).
+// It returns false if the range is empty or out of range.
+func (t *SegmentTree[T, U]) Query(lo, hi int) (T, bool) {
+	if !t.isValid(lo, hi) {
+		var zeroT T
+		return zeroT, false
+	}
+
+	return t._query(1, 0, t.n-1, lo, hi), true
+}
+
+func (t *SegmentTree[T, U]) _query(node, lo, hi, qlo, qhi int) T {
+	if qhi < lo || hi < qlo {
+		return t.identity
+	}
+
+	if qlo <= lo && hi <= qhi {
+		return t.tree[node]
+	}
+
+	t.push(node, lo, hi)
+
+	mid := lo + (hi-lo)/2
+	left := t._query(2*node, lo, mid, qlo, qhi)
+	right := t._query(2*node+1, mid+1, hi, qlo, qhi)
+
+	return t.agg(left, right)
+}
+
+// Update applies an update to all values between two given indices (inclusive).
+// It returns false if the range is empty or out of range.
+//
+// It panics if the segment tree was created without the apply and compose functions.
+func (t *SegmentTree[T, U]) Update(lo, hi int, upd U) bool {
+	if t.apply == nil || t.compose == nil {
+		panic("segment tree does not support range updates")
+	}
+
+	if !t.isValid(lo, hi) {
+		return false
+	}
+
+	t._update(1, 0, t.n-1, lo, hi, upd)
+
+	return true
+}
+
+func (t *SegmentTree[T, U]) _update(node, lo, hi, qlo, qhi int, upd U) {
+	if qhi < lo || hi < qlo {
+		return
+	}
+
+	if qlo <= lo && hi <= qhi {
+		t.mark(node, hi-lo+1, upd)
+		return
+	}
+
+	t.push(node, lo, hi)
+
+	mid := lo + (hi-lo)/2
+	t._update(2*node, lo, mid, qlo, qhi, upd)
+	t._update(2*node+1, mid+1, hi, qlo, qhi, upd)
+
+	t.tree[node] = t.agg(t.tree[2*node], t.tree[2*node+1])
+}
+
+// String returns a string representation of the values in the segment tree.
+func (t *SegmentTree[T, U]) String() string {
+	vals := make([]string, t.n)
+	for i := range t.n {
+		v, _ := t.Get(i)
+		vals[i] = fmt.Sprintf("%v", v)
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(vals, " "))
+}
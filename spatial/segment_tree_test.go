@@ -1 +1,194 @@
 package spatial
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sum(a, b int) int {
+	return a + b
+}
+
+func addToSum(val, upd, n int) int {
+	return val + upd*n
+}
+
+func minimum(a, b int) int {
+	return min(a, b)
+}
+
+func assignToMin(_, upd, _ int) int {
+	return upd
+}
+
+func overwrite(_, second int) int {
+	return second
+}
+
+func TestSegmentTree(t *testing.T) {
+	type query struct {
+		lo, hi      int
+		expectedVal int
+		expectedOK  bool
+	}
+
+	type update struct {
+		lo, hi     int
+		upd        int
+		expectedOK bool
+	}
+
+	tests := []struct {
+		name           string
+		vals           []int
+		identity       int
+		agg            AggregateFunc[int]
+		apply          ApplyFunc[int, int]
+		compose        ComposeFunc[int]
+		updates        []update
+		sets           map[int]int
+		expectedSize   int
+		expectedString string
+		queries        []query
+	}{
+		{
+			name:           "Empty",
+			vals:           []int{},
+			identity:       0,
+			agg:            sum,
+			apply:          addToSum,
+			compose:        sum,
+			updates:        []update{{lo: 0, hi: 0, upd: 1, expectedOK: false}},
+			expectedSize:   0,
+			expectedString: "[]",
+			queries: []query{
+				{lo: 0, hi: 0, expectedVal: 0, expectedOK: false},
+			},
+		},
+		{
+			name:     "RangeSum",
+			vals:     []int{5, 8, 6, 3, 2, 7, 2, 6},
+			identity: 0,
+			agg:      sum,
+			apply:    addToSum,
+			compose:  sum,
+			updates: []update{
+				{lo: 1, hi: 4, upd: 10, expectedOK: true},
+				{lo: 3, hi: 7, upd: -2, expectedOK: true},
+				{lo: 4, hi: 2, upd: 1, expectedOK: false},
+				{lo: -1, hi: 2, upd: 1, expectedOK: false},
+				{lo: 6, hi: 8, upd: 1, expectedOK: false},
+			},
+			sets:           map[int]int{0: 1},
+			expectedSize:   8,
+			expectedString: "[1 18 16 11 10 5 0 4]",
+			queries: []query{
+				{lo: 0, hi: 7, expectedVal: 65, expectedOK: true},
+				{lo: 2, hi: 5, expectedVal: 42, expectedOK: true},
+				{lo: 6, hi: 6, expectedVal: 0, expectedOK: true},
+				{lo: 5, hi: 4, expectedVal: 0, expectedOK: false},
+				{lo: 0, hi: 8, expectedVal: 0, expectedOK: false},
+			},
+		},
+		{
+			name:     "RangeMin",
+			vals:     []int{9, 4, 7, 1, 8, 3},
+			identity: math.MaxInt,
+			agg:      minimum,
+			apply:    assignToMin,
+			compose:  overwrite,
+			updates: []update{
+				{lo: 2, hi: 4, upd: 6, expectedOK: true},
+				{lo: 3, hi: 3, upd: 5, expectedOK: true},
+			},
+			sets:           map[int]int{1: 10},
+			expectedSize:   6,
+			expectedString: "[9 10 6 5 6 3]",
+			queries: []query{
+				{lo: 0, hi: 5, expectedVal: 3, expectedOK: true},
+				{lo: 0, hi: 4, expectedVal: 5, expectedOK: true},
+				{lo: 0, hi: 1, expectedVal: 9, expectedOK: true},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			st := NewSegmentTree(tc.vals, tc.identity, tc.agg, tc.apply, tc.compose)
+
+			for _, u := range tc.updates {
+				assert.Equal(t, u.expectedOK, st.Update(u.lo, u.hi, u.upd))
+			}
+
+			for i, v := range tc.sets {
+				assert.True(t, st.Set(i, v))
+			}
+
+			assert.False(t, st.Set(tc.expectedSize, 0))
+			assert.Equal(t, tc.expectedSize, st.Size())
+			assert.Equal(t, tc.expectedString, st.String())
+
+			for _, q := range tc.queries {
+				val, ok := st.Query(q.lo, q.hi)
+				assert.Equal(t, q.expectedVal, val, "Query(%d, %d)", q.lo, q.hi)
+				assert.Equal(t, q.expectedOK, ok, "Query(%d, %d)", q.lo, q.hi)
+			}
+		})
+	}
+}
+
+func TestSegmentTree_BruteForce(t *testing.T) {
+	n := 37
+	vals := make([]int, n)
+	for i := range vals {
+		vals[i] = (i * 7) % 11
+	}
+
+	st := NewSegmentTree(vals, 0, sum, addToSum, sum)
+
+	for step := range 200 {
+		lo := (step * 13) % n
+		hi := lo + (step*5)%(n-lo)
+
+		if step%3 == 0 {
+			i, v := (step*17)%n, step%9
+			vals[i] = v
+			assert.True(t, st.Set(i, v))
+		} else {
+			upd := step%7 - 3
+			for i := lo; i <= hi; i++ {
+				vals[i] += upd
+			}
+			assert.True(t, st.Update(lo, hi, upd))
+		}
+
+		expected := 0
+		for i := lo; i <= hi; i++ {
+			expected += vals[i]
+		}
+
+		val, ok := st.Query(lo, hi)
+		assert.True(t, ok)
+		assert.Equal(t, expected, val, "Query(%d, %d)", lo, hi)
+	}
+
+	for i, v := range vals {
+		val, ok := st.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, v, val)
+	}
+}
+
+func TestSegmentTree_Panic(t *testing.T) {
+	st := NewSegmentTree[int, int]([]int{1, 2, 3}, 0, sum, nil, nil)
+
+	val, ok := st.Query(0, 2)
+	assert.True(t, ok)
+	assert.Equal(t, 6, val)
+
+	assert.PanicsWithValue(t, "segment tree does not support range updates", func() {
+		st.Update(0, 2, 1)
+	})
+}